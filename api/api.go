@@ -0,0 +1,136 @@
+// Package api implements a read-only HTTP JSON API over the data store.
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+
+	"github.com/liclac/gubal/lib"
+	"github.com/liclac/gubal/models"
+)
+
+var tracer = otel.Tracer("github.com/liclac/gubal/api")
+
+// Pagination limits, for endpoints that take ?limit=.
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// An Error is an error with an associated HTTP status code, which is shown to the client.
+// Any other errors returned from handlers are logged and turned into a 500.
+type Error struct {
+	Status  int
+	Message string
+}
+
+func (e Error) Error() string { return e.Message }
+
+// errorf is a shorthand for creating an Error.
+func errorf(status int, format string, args ...interface{}) error {
+	return Error{status, fmt.Sprintf(format, args...)}
+}
+
+// A Page is a page of results, with a link to the next page if there may be one.
+type Page struct {
+	Items interface{} `json:"items"`
+	Next  string      `json:"next,omitempty"`
+}
+
+// NewHandler returns a handler serving the API, backed by the given data store. Jobs requested
+// through the API are published to pub; if it's nil, such requests are refused.
+func NewHandler(ds models.DataStore, pub lib.Publisher) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /characters", handlerFunc(searchCharacters))
+	mux.Handle("GET /characters/{id}", handlerFunc(getCharacter))
+	mux.Handle("GET /characters/{id}/levels", handlerFunc(getCharacterLevels))
+	mux.Handle("POST /characters/{id}/refresh", refreshCharacter(pub))
+	mux.Handle("GET /tombstones/{id}", handlerFunc(getTombstone))
+	mux.Handle("GET /titles", handlerFunc(listTitles))
+	mux.Handle("GET /titles/{id}", handlerFunc(getTitle))
+	mux.Handle("POST /graphql", newGraphQLHandler())
+	registerStats(mux)
+	mux.Handle("GET /census", handlerFunc(listCensusSnapshots))
+	mux.Handle("GET /census/{metric}", handlerFunc(getCensusTrend))
+	mux.Handle("/", handlerFunc(func(rw http.ResponseWriter, req *http.Request) error {
+		return errorf(http.StatusNotFound, "not found")
+	}))
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mux.ServeHTTP(rw, req.WithContext(models.WithDataStore(req.Context(), ds)))
+	})
+}
+
+// handlerFunc is an http.Handler that can return an error, which is turned into a JSON response.
+type handlerFunc func(rw http.ResponseWriter, req *http.Request) error
+
+func (fn handlerFunc) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	err := fn(rw, req)
+	if err == nil {
+		return
+	}
+
+	apiErr, ok := err.(Error)
+	switch {
+	case ok:
+	case gorm.IsRecordNotFoundError(err):
+		apiErr = Error{http.StatusNotFound, "not found"}
+	default:
+		lib.GetLogger(req.Context()).Error("Internal server error",
+			zap.String("method", req.Method),
+			zap.Stringer("url", req.URL),
+			zap.Error(err),
+		)
+		apiErr = Error{http.StatusInternalServerError, "internal server error"}
+	}
+	if err := writeJSON(rw, apiErr.Status, map[string]string{"error": apiErr.Message}); err != nil {
+		lib.GetLogger(req.Context()).Error("Couldn't write error response", zap.Error(err))
+	}
+}
+
+// writeJSON writes v to the response as JSON, with the given status code.
+func writeJSON(rw http.ResponseWriter, status int, v interface{}) error {
+	rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+	rw.WriteHeader(status)
+	return json.NewEncoder(rw).Encode(v)
+}
+
+// pathInt64 parses an integer path parameter, eg. {id}.
+func pathInt64(req *http.Request, name string) (int64, error) {
+	v, err := strconv.ParseInt(req.PathValue(name), 10, 64)
+	if err != nil {
+		return 0, errorf(http.StatusBadRequest, "invalid %s: %s", name, req.PathValue(name))
+	}
+	return v, nil
+}
+
+// pagination parses the ?after= and ?limit= query parameters used for keyset pagination.
+// What after means is up to the endpoint, eg. an ID or an opaque cursor.
+func pagination(q url.Values) (after string, limit int, err error) {
+	limit = DefaultLimit
+	if s := q.Get("limit"); s != "" {
+		l, err := strconv.Atoi(s)
+		if err != nil || l < 1 {
+			return "", 0, errorf(http.StatusBadRequest, "invalid limit: %s", s)
+		}
+		limit = l
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	return q.Get("after"), limit, nil
+}
+
+// nextPage returns the URL for the page after the given key, preserving any other parameters.
+func nextPage(req *http.Request, after string) string {
+	q := req.URL.Query()
+	q.Set("after", after)
+	u := url.URL{Path: req.URL.Path, RawQuery: q.Encode()}
+	return u.String()
+}
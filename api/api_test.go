@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liclac/gubal/models"
+)
+
+// doRequest performs a GET request against the handler, and decodes the response into v.
+func doRequest(t *testing.T, h http.Handler, path string, v interface{}) *httptest.ResponseRecorder {
+	return doMethodRequest(t, h, "GET", path, v)
+}
+
+// doMethodRequest is like doRequest, but with an arbitrary method.
+func doMethodRequest(t *testing.T, h http.Handler, method, path string, v interface{}) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(method, path, nil))
+	assert.Equal(t, "application/json; charset=utf-8", rw.Header().Get("Content-Type"))
+	if v != nil {
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), v), rw.Body.String())
+	}
+	return rw
+}
+
+func TestNotFound(t *testing.T) {
+	h := NewHandler(models.NewMemoryDataStore(), nil)
+
+	var body map[string]string
+	rw := doRequest(t, h, "/nothing/here", &body)
+	assert.Equal(t, http.StatusNotFound, rw.Code)
+	assert.Equal(t, map[string]string{"error": "not found"}, body)
+}
+
+func TestPagination(t *testing.T) {
+	for path, expect := range map[string]struct {
+		After string
+		Limit int
+		Err   string
+	}{
+		"/titles":                 {"", DefaultLimit, ""},
+		"/titles?after=5&limit=2": {"5", 2, ""},
+		"/titles?limit=100000":    {"", MaxLimit, ""},
+		"/titles?limit=0":         {"", 0, "invalid limit: 0"},
+		"/titles?limit=x":         {"", 0, "invalid limit: x"},
+	} {
+		t.Run(path, func(t *testing.T) {
+			after, limit, err := pagination(httptest.NewRequest("GET", path, nil).URL.Query())
+			if expect.Err != "" {
+				assert.EqualError(t, err, expect.Err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, expect.After, after)
+			assert.Equal(t, expect.Limit, limit)
+		})
+	}
+}
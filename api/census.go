@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/liclac/gubal/models"
+)
+
+// censusDateFormat is the format of the ?from= and ?to= query parameters.
+const censusDateFormat = "2006-01-02"
+
+// censusDate parses a date query parameter; it's zero if it's not given.
+func censusDate(q url.Values, name string) (time.Time, error) {
+	s := q.Get(name)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(censusDateFormat, s)
+	if err != nil {
+		return time.Time{}, errorf(http.StatusBadRequest, "invalid %s: %s", name, s)
+	}
+	return t, nil
+}
+
+// listCensusSnapshots serves every census snapshot.
+func listCensusSnapshots(rw http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+	snaps, err := models.GetDataStore(ctx).Census().List(ctx)
+	if err != nil {
+		return err
+	}
+	return writeJSON(rw, http.StatusOK, snaps)
+}
+
+// getCensusTrend serves a metric from every census snapshot, filtered by ?world= and ?dc=, and
+// optionally limited to dates between ?from= and ?to=.
+func getCensusTrend(rw http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+	metric := models.CensusMetric(req.PathValue("metric"))
+	if !metric.Valid() {
+		return errorf(http.StatusNotFound, "unknown metric: %s", metric)
+	}
+	q := req.URL.Query()
+	f, err := statsFilter(q)
+	if err != nil {
+		return err
+	}
+	from, err := censusDate(q, "from")
+	if err != nil {
+		return err
+	}
+	to, err := censusDate(q, "to")
+	if err != nil {
+		return err
+	}
+
+	points, err := models.GetDataStore(ctx).Census().Trend(ctx, metric, f, from, to)
+	if err != nil {
+		return err
+	}
+	return writeJSON(rw, http.StatusOK, points)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liclac/gubal/models"
+)
+
+func TestCensus(t *testing.T) {
+	ctx := context.Background()
+	ds := models.NewMemoryDataStore()
+	h := NewHandler(ds, nil)
+
+	day1 := time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2018, 3, 2, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, ds.Characters().Save(ctx, &models.Character{ID: 1, Race: models.AuRa, World: models.Ultros}))
+	require.NoError(t, ds.Census().Snapshot(ctx, day1))
+	require.NoError(t, ds.Characters().Save(ctx, &models.Character{ID: 2, Race: models.Hyur, World: models.Odin}))
+	require.NoError(t, ds.Census().Snapshot(ctx, day2))
+
+	t.Run("List", func(t *testing.T) {
+		var snaps []models.CensusSnapshot
+		rw := doRequest(t, h, "/census", &snaps)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		require.Len(t, snaps, 2)
+		assert.True(t, day1.Equal(snaps[0].Date))
+		assert.True(t, day2.Equal(snaps[1].Date))
+	})
+
+	t.Run("Trend", func(t *testing.T) {
+		var points []models.CensusPoint
+		rw := doRequest(t, h, "/census/race?dc=Primal", &points)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, []models.CensusPoint{
+			{Date: day1, Value: "AuRa", Count: 1},
+			{Date: day2, Value: "AuRa", Count: 1},
+		}, points)
+
+		points = nil
+		rw = doRequest(t, h, "/census/population?from=2018-03-02", &points)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, []models.CensusPoint{
+			{Date: day2, Value: "Odin", Count: 1},
+			{Date: day2, Value: "Ultros", Count: 1},
+		}, points)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for path, expect := range map[string]Error{
+			"/census/nothing":             {http.StatusNotFound, "unknown metric: nothing"},
+			"/census/race?from=yesterday": {http.StatusBadRequest, "invalid from: yesterday"},
+			"/census/race?to=2018-3-1":    {http.StatusBadRequest, "invalid to: 2018-3-1"},
+			"/census/race?world=Nowhere":  {http.StatusBadRequest, "unknown world: 'Nowhere'"},
+		} {
+			var body map[string]string
+			rw := doRequest(t, h, path, &body)
+			assert.Equal(t, expect.Status, rw.Code, path)
+			assert.Equal(t, expect.Message, body["error"], path)
+		}
+	})
+}
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/liclac/gubal/fetcher"
+	"github.com/liclac/gubal/lib"
+	"github.com/liclac/gubal/models"
+)
+
+// MaxRefreshWait is the longest a refresh request will wait for the character to be fetched.
+const MaxRefreshWait = 60 * time.Second
+
+// refreshPollInterval is how often a waiting refresh request checks if the character's been fetched.
+var refreshPollInterval = 250 * time.Millisecond
+
+// ParseCharacterQuery parses a character search from query parameters. Every field of
+// models.CharacterQuery has a snake_cased parameter, except Levels, which is a repeated ?level=
+// taking ranges like "PLD:50-60", and Desc, which is ?order=desc.
+func ParseCharacterQuery(v url.Values) (models.CharacterQuery, error) {
+	q := models.CharacterQuery{
+		Name:      v.Get("name"),
+		World:     models.World(v.Get("world")),
+		Race:      models.CharacterRace(v.Get("race")),
+		Clan:      models.CharacterClan(v.Get("clan")),
+		Gender:    v.Get("gender"),
+		Guardian:  models.CharacterGuardian(v.Get("guardian")),
+		CityState: models.CityState(v.Get("city_state")),
+		GC:        models.GrandCompany(v.Get("gc")),
+		Title:     v.Get("title"),
+		Sort:      models.CharacterSort(v.Get("sort")),
+	}
+	if s := v.Get("gc_rank"); s != "" {
+		rank, err := strconv.Atoi(s)
+		if err != nil {
+			return q, errorf(http.StatusBadRequest, "invalid gc_rank: %s", s)
+		}
+		q.GCRank = rank
+	}
+	for _, s := range v["level"] {
+		r, err := models.ParseLevelRange(s)
+		if err != nil {
+			return q, errorf(http.StatusBadRequest, "%s", err)
+		}
+		q.Levels = append(q.Levels, r)
+	}
+	switch order := v.Get("order"); order {
+	case "", "asc":
+	case "desc":
+		q.Desc = true
+	default:
+		return q, errorf(http.StatusBadRequest, "invalid order: %s", order)
+	}
+
+	after, limit, err := pagination(v)
+	if err != nil {
+		return q, err
+	}
+	q.After, q.Limit = after, limit
+	if err := q.Validate(); err != nil {
+		return q, errorf(http.StatusBadRequest, "%s", err)
+	}
+	return q, nil
+}
+
+// searchCharacters serves a page of characters matching the query parameters.
+func searchCharacters(rw http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+	q, err := ParseCharacterQuery(req.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	chars, err := models.GetDataStore(ctx).Characters().Search(ctx, q)
+	if err != nil {
+		return err
+	}
+	page := Page{Items: chars}
+	if chars == nil {
+		page.Items = []*models.Character{}
+	}
+	if len(chars) == q.Limit {
+		page.Next = nextPage(req, q.CursorFor(chars[len(chars)-1]))
+	}
+	return writeJSON(rw, http.StatusOK, page)
+}
+
+// getCharacter serves a character, with its title. Characters with tombstones are 410 Gone.
+func getCharacter(rw http.ResponseWriter, req *http.Request) error {
+	id, err := pathInt64(req, "id")
+	if err != nil {
+		return err
+	}
+	ch, err := loadCharacter(req.Context(), id)
+	if err != nil {
+		return err
+	}
+	return writeJSON(rw, http.StatusOK, ch)
+}
+
+// loadCharacter loads a character with its title, or returns a 404 or 410 Error.
+func loadCharacter(ctx context.Context, id int64) (*models.Character, error) {
+	ds := models.GetDataStore(ctx)
+	ch, err := ds.Characters().Get(ctx, id)
+	if gorm.IsRecordNotFoundError(err) {
+		dead, err := ds.CharacterTombstones().Check(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if dead {
+			return nil, errorf(http.StatusGone, "character does not exist")
+		}
+		return nil, errorf(http.StatusNotFound, "character not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ch.TitleID.Valid {
+		if ch.Title, err = ds.CharacterTitles().Get(ctx, int(ch.TitleID.Int64)); err != nil {
+			return nil, err
+		}
+	}
+	return ch, nil
+}
+
+// getCharacterLevels serves all of a character's levels.
+func getCharacterLevels(rw http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+	ds := models.GetDataStore(ctx)
+	id, err := pathInt64(req, "id")
+	if err != nil {
+		return err
+	}
+
+	// Make sure the character exists, so we don't serve an empty list for a nonexistent one.
+	if _, err := ds.Characters().Get(ctx, id); err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return errorf(http.StatusNotFound, "character not found")
+		}
+		return err
+	}
+
+	lvls, err := ds.Levels().List(ctx, id)
+	if err != nil {
+		return err
+	}
+	if lvls == nil {
+		lvls = []*models.Level{}
+	}
+	return writeJSON(rw, http.StatusOK, lvls)
+}
+
+// refreshCharacter enqueues a forced fetch of a character on the priority topic. If ?wait= is given,
+// eg. "?wait=10s", it waits up to that long (or MaxRefreshWait) for the character to be updated, and
+// serves it if it is; otherwise, or if it times out, it responds with 202 Accepted.
+func refreshCharacter(pub lib.Publisher) handlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) (rerr error) {
+		if pub == nil {
+			return errorf(http.StatusServiceUnavailable, "refreshing is not available")
+		}
+
+		// Continue the caller's trace, if any, so it follows the job through to the fetcher.
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+		ctx, span := tracer.Start(ctx, "POST /characters/{id}/refresh", trace.WithSpanKind(trace.SpanKindServer))
+		defer func() { lib.EndSpan(span, rerr) }()
+		ds := models.GetDataStore(ctx)
+		id, err := pathInt64(req, "id")
+		if err != nil {
+			return err
+		}
+		var wait time.Duration
+		if s := req.URL.Query().Get("wait"); s != "" {
+			if wait, err = time.ParseDuration(s); err != nil || wait < 0 {
+				return errorf(http.StatusBadRequest, "invalid wait: %s", s)
+			}
+			if wait > MaxRefreshWait {
+				wait = MaxRefreshWait
+			}
+		}
+
+		// A tombstoned character won't be fetched, so there's no point in waiting for it.
+		dead, err := ds.CharacterTombstones().Check(ctx, id)
+		if err != nil {
+			return err
+		}
+		if dead {
+			return errorf(http.StatusGone, "character does not exist")
+		}
+
+		// Remember when it was last updated, so we can tell when the fetch is done.
+		var since time.Time
+		ch, err := ds.Characters().Get(ctx, id)
+		switch {
+		case err == nil:
+			since = ch.UpdatedAt
+		case !gorm.IsRecordNotFoundError(err):
+			return err
+		}
+
+		body, err := json.Marshal(fetcher.NewFetchMessage(ctx, fetcher.FetchCharacterJob{ID: id, Force: true}))
+		if err != nil {
+			return err
+		}
+		if err := pub.Publish(fetcher.FetchPriorityTopic, body); err != nil {
+			return err
+		}
+		accepted := map[string]string{"status": "queued"}
+		if wait == 0 {
+			return writeJSON(rw, http.StatusAccepted, accepted)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, wait)
+		defer cancel()
+		ticker := time.NewTicker(refreshPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return writeJSON(rw, http.StatusAccepted, accepted)
+			case <-ticker.C:
+			}
+			ch, err := loadCharacter(ctx, id)
+			if apiErr, ok := err.(Error); ok && apiErr.Status == http.StatusNotFound {
+				continue
+			}
+			if ctx.Err() != nil {
+				return writeJSON(rw, http.StatusAccepted, accepted)
+			}
+			if err != nil {
+				return err
+			}
+			if ch.UpdatedAt.After(since) {
+				return writeJSON(rw, http.StatusOK, ch)
+			}
+		}
+	}
+}
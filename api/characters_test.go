@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/liclac/gubal/fetcher"
+	"github.com/liclac/gubal/lib"
+	"github.com/liclac/gubal/models"
+)
+
+func TestCharacters(t *testing.T) {
+	ctx := context.Background()
+	ds := models.NewMemoryDataStore()
+	h := NewHandler(ds, nil)
+
+	title, err := ds.CharacterTitles().GetOrCreate(ctx, "Khloe's Friend")
+	require.NoError(t, err)
+	require.NoError(t, ds.Characters().Save(ctx, &models.Character{
+		ID:        7248246,
+		FirstName: "Emi",
+		LastName:  "Hawke",
+		Race:      models.AuRa,
+		Clan:      models.AuRaRaen,
+		World:     models.Ultros,
+		Title:     title,
+	}))
+	require.NoError(t, ds.Levels().Set(ctx, &models.Level{CharacterID: 7248246, Job: models.SCH, Level: 70}))
+	require.NoError(t, ds.Levels().Set(ctx, &models.Level{CharacterID: 7248246, Job: models.PLD, Level: 62}))
+	require.NoError(t, ds.CharacterTombstones().Create(ctx, 1234))
+
+	t.Run("Search", func(t *testing.T) {
+		require.NoError(t, ds.Characters().Save(ctx, &models.Character{
+			ID:        1000,
+			FirstName: "Emi",
+			LastName:  "Aoki",
+			Race:      models.Hyur,
+			Clan:      models.HyurMidlander,
+			World:     models.Ultros,
+		}))
+
+		type page struct {
+			Items []models.Character `json:"items"`
+			Next  string             `json:"next"`
+		}
+		var p page
+		rw := doRequest(t, h, "/characters?name=emi&sort=last_name&limit=1", &p)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		require.Len(t, p.Items, 1)
+		assert.Equal(t, "Aoki", p.Items[0].LastName)
+		require.NotEmpty(t, p.Next)
+
+		next := p.Next
+		p = page{}
+		rw = doRequest(t, h, next, &p)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		require.Len(t, p.Items, 1)
+		assert.Equal(t, "Hawke", p.Items[0].LastName)
+
+		p = page{}
+		rw = doRequest(t, h, "/characters?race=AuRa&level=SCH:60-", &p)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		require.Len(t, p.Items, 1)
+		assert.Equal(t, int64(7248246), p.Items[0].ID)
+		assert.Equal(t, "", p.Next)
+
+		p = page{}
+		rw = doRequest(t, h, "/characters?world=Ultros&order=desc", &p)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		require.Len(t, p.Items, 2)
+		assert.Equal(t, int64(7248246), p.Items[0].ID)
+		assert.Equal(t, int64(1000), p.Items[1].ID)
+
+		p = page{}
+		rw = doRequest(t, h, "/characters?name=nobody", &p)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, `{"items":[]}`+"\n", rw.Body.String())
+
+		for path, msg := range map[string]string{
+			"/characters?world=Nowhere":  "unknown world: 'Nowhere'",
+			"/characters?level=XYZ:50":   "unknown job: 'XYZ'",
+			"/characters?sort=gender":    "can't sort by: gender",
+			"/characters?order=sideways": "invalid order: sideways",
+			"/characters?gc_rank=high":   "invalid gc_rank: high",
+		} {
+			var body map[string]string
+			rw := doRequest(t, h, path, &body)
+			assert.Equal(t, http.StatusBadRequest, rw.Code, path)
+			assert.Equal(t, msg, body["error"], path)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		var ch models.Character
+		rw := doRequest(t, h, "/characters/7248246", &ch)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "Emi", ch.FirstName)
+		assert.Equal(t, "Hawke", ch.LastName)
+		assert.Equal(t, models.Ultros, ch.World)
+		if assert.NotNil(t, ch.Title) {
+			assert.Equal(t, "Khloe's Friend", ch.Title.Title)
+		}
+	})
+
+	t.Run("Levels", func(t *testing.T) {
+		var lvls []models.Level
+		rw := doRequest(t, h, "/characters/7248246/levels", &lvls)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		require.Len(t, lvls, 2)
+		assert.Equal(t, models.PLD, lvls[0].Job)
+		assert.Equal(t, 62, lvls[0].Level)
+		assert.Equal(t, models.SCH, lvls[1].Job)
+		assert.Equal(t, 70, lvls[1].Level)
+	})
+
+	t.Run("Nonexistent", func(t *testing.T) {
+		var body map[string]string
+		rw := doRequest(t, h, "/characters/1", &body)
+		assert.Equal(t, http.StatusNotFound, rw.Code)
+		assert.Equal(t, "character not found", body["error"])
+
+		rw = doRequest(t, h, "/characters/1/levels", &body)
+		assert.Equal(t, http.StatusNotFound, rw.Code)
+		assert.Equal(t, "character not found", body["error"])
+	})
+
+	t.Run("Tombstoned", func(t *testing.T) {
+		var body map[string]string
+		rw := doRequest(t, h, "/characters/1234", &body)
+		assert.Equal(t, http.StatusGone, rw.Code)
+		assert.Equal(t, "character does not exist", body["error"])
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var body map[string]string
+		rw := doRequest(t, h, "/characters/abc", &body)
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+		assert.Equal(t, "invalid id: abc", body["error"])
+	})
+}
+
+var (
+	testSpansOnce     sync.Once
+	testSpansRecorder *tracetest.SpanRecorder
+)
+
+// recordSpans installs a global tracer provider recording every span, and W3C trace propagation.
+func recordSpans() *tracetest.SpanRecorder {
+	testSpansOnce.Do(func() {
+		testSpansRecorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(testSpansRecorder)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+	return testSpansRecorder
+}
+
+func TestRefreshCharacter(t *testing.T) {
+	ctx := context.Background()
+	ds := models.NewMemoryDataStore()
+	realRefreshPollInterval := refreshPollInterval
+	refreshPollInterval = time.Millisecond
+	t.Cleanup(func() { refreshPollInterval = realRefreshPollInterval })
+	require.NoError(t, ds.Characters().Save(ctx, &models.Character{ID: 7248246, FirstName: "Emi", LastName: "Hawke"}))
+	require.NoError(t, ds.CharacterTombstones().Create(ctx, 1234))
+
+	// Pretend to be a fetcher, and process any published jobs by renaming the character.
+	var published []fetcher.FetchMessage
+	h := NewHandler(ds, lib.PublisherFunc(func(topic string, body []byte) error {
+		assert.Equal(t, fetcher.FetchPriorityTopic, topic)
+		var msg fetcher.FetchMessage
+		require.NoError(t, json.Unmarshal(body, &msg))
+		published = append(published, msg)
+		job := msg.Job.(*fetcher.FetchCharacterJob)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			assert.NoError(t, ds.Characters().Save(ctx, &models.Character{ID: job.ID, FirstName: "Refreshed", LastName: "Hawke"}))
+		}()
+		return nil
+	}))
+
+	t.Run("Queue", func(t *testing.T) {
+		published = nil
+		var body map[string]string
+		rw := doMethodRequest(t, h, "POST", "/characters/7248246/refresh", &body)
+		assert.Equal(t, http.StatusAccepted, rw.Code)
+		assert.Equal(t, "queued", body["status"])
+		require.Len(t, published, 1)
+		assert.Equal(t, &fetcher.FetchCharacterJob{ID: 7248246, Force: true}, published[0].Job)
+	})
+
+	t.Run("Trace", func(t *testing.T) {
+		spans := recordSpans()
+		published = nil
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		parentID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+		req := httptest.NewRequest("POST", "/characters/7248246/refresh", nil)
+		req.Header.Set("traceparent", "00-"+traceID.String()+"-"+parentID.String()+"-01")
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusAccepted, rw.Code)
+
+		// The job should carry the trace on, as a child of the handler's span, not the caller's.
+		var server sdktrace.ReadOnlySpan
+		for _, s := range spans.Ended() {
+			if s.SpanContext().TraceID() == traceID && s.Parent().SpanID() == parentID {
+				server = s
+			}
+		}
+		require.NotNil(t, server)
+		assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+		require.Len(t, published, 1)
+		sc := published[0].SpanContext()
+		assert.Equal(t, traceID, sc.TraceID())
+		assert.Equal(t, server.SpanContext().SpanID(), sc.SpanID())
+	})
+
+	t.Run("Wait", func(t *testing.T) {
+		var ch models.Character
+		rw := doMethodRequest(t, h, "POST", "/characters/7248246/refresh?wait=5s", &ch)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "Refreshed", ch.FirstName)
+	})
+
+	t.Run("New", func(t *testing.T) {
+		var ch models.Character
+		rw := doMethodRequest(t, h, "POST", "/characters/1000/refresh?wait=5s", &ch)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, int64(1000), ch.ID)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		h := NewHandler(ds, lib.PublisherFunc(func(topic string, body []byte) error { return nil }))
+		var body map[string]string
+		rw := doMethodRequest(t, h, "POST", "/characters/7248246/refresh?wait=20ms", &body)
+		assert.Equal(t, http.StatusAccepted, rw.Code)
+		assert.Equal(t, "queued", body["status"])
+	})
+
+	t.Run("Tombstoned", func(t *testing.T) {
+		published = nil
+		var body map[string]string
+		rw := doMethodRequest(t, h, "POST", "/characters/1234/refresh", &body)
+		assert.Equal(t, http.StatusGone, rw.Code)
+		assert.Len(t, published, 0)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var body map[string]string
+		rw := doMethodRequest(t, h, "POST", "/characters/7248246/refresh?wait=forever", &body)
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+		assert.Equal(t, "invalid wait: forever", body["error"])
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		var body map[string]string
+		rw := doMethodRequest(t, NewHandler(ds, nil), "POST", "/characters/7248246/refresh", &body)
+		assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	})
+}
+
+func TestTombstones(t *testing.T) {
+	ctx := context.Background()
+	ds := models.NewMemoryDataStore()
+	h := NewHandler(ds, nil)
+	require.NoError(t, ds.CharacterTombstones().Create(ctx, 1234))
+
+	t.Run("Get", func(t *testing.T) {
+		var ts models.CharacterTombstone
+		rw := doRequest(t, h, "/tombstones/1234", &ts)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, int64(1234), ts.ID)
+	})
+
+	t.Run("Nonexistent", func(t *testing.T) {
+		var body map[string]string
+		rw := doRequest(t, h, "/tombstones/1", &body)
+		assert.Equal(t, http.StatusNotFound, rw.Code)
+		assert.Equal(t, "tombstone not found", body["error"])
+	})
+}
@@ -0,0 +1,462 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/graph-gophers/dataloader"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/jinzhu/gorm"
+
+	"github.com/liclac/gubal/models"
+)
+
+// graphqlSchema is the schema served at /graphql. Character IDs are IDs rather than Ints, because
+// GraphQL's Int is only 32 bits wide.
+const graphqlSchema = `
+schema {
+	query: Query
+}
+
+scalar Time
+
+type Query {
+	character(id: ID!): Character
+	characters(
+		name: String, world: String, race: String, clan: String, gender: String,
+		guardian: String, cityState: String, gc: String, gcRank: Int, title: String,
+		levels: [String!], sort: String, desc: Boolean, after: String, limit: Int
+	): CharacterPage!
+	tombstone(id: ID!): Tombstone
+	title(id: Int!): CharacterTitle
+	titles(after: Int, limit: Int): [CharacterTitle!]!
+}
+
+type Character {
+	id: ID!
+	createdAt: Time!
+	updatedAt: Time!
+	seenAt: Time!
+	firstName: String!
+	lastName: String!
+	race: String!
+	clan: String!
+	gender: String!
+	guardian: String!
+	cityState: String!
+	world: String!
+	title: CharacterTitle
+	gc: String
+	gcRank: Int!
+	levels: [Level!]!
+}
+
+type CharacterPage {
+	items: [Character!]!
+	next: String
+}
+
+type Level {
+	job: String!
+	level: Int!
+	createdAt: Time!
+	updatedAt: Time!
+}
+
+type CharacterTitle {
+	id: Int!
+	createdAt: Time!
+	title: String!
+	characters(after: String, limit: Int): CharacterPage!
+}
+
+type Tombstone {
+	id: ID!
+	createdAt: Time!
+}
+`
+
+// newGraphQLHandler returns a handler serving GraphQL queries, POSTed as JSON.
+func newGraphQLHandler() http.Handler {
+	schema := graphql.MustParseSchema(graphqlSchema, &graphqlResolver{})
+	h := &relay.Handler{Schema: schema}
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// Loaders cache what they load, so every request needs its own.
+		ctx := req.Context()
+		ctx = context.WithValue(ctx, ctxKeyLoaders, newGraphQLLoaders(models.GetDataStore(ctx)))
+		h.ServeHTTP(rw, req.WithContext(ctx))
+	})
+}
+
+type ctxKey int
+
+const ctxKeyLoaders ctxKey = iota
+
+// graphqlLoaders batch up loads of associations, so that eg. the levels of every character in a
+// search are loaded with a single query, rather than one per character.
+type graphqlLoaders struct {
+	levels *dataloader.Loader // Character ID -> []*models.Level.
+	titles *dataloader.Loader // Title ID -> *models.CharacterTitle, or nil.
+
+	titleCharacters *dataloader.Loader // titleCharactersKey -> []*models.Character.
+}
+
+func newGraphQLLoaders(ds models.DataStore) *graphqlLoaders {
+	return &graphqlLoaders{
+		levels: dataloader.NewBatchedLoader(func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+			ids := make([]int64, len(keys))
+			for i, key := range keys {
+				ids[i] = int64(key.(idKey))
+			}
+			lvls, err := ds.Levels().ListMany(ctx, ids)
+			byID := make(map[int64][]*models.Level, len(ids))
+			for _, lvl := range lvls {
+				byID[lvl.CharacterID] = append(byID[lvl.CharacterID], lvl)
+			}
+			results := make([]*dataloader.Result, len(keys))
+			for i, id := range ids {
+				results[i] = &dataloader.Result{Data: byID[id], Error: err}
+			}
+			return results
+		}),
+		titles: dataloader.NewBatchedLoader(func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+			ids := make([]int, len(keys))
+			for i, key := range keys {
+				ids[i] = int(key.(idKey))
+			}
+			titles, err := ds.CharacterTitles().GetMany(ctx, ids)
+			byID := make(map[int]*models.CharacterTitle, len(ids))
+			for _, title := range titles {
+				byID[title.ID] = title
+			}
+			results := make([]*dataloader.Result, len(keys))
+			for i, id := range ids {
+				results[i] = &dataloader.Result{Data: byID[id], Error: err}
+			}
+			return results
+		}),
+		titleCharacters: dataloader.NewBatchedLoader(func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+			// Titles asked for the same page of characters are searched together; usually that's all
+			// of them, eg. the first page of every title in a list.
+			type pageArgs struct {
+				after string
+				limit int
+			}
+			titleIDs := make(map[pageArgs][]int)
+			for _, key := range keys {
+				k := key.(titleCharactersKey)
+				args := pageArgs{k.after, k.limit}
+				titleIDs[args] = append(titleIDs[args], k.titleID)
+			}
+			pages := make(map[titleCharactersKey][]*models.Character, len(keys))
+			errs := make(map[pageArgs]error, len(titleIDs))
+			for args, ids := range titleIDs {
+				q := models.CharacterQuery{After: args.after, Limit: args.limit}
+				byID, err := ds.Characters().SearchTitles(ctx, q, ids)
+				for id, chars := range byID {
+					pages[titleCharactersKey{id, args.after, args.limit}] = chars
+				}
+				errs[args] = err
+			}
+			results := make([]*dataloader.Result, len(keys))
+			for i, key := range keys {
+				k := key.(titleCharactersKey)
+				results[i] = &dataloader.Result{Data: pages[k], Error: errs[pageArgs{k.after, k.limit}]}
+			}
+			return results
+		}),
+	}
+}
+
+func getGraphQLLoaders(ctx context.Context) *graphqlLoaders {
+	return ctx.Value(ctxKeyLoaders).(*graphqlLoaders)
+}
+
+// idKey is a dataloader.Key for an ID.
+type idKey int64
+
+func (k idKey) String() string   { return strconv.FormatInt(int64(k), 10) }
+func (k idKey) Raw() interface{} { return k }
+
+// titleCharactersKey is a dataloader.Key for a page of a title's characters.
+type titleCharactersKey struct {
+	titleID int
+	after   string
+	limit   int
+}
+
+func (k titleCharactersKey) String() string {
+	return fmt.Sprintf("%d/%s/%d", k.titleID, k.after, k.limit)
+}
+func (k titleCharactersKey) Raw() interface{} { return k }
+
+// graphqlResolver resolves the Query type.
+type graphqlResolver struct{}
+
+func (graphqlResolver) Character(ctx context.Context, args struct{ ID graphql.ID }) (*characterResolver, error) {
+	id, err := strconv.ParseInt(string(args.ID), 10, 64)
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, "invalid id: %s", args.ID)
+	}
+	ch, err := models.GetDataStore(ctx).Characters().Get(ctx, id)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &characterResolver{ch}, nil
+}
+
+// Characters searches for characters; arguments are the same as for GET /characters.
+func (graphqlResolver) Characters(ctx context.Context, args struct {
+	Name      *string
+	World     *string
+	Race      *string
+	Clan      *string
+	Gender    *string
+	Guardian  *string
+	CityState *string
+	GC        *string
+	GCRank    *int32
+	Title     *string
+	Levels    *[]string
+	Sort      *string
+	Desc      *bool
+	After     *string
+	Limit     *int32
+}) (*characterPageResolver, error) {
+	v := url.Values{}
+	for key, arg := range map[string]*string{
+		"name":       args.Name,
+		"world":      args.World,
+		"race":       args.Race,
+		"clan":       args.Clan,
+		"gender":     args.Gender,
+		"guardian":   args.Guardian,
+		"city_state": args.CityState,
+		"gc":         args.GC,
+		"title":      args.Title,
+		"sort":       args.Sort,
+		"after":      args.After,
+	} {
+		if arg != nil {
+			v.Set(key, *arg)
+		}
+	}
+	if args.GCRank != nil {
+		v.Set("gc_rank", strconv.Itoa(int(*args.GCRank)))
+	}
+	if args.Levels != nil {
+		v["level"] = *args.Levels
+	}
+	if args.Desc != nil && *args.Desc {
+		v.Set("order", "desc")
+	}
+	if args.Limit != nil {
+		v.Set("limit", strconv.Itoa(int(*args.Limit)))
+	}
+	q, err := ParseCharacterQuery(v)
+	if err != nil {
+		return nil, err
+	}
+	return searchCharacterPage(ctx, q)
+}
+
+func (graphqlResolver) Tombstone(ctx context.Context, args struct{ ID graphql.ID }) (*tombstoneResolver, error) {
+	id, err := strconv.ParseInt(string(args.ID), 10, 64)
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, "invalid id: %s", args.ID)
+	}
+	ts, err := models.GetDataStore(ctx).CharacterTombstones().Get(ctx, id)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tombstoneResolver{ts}, nil
+}
+
+func (graphqlResolver) Title(ctx context.Context, args struct{ ID int32 }) (*titleResolver, error) {
+	title, err := models.GetDataStore(ctx).CharacterTitles().Get(ctx, int(args.ID))
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &titleResolver{title}, nil
+}
+
+func (graphqlResolver) Titles(ctx context.Context, args struct {
+	After *int32
+	Limit *int32
+}) ([]*titleResolver, error) {
+	after, limit, err := graphqlPagination(args.After, args.Limit)
+	if err != nil {
+		return nil, err
+	}
+	titles, err := models.GetDataStore(ctx).CharacterTitles().List(ctx, int(after), limit)
+	if err != nil {
+		return nil, err
+	}
+	rs := make([]*titleResolver, len(titles))
+	for i, title := range titles {
+		rs[i] = &titleResolver{title}
+	}
+	return rs, nil
+}
+
+// graphqlPagination validates after and limit arguments like pagination() does for query parameters.
+func graphqlPagination(after, limit *int32) (int32, int, error) {
+	var a int32
+	l := DefaultLimit
+	if after != nil {
+		a = *after
+	}
+	if limit != nil {
+		if *limit < 1 {
+			return 0, 0, errorf(http.StatusBadRequest, "invalid limit: %d", *limit)
+		}
+		l = int(*limit)
+	}
+	if l > MaxLimit {
+		l = MaxLimit
+	}
+	return a, l, nil
+}
+
+// searchCharacterPage runs a search, and wraps the results in a page.
+func searchCharacterPage(ctx context.Context, q models.CharacterQuery) (*characterPageResolver, error) {
+	chars, err := models.GetDataStore(ctx).Characters().Search(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return newCharacterPage(q, chars), nil
+}
+
+// newCharacterPage wraps the results of a search in a page.
+func newCharacterPage(q models.CharacterQuery, chars []*models.Character) *characterPageResolver {
+	page := &characterPageResolver{}
+	for _, ch := range chars {
+		page.items = append(page.items, &characterResolver{ch})
+	}
+	if len(chars) == q.Limit {
+		next := q.CursorFor(chars[len(chars)-1])
+		page.next = &next
+	}
+	return page
+}
+
+type characterResolver struct{ ch *models.Character }
+
+func (r *characterResolver) ID() graphql.ID {
+	return graphql.ID(strconv.FormatInt(r.ch.ID, 10))
+}
+func (r *characterResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.ch.CreatedAt} }
+func (r *characterResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.ch.UpdatedAt} }
+func (r *characterResolver) SeenAt() graphql.Time    { return graphql.Time{Time: r.ch.SeenAt} }
+func (r *characterResolver) FirstName() string       { return r.ch.FirstName }
+func (r *characterResolver) LastName() string        { return r.ch.LastName }
+func (r *characterResolver) Race() string            { return string(r.ch.Race) }
+func (r *characterResolver) Clan() string            { return string(r.ch.Clan) }
+func (r *characterResolver) Gender() string          { return r.ch.Gender }
+func (r *characterResolver) Guardian() string        { return string(r.ch.Guardian) }
+func (r *characterResolver) CityState() string       { return string(r.ch.CityState) }
+func (r *characterResolver) World() string           { return string(r.ch.World) }
+func (r *characterResolver) GCRank() int32           { return int32(r.ch.GCRank) }
+
+func (r *characterResolver) GC() *string {
+	if r.ch.GC == nil {
+		return nil
+	}
+	gc := string(*r.ch.GC)
+	return &gc
+}
+
+func (r *characterResolver) Title(ctx context.Context) (*titleResolver, error) {
+	if !r.ch.TitleID.Valid {
+		return nil, nil
+	}
+	v, err := getGraphQLLoaders(ctx).titles.Load(ctx, idKey(r.ch.TitleID.Int64))()
+	if err != nil || v.(*models.CharacterTitle) == nil {
+		return nil, err
+	}
+	return &titleResolver{v.(*models.CharacterTitle)}, nil
+}
+
+func (r *characterResolver) Levels(ctx context.Context) ([]*levelResolver, error) {
+	v, err := getGraphQLLoaders(ctx).levels.Load(ctx, idKey(r.ch.ID))()
+	if err != nil {
+		return nil, err
+	}
+	lvls := v.([]*models.Level)
+	rs := make([]*levelResolver, len(lvls))
+	for i, lvl := range lvls {
+		rs[i] = &levelResolver{lvl}
+	}
+	return rs, nil
+}
+
+type characterPageResolver struct {
+	items []*characterResolver
+	next  *string
+}
+
+func (r *characterPageResolver) Items() []*characterResolver {
+	if r.items == nil {
+		return []*characterResolver{}
+	}
+	return r.items
+}
+func (r *characterPageResolver) Next() *string { return r.next }
+
+type levelResolver struct{ lvl *models.Level }
+
+func (r *levelResolver) Job() string             { return string(r.lvl.Job) }
+func (r *levelResolver) Level() int32            { return int32(r.lvl.Level) }
+func (r *levelResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.lvl.CreatedAt} }
+func (r *levelResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.lvl.UpdatedAt} }
+
+type titleResolver struct{ title *models.CharacterTitle }
+
+func (r *titleResolver) ID() int32               { return int32(r.title.ID) }
+func (r *titleResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.title.CreatedAt} }
+func (r *titleResolver) Title() string           { return r.title.Title }
+
+// Characters returns a page of characters with the title. Pages for several titles, eg. for every
+// title in a list, are loaded together.
+func (r *titleResolver) Characters(ctx context.Context, args struct {
+	After *string
+	Limit *int32
+}) (*characterPageResolver, error) {
+	var q models.CharacterQuery
+	_, limit, err := graphqlPagination(nil, args.Limit)
+	if err != nil {
+		return nil, err
+	}
+	q.Limit = limit
+	if args.After != nil {
+		q.After = *args.After
+	}
+	if err := q.Validate(); err != nil {
+		return nil, errorf(http.StatusBadRequest, "%s", err)
+	}
+	v, err := getGraphQLLoaders(ctx).titleCharacters.Load(ctx, titleCharactersKey{r.title.ID, q.After, q.Limit})()
+	if err != nil {
+		return nil, err
+	}
+	return newCharacterPage(q, v.([]*models.Character)), nil
+}
+
+type tombstoneResolver struct{ ts *models.CharacterTombstone }
+
+func (r *tombstoneResolver) ID() graphql.ID {
+	return graphql.ID(strconv.FormatInt(r.ts.ID, 10))
+}
+func (r *tombstoneResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.ts.CreatedAt} }
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liclac/gubal/models"
+)
+
+// countingDataStore counts calls to the methods the GraphQL loaders batch.
+type countingDataStore struct {
+	models.DataStore
+	levelCalls, titleCalls, titleCharacterCalls int32
+}
+
+func (ds *countingDataStore) Characters() models.CharacterStore {
+	return countingCharacterStore{ds.DataStore.Characters(), &ds.titleCharacterCalls}
+}
+
+func (ds *countingDataStore) Levels() models.LevelStore {
+	return countingLevelStore{ds.DataStore.Levels(), &ds.levelCalls}
+}
+
+func (ds *countingDataStore) CharacterTitles() models.CharacterTitleStore {
+	return countingTitleStore{ds.DataStore.CharacterTitles(), &ds.titleCalls}
+}
+
+type countingCharacterStore struct {
+	models.CharacterStore
+	calls *int32
+}
+
+func (s countingCharacterStore) SearchTitles(ctx context.Context, q models.CharacterQuery, titleIDs []int) (map[int][]*models.Character, error) {
+	atomic.AddInt32(s.calls, 1)
+	return s.CharacterStore.SearchTitles(ctx, q, titleIDs)
+}
+
+type countingLevelStore struct {
+	models.LevelStore
+	calls *int32
+}
+
+func (s countingLevelStore) ListMany(ctx context.Context, cIDs []int64) ([]*models.Level, error) {
+	atomic.AddInt32(s.calls, 1)
+	return s.LevelStore.ListMany(ctx, cIDs)
+}
+
+type countingTitleStore struct {
+	models.CharacterTitleStore
+	calls *int32
+}
+
+func (s countingTitleStore) GetMany(ctx context.Context, ids []int) ([]*models.CharacterTitle, error) {
+	atomic.AddInt32(s.calls, 1)
+	return s.CharacterTitleStore.GetMany(ctx, ids)
+}
+
+// doGraphQL runs a GraphQL query against the handler, and decodes the response's data into v.
+// Returns any errors in the response.
+func doGraphQL(t *testing.T, h http.Handler, query string, v interface{}) []string {
+	body, err := json.Marshal(map[string]string{"query": query})
+	require.NoError(t, err)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest("POST", "/graphql", strings.NewReader(string(body))))
+	require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp), rw.Body.String())
+	var errs []string
+	for _, e := range resp.Errors {
+		errs = append(errs, e.Message)
+	}
+	if v != nil && len(resp.Data) > 0 {
+		require.NoError(t, json.Unmarshal(resp.Data, v), string(resp.Data))
+	}
+	return errs
+}
+
+func TestGraphQL(t *testing.T) {
+	ctx := context.Background()
+	ds := &countingDataStore{DataStore: models.NewMemoryDataStore()}
+	h := NewHandler(ds, nil)
+
+	title, err := ds.CharacterTitles().GetOrCreate(ctx, "Khloe's Friend")
+	require.NoError(t, err)
+	for _, ch := range []*models.Character{
+		{ID: 1, FirstName: "Emi", LastName: "Hawke", World: models.Ultros, Title: title},
+		{ID: 2, FirstName: "Rei", LastName: "Hawke", World: models.Ultros, Title: title},
+		{ID: 3, FirstName: "Kai", LastName: "Hawke", World: models.Ultros},
+	} {
+		require.NoError(t, ds.Characters().Save(ctx, ch))
+		require.NoError(t, ds.Levels().Set(ctx, &models.Level{CharacterID: ch.ID, Job: models.PLD, Level: int(ch.ID * 10)}))
+	}
+	require.NoError(t, ds.CharacterTombstones().Create(ctx, 1234))
+
+	t.Run("Character", func(t *testing.T) {
+		var data struct {
+			Character struct {
+				ID        string
+				FirstName string
+				Title     struct{ Title string }
+				Levels    []struct {
+					Job   string
+					Level int
+				}
+			}
+		}
+		errs := doGraphQL(t, h, `{ character(id: "1") { id firstName title { title } levels { job level } } }`, &data)
+		require.Empty(t, errs)
+		assert.Equal(t, "1", data.Character.ID)
+		assert.Equal(t, "Emi", data.Character.FirstName)
+		assert.Equal(t, "Khloe's Friend", data.Character.Title.Title)
+		require.Len(t, data.Character.Levels, 1)
+		assert.Equal(t, "PLD", data.Character.Levels[0].Job)
+		assert.Equal(t, 10, data.Character.Levels[0].Level)
+	})
+
+	t.Run("Nonexistent", func(t *testing.T) {
+		var data struct{ Character *struct{ ID string } }
+		errs := doGraphQL(t, h, `{ character(id: "100") { id } }`, &data)
+		require.Empty(t, errs)
+		assert.Nil(t, data.Character)
+	})
+
+	t.Run("Batching", func(t *testing.T) {
+		atomic.StoreInt32(&ds.levelCalls, 0)
+		atomic.StoreInt32(&ds.titleCalls, 0)
+		var data struct {
+			Characters struct {
+				Items []struct {
+					ID     string
+					Title  *struct{ Title string }
+					Levels []struct{ Level int }
+				}
+				Next *string
+			}
+		}
+		errs := doGraphQL(t, h, `{ characters(world: "Ultros") { items { id title { title } levels { level } } next } }`, &data)
+		require.Empty(t, errs)
+		require.Len(t, data.Characters.Items, 3)
+		for i, item := range data.Characters.Items {
+			require.Len(t, item.Levels, 1)
+			assert.Equal(t, (i+1)*10, item.Levels[0].Level)
+		}
+		assert.NotNil(t, data.Characters.Items[0].Title)
+		assert.NotNil(t, data.Characters.Items[1].Title)
+		assert.Nil(t, data.Characters.Items[2].Title)
+		assert.Nil(t, data.Characters.Next)
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&ds.levelCalls))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&ds.titleCalls))
+	})
+
+	t.Run("TitleCharacters", func(t *testing.T) {
+		var data struct {
+			Title struct {
+				Title      string
+				Characters struct {
+					Items []struct{ FirstName string }
+					Next  *string
+				}
+			}
+		}
+		errs := doGraphQL(t, h, `{ title(id: 1) { title characters(limit: 1) { items { firstName } next } } }`, &data)
+		require.Empty(t, errs)
+		assert.Equal(t, "Khloe's Friend", data.Title.Title)
+		require.Len(t, data.Title.Characters.Items, 1)
+		assert.Equal(t, "Emi", data.Title.Characters.Items[0].FirstName)
+		require.NotNil(t, data.Title.Characters.Next)
+	})
+
+	t.Run("Titles", func(t *testing.T) {
+		_, err := ds.CharacterTitles().GetOrCreate(ctx, "Bunny Lord")
+		require.NoError(t, err)
+
+		var data struct {
+			Titles []struct {
+				ID         int
+				Characters struct {
+					Items []struct{ FirstName string }
+				}
+			}
+		}
+		atomic.StoreInt32(&ds.titleCharacterCalls, 0)
+		errs := doGraphQL(t, h, `{ titles { id characters(limit: 1) { items { firstName } } } }`, &data)
+		require.Empty(t, errs)
+		require.Len(t, data.Titles, 2)
+		assert.Equal(t, 1, data.Titles[0].ID)
+		require.Len(t, data.Titles[0].Characters.Items, 1)
+		assert.Equal(t, "Emi", data.Titles[0].Characters.Items[0].FirstName)
+		assert.Equal(t, 2, data.Titles[1].ID)
+		assert.Empty(t, data.Titles[1].Characters.Items)
+
+		// Every title's characters are loaded at once.
+		assert.Equal(t, int32(1), atomic.LoadInt32(&ds.titleCharacterCalls))
+	})
+
+	t.Run("Tombstone", func(t *testing.T) {
+		var data struct{ Tombstone *struct{ ID string } }
+		errs := doGraphQL(t, h, `{ tombstone(id: "1234") { id } }`, &data)
+		require.Empty(t, errs)
+		require.NotNil(t, data.Tombstone)
+		assert.Equal(t, "1234", data.Tombstone.ID)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		errs := doGraphQL(t, h, `{ characters(world: "Nowhere") { next } }`, nil)
+		assert.Equal(t, []string{"unknown world: 'Nowhere'"}, errs)
+
+		errs = doGraphQL(t, h, `{ character(id: "abc") { id } }`, nil)
+		assert.Equal(t, []string{"invalid id: abc"}, errs)
+	})
+}
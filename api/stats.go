@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/liclac/gubal/models"
+)
+
+// Limits for top lists, eg. /stats/titles.
+const (
+	DefaultTopLimit = 10
+	MaxTopLimit     = models.StatsTopLimit
+)
+
+// statsFilter parses the ?world= and ?dc= query parameters into a StatsFilter.
+func statsFilter(q url.Values) (models.StatsFilter, error) {
+	f := models.StatsFilter{
+		World:      models.World(q.Get("world")),
+		DataCenter: models.DataCenter(q.Get("dc")),
+	}
+	if err := f.Validate(); err != nil {
+		return f, errorf(http.StatusBadRequest, "%s", err)
+	}
+	return f, nil
+}
+
+// topLimit parses the ?limit= query parameter for top lists.
+func topLimit(q url.Values) (int, error) {
+	limit := DefaultTopLimit
+	if s := q.Get("limit"); s != "" {
+		l, err := strconv.Atoi(s)
+		if err != nil || l < 1 {
+			return 0, errorf(http.StatusBadRequest, "invalid limit: %s", s)
+		}
+		limit = l
+	}
+	if limit > MaxTopLimit {
+		limit = MaxTopLimit
+	}
+	return limit, nil
+}
+
+// setRefreshedAt sets Last-Modified to when precomputed stats were last refreshed; it's left out if
+// they haven't been, and are counted up live instead.
+func setRefreshedAt(rw http.ResponseWriter, ctx context.Context, stats models.StatsStore) error {
+	at, err := stats.RefreshedAt(ctx)
+	if err != nil {
+		return err
+	}
+	if !at.IsZero() {
+		rw.Header().Set("Last-Modified", at.UTC().Format(http.TimeFormat))
+	}
+	return nil
+}
+
+// statsHandler serves a breakdown from the StatsStore, filtered by ?world= and ?dc=.
+func statsHandler[T any](fn func(s models.StatsStore, ctx context.Context, f models.StatsFilter) ([]T, error)) handlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) error {
+		ctx := req.Context()
+		f, err := statsFilter(req.URL.Query())
+		if err != nil {
+			return err
+		}
+		stats := models.GetDataStore(ctx).Stats()
+		counts, err := fn(stats, ctx, f)
+		if err != nil {
+			return err
+		}
+		if err := setRefreshedAt(rw, ctx, stats); err != nil {
+			return err
+		}
+		return writeJSON(rw, http.StatusOK, counts)
+	}
+}
+
+// topStatsHandler serves a top list from the StatsStore, filtered by ?world= and ?dc=, and limited
+// by ?limit=.
+func topStatsHandler(fn func(s models.StatsStore, ctx context.Context, f models.StatsFilter, limit int) ([]models.StatsCount, error)) handlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) error {
+		ctx := req.Context()
+		f, err := statsFilter(req.URL.Query())
+		if err != nil {
+			return err
+		}
+		limit, err := topLimit(req.URL.Query())
+		if err != nil {
+			return err
+		}
+		stats := models.GetDataStore(ctx).Stats()
+		counts, err := fn(stats, ctx, f, limit)
+		if err != nil {
+			return err
+		}
+		if err := setRefreshedAt(rw, ctx, stats); err != nil {
+			return err
+		}
+		return writeJSON(rw, http.StatusOK, counts)
+	}
+}
+
+// registerStats registers the /stats/ endpoints on a mux.
+func registerStats(mux *http.ServeMux) {
+	mux.Handle("GET /stats/genders", statsHandler(models.StatsStore.Genders))
+	mux.Handle("GET /stats/races", statsHandler(models.StatsStore.Races))
+	mux.Handle("GET /stats/clans", statsHandler(models.StatsStore.Clans))
+	mux.Handle("GET /stats/levels", statsHandler(models.StatsStore.Levels))
+	mux.Handle("GET /stats/gcs", statsHandler(models.StatsStore.GrandCompanies))
+	mux.Handle("GET /stats/gc-ranks", statsHandler(models.StatsStore.GrandCompanyRanks))
+	mux.Handle("GET /stats/worlds", statsHandler(models.StatsStore.Worlds))
+	mux.Handle("GET /stats/titles", topStatsHandler(models.StatsStore.TopTitles))
+	mux.Handle("GET /stats/first-names", topStatsHandler(models.StatsStore.TopFirstNames))
+	mux.Handle("GET /stats/last-names", topStatsHandler(models.StatsStore.TopLastNames))
+}
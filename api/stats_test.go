@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liclac/gubal/models"
+)
+
+// refreshedDataStore pretends its stats were precomputed at a certain time.
+type refreshedDataStore struct {
+	models.DataStore
+	at time.Time
+}
+
+func (ds refreshedDataStore) Stats() models.StatsStore {
+	return refreshedStatsStore{ds.DataStore.Stats(), ds.at}
+}
+
+type refreshedStatsStore struct {
+	models.StatsStore
+	at time.Time
+}
+
+func (s refreshedStatsStore) RefreshedAt(ctx context.Context) (time.Time, error) { return s.at, nil }
+
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+	ds := models.NewMemoryDataStore()
+	h := NewHandler(ds, nil)
+
+	for _, ch := range []*models.Character{
+		{ID: 1, FirstName: "Emi", LastName: "Hawke", Gender: "♀", World: models.Ultros},
+		{ID: 2, FirstName: "Emi", LastName: "Adams", Gender: "♀", World: models.Odin},
+		{ID: 3, FirstName: "Zed", LastName: "Adams", Gender: "♂", World: models.Ultros},
+	} {
+		require.NoError(t, ds.Characters().Save(ctx, ch))
+	}
+	require.NoError(t, ds.Levels().Set(ctx, &models.Level{CharacterID: 1, Job: models.SCH, Level: 70}))
+	require.NoError(t, ds.Levels().Set(ctx, &models.Level{CharacterID: 2, Job: models.SCH, Level: 55}))
+
+	t.Run("Genders", func(t *testing.T) {
+		var counts []models.StatsCount
+		rw := doRequest(t, h, "/stats/genders", &counts)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, []models.StatsCount{{Value: "♀", Count: 2}, {Value: "♂", Count: 1}}, counts)
+	})
+
+	t.Run("World", func(t *testing.T) {
+		var counts []models.StatsCount
+		rw := doRequest(t, h, "/stats/genders?world=Odin", &counts)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, []models.StatsCount{{Value: "♀", Count: 1}}, counts)
+	})
+
+	t.Run("DataCenter", func(t *testing.T) {
+		var counts []models.LevelStatsCount
+		rw := doRequest(t, h, "/stats/levels?dc=Primal", &counts)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, []models.LevelStatsCount{{Job: models.SCH, Min: 70, Max: 70, Count: 1}}, counts)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		rw := doRequest(t, h, "/stats/gc-ranks", nil)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "[]\n", rw.Body.String())
+	})
+
+	t.Run("Top", func(t *testing.T) {
+		var counts []models.StatsCount
+		rw := doRequest(t, h, "/stats/last-names?limit=1", &counts)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, []models.StatsCount{{Value: "Adams", Count: 2}}, counts)
+	})
+
+	t.Run("RefreshedAt", func(t *testing.T) {
+		rw := doRequest(t, h, "/stats/genders", nil)
+		assert.Equal(t, "", rw.Header().Get("Last-Modified"))
+
+		at := time.Date(2018, 3, 20, 12, 0, 0, 0, time.UTC)
+		h := NewHandler(refreshedDataStore{ds, at}, nil)
+		for _, path := range []string{"/stats/genders", "/stats/titles"} {
+			rw := doRequest(t, h, path, nil)
+			assert.Equal(t, http.StatusOK, rw.Code, path)
+			assert.Equal(t, "Tue, 20 Mar 2018 12:00:00 GMT", rw.Header().Get("Last-Modified"), path)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for path, msg := range map[string]string{
+			"/stats/genders?world=Nowhere":  "unknown world: 'Nowhere'",
+			"/stats/genders?dc=Nowhere":     "unknown data center: 'Nowhere'",
+			"/stats/first-names?limit=0":    "invalid limit: 0",
+			"/stats/first-names?limit=many": "invalid limit: many",
+		} {
+			var body map[string]string
+			rw := doRequest(t, h, path, &body)
+			assert.Equal(t, http.StatusBadRequest, rw.Code, path)
+			assert.Equal(t, msg, body["error"], path)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/liclac/gubal/models"
+)
+
+// listTitles serves a page of titles, ordered by ID.
+func listTitles(rw http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+	afterStr, limit, err := pagination(req.URL.Query())
+	if err != nil {
+		return err
+	}
+	after := 0
+	if afterStr != "" {
+		if after, err = strconv.Atoi(afterStr); err != nil {
+			return errorf(http.StatusBadRequest, "invalid after: %s", afterStr)
+		}
+	}
+
+	titles, err := models.GetDataStore(ctx).CharacterTitles().List(ctx, after, limit)
+	if err != nil {
+		return err
+	}
+	page := Page{Items: titles}
+	if titles == nil {
+		page.Items = []*models.CharacterTitle{}
+	}
+	if len(titles) == limit {
+		page.Next = nextPage(req, strconv.Itoa(titles[len(titles)-1].ID))
+	}
+	return writeJSON(rw, http.StatusOK, page)
+}
+
+// getTitle serves a single title.
+func getTitle(rw http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+	id, err := pathInt64(req, "id")
+	if err != nil {
+		return err
+	}
+
+	title, err := models.GetDataStore(ctx).CharacterTitles().Get(ctx, int(id))
+	if gorm.IsRecordNotFoundError(err) {
+		return errorf(http.StatusNotFound, "title not found")
+	}
+	if err != nil {
+		return err
+	}
+	return writeJSON(rw, http.StatusOK, title)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liclac/gubal/models"
+)
+
+func TestTitles(t *testing.T) {
+	ctx := context.Background()
+	ds := models.NewMemoryDataStore()
+	h := NewHandler(ds, nil)
+
+	for _, title := range []string{"Khloe's Friend", "The Final Witness", "Sultana's Seeker"} {
+		_, err := ds.CharacterTitles().GetOrCreate(ctx, title)
+		require.NoError(t, err)
+	}
+
+	t.Run("List", func(t *testing.T) {
+		var page struct {
+			Items []models.CharacterTitle `json:"items"`
+			Next  string                  `json:"next"`
+		}
+		rw := doRequest(t, h, "/titles?limit=2", &page)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		require.Len(t, page.Items, 2)
+		assert.Equal(t, "Khloe's Friend", page.Items[0].Title)
+		assert.Equal(t, "The Final Witness", page.Items[1].Title)
+		assert.Equal(t, "/titles?after=2&limit=2", page.Next)
+
+		page.Items, page.Next = nil, ""
+		rw = doRequest(t, h, "/titles?after=2&limit=2", &page)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, "Sultana's Seeker", page.Items[0].Title)
+		assert.Equal(t, "", page.Next)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		var title models.CharacterTitle
+		rw := doRequest(t, h, "/titles/2", &title)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "The Final Witness", title.Title)
+	})
+
+	t.Run("Nonexistent", func(t *testing.T) {
+		var body map[string]string
+		rw := doRequest(t, h, "/titles/100", &body)
+		assert.Equal(t, http.StatusNotFound, rw.Code)
+		assert.Equal(t, "title not found", body["error"])
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var body map[string]string
+		rw := doRequest(t, h, "/titles?after=x", &body)
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+		assert.Equal(t, "invalid after: x", body["error"])
+	})
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/liclac/gubal/models"
+)
+
+// getTombstone serves a character's tombstone, if it has one.
+func getTombstone(rw http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+	id, err := pathInt64(req, "id")
+	if err != nil {
+		return err
+	}
+
+	ts, err := models.GetDataStore(ctx).CharacterTombstones().Get(ctx, id)
+	if gorm.IsRecordNotFoundError(err) {
+		return errorf(http.StatusNotFound, "tombstone not found")
+	}
+	if err != nil {
+		return err
+	}
+	return writeJSON(rw, http.StatusOK, ts)
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/liclac/gubal/fetcher"
+)
+
+// canaryCmd represents the canary command
+var canaryCmd = &cobra.Command{
+	Use:   "canary [file]",
+	Short: "Check known characters for Lodestone layout changes",
+	Long: `Check known characters for Lodestone layout changes.
+
+Fetches every character in the given file (canaries.json by default), parses them like the fetcher
+would, and reports any selectors that stopped matching and fields that parsed differently than
+expected. Nothing is written to the database. Exits non-zero if any canary looks off, or couldn't be
+fetched at all, so it can be run from cron after patches, before thousands of fetches start failing.
+
+The file is a JSON array of canaries, eg:
+
+  [{"id": 7248246,
+    "expect": {"first_name": "Emi", "last_name": "Hawke", "world": "Ultros", ...},
+    "levels": {"PLD": 62, "SCH": 70, ...}}]
+
+The expected character uses the same fields as the API; jobs missing from levels are expected to be
+locked.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "canaries.json"
+		if len(args) > 0 {
+			path = args[0]
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		canaries, err := fetcher.LoadCanaries(f)
+		f.Close()
+		if err != nil {
+			return errors.Wrap(err, path)
+		}
+
+		layouts, err := loadLayouts()
+		if err != nil {
+			return err
+		}
+
+		ctx := fetcher.WithLayouts(context.Background(), layouts)
+		var results []*fetcher.CanaryResult
+		drifted, unfetched := 0, 0
+		for _, c := range canaries {
+			// One canary that can't be fetched, eg. because the character's been deleted, shouldn't
+			// stop the rest from being checked.
+			res, err := fetcher.RunCanary(ctx, c)
+			switch {
+			case err != nil:
+				unfetched++
+				res = &fetcher.CanaryResult{ID: c.ID, FetchError: err.Error()}
+				zap.L().Warn("Couldn't fetch canary", zap.Int64("id", c.ID), zap.Error(err))
+			case !res.OK():
+				drifted++
+				zap.L().Warn("Canary doesn't look as expected", zap.Int64("id", c.ID))
+			}
+			results = append(results, res)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			for _, res := range results {
+				if err := enc.Encode(res); err != nil {
+					return err
+				}
+			}
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tSTATUS\tPROBLEMS")
+			for _, res := range results {
+				status := "ok"
+				var problems []string
+				switch {
+				case res.FetchError != "":
+					status = "error"
+					problems = append(problems, "fetch: "+res.FetchError)
+				case !res.OK():
+					status = "drift"
+				}
+				for _, sel := range res.Selectors {
+					problems = append(problems, "no match: "+sel)
+				}
+				problems = append(problems, res.Errors...)
+				problems = append(problems, res.Mismatches...)
+				fmt.Fprintf(w, "%d\t%s\t%s\n", res.ID, status, strings.Join(problems, "; "))
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case drifted > 0 && unfetched > 0:
+			return errors.Errorf("layout drift detected in %d of %d canaries, and %d couldn't be fetched", drifted, len(canaries), unfetched)
+		case drifted > 0:
+			return errors.Errorf("layout drift detected in %d of %d canaries", drifted, len(canaries))
+		case unfetched > 0:
+			return errors.Errorf("%d of %d canaries couldn't be fetched", unfetched, len(canaries))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(canaryCmd)
+	canaryCmd.Flags().Bool("json", false, "print results as JSON, one per line")
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/liclac/gubal/export"
+	"github.com/liclac/gubal/models"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export data for offline analysis",
+	Long: `Export data for offline analysis.
+
+Writes tables out as CSV, JSON Lines or Parquet, which load straight into eg. pandas or DuckDB.
+Records are read from the database a chunk at a time, so exports of any size run in constant memory.`,
+}
+
+// exportCharsCmd represents the export characters command
+var exportCharsCmd = &cobra.Command{
+	Use:   "characters",
+	Short: "Export characters",
+	Long: `Export characters.
+
+Writes one row per character, with their title and a level_<job> column for every job, eg.
+level_pld. Takes the same filters as query chars.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		q, err := characterQueryFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		q.Limit, _ = cmd.Flags().GetInt("chunk-size")
+		return runExport(cmd, "characters", export.CharacterColumns,
+			func(ctx context.Context, ds models.DataStore, w export.Writer) (int, error) {
+				return export.Characters(ctx, ds, q, w)
+			})
+	},
+}
+
+// exportTombstonesCmd represents the export tombstones command
+var exportTombstonesCmd = &cobra.Command{
+	Use:   "tombstones",
+	Short: "Export character tombstones",
+	Long: `Export character tombstones.
+
+Writes one row per deleted character, with its ID and when it was found to be gone.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		chunkSize, _ := cmd.Flags().GetInt("chunk-size")
+		return runExport(cmd, "tombstones", export.TombstoneColumns,
+			func(ctx context.Context, ds models.DataStore, w export.Writer) (int, error) {
+				return export.Tombstones(ctx, ds, chunkSize, w)
+			})
+	},
+}
+
+// runExport opens the output and a writer for the --format flag, and calls fn to fill it.
+func runExport(cmd *cobra.Command, name string, cols []export.Column, fn func(context.Context, models.DataStore, export.Writer) (int, error)) (rerr error) {
+	formatStr, _ := cmd.Flags().GetString("format")
+	format := export.Format(formatStr)
+	if !format.Valid() {
+		return errors.Errorf("unknown format: %s", format)
+	}
+
+	var out io.Writer = os.Stdout
+	if path, _ := cmd.Flags().GetString("output"); path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer func() { rerr = multierr.Append(rerr, f.Close()) }()
+		out = f
+	}
+
+	db, err := dbConnect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	keepLogsOutOf(db, out)
+
+	w, err := export.NewWriter(format, out, cols)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	n, err := fn(context.Background(), models.NewDataStore(db), w)
+	if err := multierr.Append(err, w.Close()); err != nil {
+		return err
+	}
+	zap.L().Info("Exported "+name,
+		zap.Int("count", n),
+		zap.String("format", string(format)),
+		zap.Duration("duration", time.Since(start)),
+	)
+	return nil
+}
+
+// keepLogsOutOf sends db's query logs (see --prod), which go to stdout by default, to stderr if out is
+// stdout, so they don't end up in the middle of an export written there.
+func keepLogsOutOf(db *gorm.DB, out io.Writer) {
+	if out == io.Writer(os.Stdout) {
+		db.SetLogger(gorm.Logger{LogWriter: log.New(os.Stderr, "\r\n", 0)})
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	for _, c := range []*cobra.Command{exportCharsCmd, exportTombstonesCmd} {
+		exportCmd.AddCommand(c)
+		c.Flags().StringP("format", "f", string(export.CSV), "output format: csv, jsonl or parquet")
+		c.Flags().StringP("output", "o", "-", "file to write to, or - for stdout")
+		c.Flags().Int("chunk-size", export.DefaultChunkSize, "number of records to read from the database at a time")
+	}
+	addCharacterFilterFlags(exportCharsCmd.Flags())
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+// captureStderr replaces os.Stderr with a pipe, and returns a function that puts it back, and
+// returns everything written to it in the meantime.
+func captureStderr(t *testing.T) func() string {
+	realStderr := os.Stderr
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stderr = w
+	t.Cleanup(func() { os.Stderr = realStderr })
+
+	return func() string {
+		os.Stderr = realStderr
+		require.NoError(t, w.Close())
+		var buf bytes.Buffer
+		_, err := io.Copy(&buf, r)
+		require.NoError(t, err)
+		return buf.String()
+	}
+}
+
+func TestKeepLogsOutOf(t *testing.T) {
+	for name, out := range map[string]io.Writer{"Stdout": os.Stdout, "File": &bytes.Buffer{}} {
+		t.Run(name, func(t *testing.T) {
+			stderr := captureStderr(t)
+			db, err := gorm.Open("sqlite3", filepath.Join(t.TempDir(), "gubal.db"))
+			require.NoError(t, err)
+			defer db.Close()
+			db = db.LogMode(true).Debug()
+
+			keepLogsOutOf(db, out)
+			require.NoError(t, db.Exec(`SELECT 1`).Error)
+			if out == io.Writer(os.Stdout) {
+				assert.Contains(t, stderr(), "SELECT 1")
+			} else {
+				assert.Empty(t, stderr())
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strconv"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/liclac/gubal/fetcher"
@@ -13,8 +14,11 @@ import (
 var fetchCharCmd = &cobra.Command{
 	Use:   "char [id] [num]",
 	Short: "Queue up characters to be fetched",
-	Long:  `Queue up characters to be fetched.`,
-	Args:  cobra.RangeArgs(1, 2),
+	Long: `Queue up characters to be fetched.
+
+By default, only the mobile version of each page is fetched. --page=desktop,mobile fetches the
+desktop version instead, falling back to the mobile one if it can't be parsed.`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		startID, err := strconv.ParseInt(args[0], 10, 64)
 		if err != nil {
@@ -28,10 +32,24 @@ var fetchCharCmd = &cobra.Command{
 			}
 			endID = id
 		}
+		regionStr, _ := cmd.Flags().GetString("region")
+		region := fetcher.Region(regionStr)
+		if !region.Valid() {
+			return errors.Errorf("unknown region: %s", region)
+		}
+		pageStrs, _ := cmd.Flags().GetStringSlice("page")
+		var pages []fetcher.Page
+		for _, s := range pageStrs {
+			page := fetcher.Page(s)
+			if !page.Valid() {
+				return errors.Errorf("unknown page: %s", page)
+			}
+			pages = append(pages, page)
+		}
 
 		var bodies [][]byte
 		for id := startID; id <= endID; id++ {
-			job := fetcher.FetchCharacterJob{ID: id}
+			job := fetcher.FetchCharacterJob{ID: id, Region: region, Pages: pages}
 			msg := fetcher.FetchMessage{Job: job}
 			body, err := json.Marshal(msg)
 			if err != nil {
@@ -50,4 +68,7 @@ var fetchCharCmd = &cobra.Command{
 
 func init() {
 	fetchCmd.AddCommand(fetchCharCmd)
+
+	fetchCharCmd.Flags().String("region", string(fetcher.DefaultRegion), "Lodestone to fetch from: na, eu, jp, fr or de")
+	fetchCharCmd.Flags().StringSlice("page", nil, "versions of the page to try, in order: mobile, desktop (default mobile)")
 }
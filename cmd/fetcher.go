@@ -3,16 +3,23 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"path"
 	"sync"
+	"syscall"
 	"time"
 
+	"github.com/jinzhu/gorm"
 	"github.com/nsqio/go-nsq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
@@ -28,60 +35,126 @@ var fetcherCmd = &cobra.Command{
 	Long:  `Run a fetcher process.`,
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// Set up tracing first, so spans are flushed after everything else has shut down.
+		shutdownTracing, err := setupTracing(context.Background(), "gubal-fetcher", viper.GetString("trace"))
+		if err != nil {
+			return err
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				zap.L().Warn("Couldn't flush traces", zap.Error(err))
+			}
+		}()
+
 		var wg sync.WaitGroup
 		defer wg.Wait()
 
 		concurrency := viper.GetInt("concurrency")
-		zap.L().Info("Starting fetcher...", zap.Int("concurrency", concurrency))
+		dryRun := viper.GetBool("dry-run")
+		shutdownTimeout := viper.GetDuration("shutdown-timeout")
+		zap.L().Info("Starting fetcher...", zap.Int("concurrency", concurrency), zap.Bool("dry_run", dryRun))
 
+		// Catch signals before connecting to anything, so an early one still shuts down cleanly.
+		sigC := make(chan os.Signal, 2)
+		signal.Notify(sigC, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigC)
+
+		// Jobs run under this context, which is only cancelled if they fail to drain on shutdown.
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		// Prepare a cache...
+		// Prepare a cache... a dry run can read it, but mustn't record anything in it.
 		wd, err := os.Getwd()
 		if err != nil {
 			return err
 		}
-		cacheFS := afero.NewBasePathFs(afero.NewOsFs(), path.Join(wd, "cache"))
+		var cacheFS afero.Fs = afero.NewBasePathFs(afero.NewOsFs(), path.Join(wd, "cache"))
+		if dryRun {
+			cacheFS = afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(cacheFS), afero.NewMemMapFs())
+		}
 		ctx = fetcher.WithCacheFS(ctx, cacheFS)
 
-		// Connect to the database...
-		db, err := dbConnect()
+		// Load page layouts up front, so a broken --layouts file fails now, rather than every job.
+		layouts, err := loadLayouts()
 		if err != nil {
 			return err
 		}
-		defer db.Close()
+		ctx = fetcher.WithLayouts(ctx, layouts)
+
+		// Connect to the database... or, for a dry run, keep everything in memory.
+		health := &fetcher.Health{StallTimeout: viper.GetDuration("stall-timeout")}
+		var db *gorm.DB
+		if dryRun {
+			ctx = models.WithDataStore(ctx, models.NewTracedDataStore(models.NewMemoryDataStore()))
+		} else {
+			db, err = dbConnect()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			// Adjust pool size to concurrency; SQLite can only have one connection anyway.
+			if db.Dialect().GetName() != "sqlite3" {
+				db.DB().SetMaxOpenConns(concurrency * 2)
+				db.DB().SetMaxIdleConns(concurrency * 2)
+			}
+			health.DB = db.DB()
+		}
 
-		// Adjust pool size to concurrency.
-		db.DB().SetMaxOpenConns(concurrency * 2)
-		db.DB().SetMaxIdleConns(concurrency * 2)
+		// Serve metrics and health checks, if asked to.
+		if addr := viper.GetString("metrics-listen"); addr != "" {
+			lis, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.Handler())
+			mux.Handle("/healthz", health)
+			mux.Handle("/readyz", health)
+			srv := &http.Server{Handler: mux}
+			go func() {
+				if err := srv.Serve(lis); err != http.ErrServerClosed {
+					zap.L().Error("Metrics listener failed", zap.Error(err))
+				}
+			}()
+			defer srv.Close()
+			zap.L().Info("Serving metrics and health checks...", zap.String("addr", lis.Addr().String()))
+		}
 
-		// Connect to NSQ...
+		// Connect to NSQ... a dry run uses an ephemeral channel, so it gets its own copy of every
+		// message instead of stealing them from real fetchers.
 		prod, err := newNSQProducer()
 		if err != nil {
 			return err
 		}
-		cons, err := newNSQConsumer(fetcher.FetchTopic, "fetcher")
-		if err != nil {
-			return err
+		defer prod.Stop()
+		channel := "fetcher"
+		if dryRun {
+			channel = "fetcher-dry-run#ephemeral"
 		}
-		cons.ChangeMaxInFlight(concurrency)
-		cons.AddConcurrentHandlers(nsq.HandlerFunc(func(m *nsq.Message) (rerr error) {
+		// Both topics' consumers share one pool of --concurrency slots, so jobs from the priority
+		// topic don't come on top of the normal topic's, and overrun the database's connection pool.
+		slots := make(chan struct{}, concurrency)
+		handler := nsq.HandlerFunc(func(m *nsq.Message) (rerr error) {
 			wg.Add(1)
 			defer wg.Done()
+			if err := waitForSlot(ctx, slots, m); err != nil {
+				m.RequeueWithoutBackoff(0)
+				return err
+			}
+			defer func() { <-slots }()
+			jobDone := health.StartJob()
+			defer func() { jobDone(rerr) }()
 
-			// TODO: Stop tying datastores to database instances, this is really wasteful.
-			// You have the context right there, just use it >_>
-			tx := db.Begin()
+			// A job cut short by a shutdown isn't at fault; hand it straight to another fetcher,
+			// rather than backing off from it. Its transaction has already been rolled back.
 			defer func() {
-				if rerr != nil {
-					rerr = multierr.Append(rerr, tx.Rollback().Error)
-				} else {
-					rerr = multierr.Append(rerr, tx.Commit().Error)
+				if rerr != nil && ctx.Err() != nil {
+					m.RequeueWithoutBackoff(0)
 				}
 			}()
-			ctx = lib.WithRawDB(ctx, tx)
-			ctx = models.WithDataStore(ctx, models.NewDataStore(db))
 
 			zap.L().Debug("Processing...",
 				zap.ByteString("body", m.Body),
@@ -93,7 +166,36 @@ var fetcherCmd = &cobra.Command{
 				return err
 			}
 
-			jobs, err := msg.Job.Run(ctx)
+			// Every message starts a trace of its own, linked to whatever enqueued it, rather than
+			// continuing its trace; otherwise a whole crawl would be one never-ending trace.
+			opts := []trace.SpanStartOption{
+				trace.WithNewRoot(),
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(
+					attribute.String("messaging.system", "nsq"),
+					attribute.String("messaging.message.id", string(m.ID[:])),
+					attribute.Int("messaging.nsq.attempts", int(m.Attempts)),
+				),
+			}
+			if sc := msg.SpanContext(); sc.IsValid() {
+				opts = append(opts, trace.WithLinks(trace.Link{SpanContext: sc}))
+			}
+			ctx, span := tracer.Start(ctx, "receive "+msg.Job.Type(), opts...)
+			defer func() { lib.EndSpan(span, rerr) }()
+
+			if dryRun {
+				start := time.Now()
+				jobs, err := fetcher.RunJob(ctx, msg.Job)
+				fetcher.ObserveJob(msg.Job, time.Since(start), err)
+				for _, job := range jobs {
+					zap.L().Info("Not enqueueing job in a dry run", zap.String("type", job.Type()))
+				}
+				return err
+			}
+
+			start := time.Now()
+			jobs, err := runJobInTx(ctx, db, msg.Job)
+			fetcher.ObserveJob(msg.Job, time.Since(start), err)
 			if err != nil {
 				return err
 			}
@@ -101,7 +203,7 @@ var fetcherCmd = &cobra.Command{
 			case 0:
 				return nil
 			case 1:
-				data, err := json.Marshal(fetcher.FetchMessage{Job: jobs[0]})
+				data, err := json.Marshal(fetcher.NewFetchMessage(ctx, jobs[0]))
 				if err != nil {
 					return err
 				}
@@ -109,7 +211,7 @@ var fetcherCmd = &cobra.Command{
 			default:
 				bodies := make([][]byte, len(jobs))
 				for i, job := range jobs {
-					data, err := json.Marshal(fetcher.FetchMessage{Job: job})
+					data, err := json.Marshal(fetcher.NewFetchMessage(ctx, job))
 					if err != nil {
 						return err
 					}
@@ -117,28 +219,111 @@ var fetcherCmd = &cobra.Command{
 				}
 				return prod.MultiPublish(fetcher.FetchTopic, bodies)
 			}
-		}), concurrency)
-		if err := nsqConsumerConnect(cons); err != nil {
-			return err
+		})
+
+		// Consume the priority topic alongside the normal one; since it's normally empty, whatever
+		// does land on it takes the next free slot, rather than waiting for the normal topic's backlog.
+		var consumers []*nsq.Consumer
+		for _, topic := range []string{fetcher.FetchTopic, fetcher.FetchPriorityTopic} {
+			cons, err := newNSQConsumer(topic, channel)
+			if err != nil {
+				return err
+			}
+			cons.ChangeMaxInFlight(concurrency)
+			cons.AddConcurrentHandlers(handler, concurrency)
+			health.AddConsumer(topic, cons)
+			consumers = append(consumers, cons)
+			defer func() {
+				cons.Stop()
+				<-cons.StopChan
+			}()
+			if err := nsqConsumerConnect(cons); err != nil {
+				return err
+			}
 		}
-		defer func() {
+
+		// Wait for a signal, then stop taking new messages, and give the jobs in flight until
+		// --shutdown-timeout to finish. Any still running then, or after a second signal, are
+		// cancelled: their transactions roll back, and their messages are requeued.
+		sig := <-sigC
+		zap.L().Info("Shutting down...", zap.Stringer("signal", sig), zap.Duration("timeout", shutdownTimeout))
+		for _, cons := range consumers {
 			cons.Stop()
-			<-cons.StopChan
+		}
+		drained := make(chan struct{})
+		go func() {
+			// Consumers stop once their in-flight messages have been responded to, but give up on
+			// them after 30s, so wait for our own handlers too.
+			for _, cons := range consumers {
+				<-cons.StopChan
+			}
+			wg.Wait()
+			close(drained)
 		}()
-
-		// Wait for a signal, then cancel the connection and wait.
-		sigC := make(chan os.Signal, 1)
-		signal.Notify(sigC)
-		signal.Stop(sigC)
-		<-sigC
+		select {
+		case <-drained:
+		case <-time.After(shutdownTimeout):
+			zap.L().Warn("Jobs didn't finish in time, cancelling them...")
+		case <-sigC:
+			zap.L().Warn("Signalled again, cancelling jobs...")
+		}
 		cancel()
-
+		<-drained
+		zap.L().Info("Shut down cleanly")
 		return nil
 	},
 }
 
+// slotTouchInterval is how often a message waiting for a slot is touched; well within nsqd's
+// default message timeout of 60s.
+const slotTouchInterval = 20 * time.Second
+
+// waitForSlot blocks until there's room in slots for another job, or ctx is cancelled. The message
+// is touched while it waits, so nsqd doesn't time it out and hand it to someone else meanwhile.
+func waitForSlot(ctx context.Context, slots chan struct{}, m *nsq.Message) error {
+	ticker := time.NewTicker(slotTouchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case slots <- struct{}{}:
+			return nil
+		case <-ticker.C:
+			m.Touch()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// runJobInTx runs a job in its own transaction, with a DataStore bound to it, so that a failed job
+// doesn't leave half its writes behind. Resulting jobs should only be enqueued once it's committed.
+// The transaction is begun with ctx, so the driver cancels its running queries when the job is.
+func runJobInTx(ctx context.Context, db *gorm.DB, job fetcher.Job) (jobs []fetcher.Job, rerr error) {
+	start := time.Now()
+	tx := db.BeginTx(ctx, nil)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rerr != nil {
+			rerr = multierr.Append(rerr, tx.Rollback().Error)
+		} else {
+			rerr = multierr.Append(rerr, tx.Commit().Error)
+		}
+		fetcher.ObserveTx(time.Since(start), rerr == nil)
+	}()
+	ctx = lib.WithRawDB(ctx, tx)
+	ctx = models.WithDataStore(ctx, models.NewTracedDataStore(models.NewDataStore(tx)))
+	return fetcher.RunJob(ctx, job)
+}
+
 func init() {
 	rootCmd.AddCommand(fetcherCmd)
-	fetcherCmd.Flags().IntP("concurrency", "c", 10, "concurrent jobs to process")
+	fetcherCmd.Flags().IntP("concurrency", "c", 10, "concurrent jobs to process, across the normal and priority topics")
+	fetcherCmd.Flags().Bool("dry-run", false, "process jobs without writing anything to the database")
+	fetcherCmd.Flags().String("metrics-listen", "", "address to serve Prometheus metrics (/metrics) and health checks (/healthz, /readyz) on, eg. :9150; neither is served without it")
+	fetcherCmd.Flags().Duration("shutdown-timeout", 20*time.Second, "on SIGINT/SIGTERM, how long to let jobs in flight finish before cancelling them; nsq gives up on them after 30s")
+	fetcherCmd.Flags().Duration("stall-timeout", fetcher.DefaultStallTimeout, "fail /healthz if jobs are in flight, but none have finished in this long; only served with --metrics-listen")
+	fetcherCmd.Flags().String("trace", "", "export traces: stdout, or otlp for an OpenTelemetry collector (see OTEL_EXPORTER_OTLP_ENDPOINT)")
 	must(viper.BindPFlags(fetcherCmd.Flags()))
 }
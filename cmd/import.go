@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/liclac/gubal/export"
+	"github.com/liclac/gubal/models"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import data from an export",
+	Long: `Import data from an export.
+
+Reads CSV or JSON Lines files in the shape written by gubal export, eg. to seed a new environment
+from someone else's dump instead of recrawling the Lodestone.`,
+}
+
+// importCharsCmd represents the import characters command
+var importCharsCmd = &cobra.Command{
+	Use:   "characters [file...]",
+	Short: "Import characters",
+	Long: `Import characters.
+
+Reads files written by gubal export characters, or stdin if none (or -) are given, and upserts every
+character in them the same way a fetch would: existing characters and levels are overwritten, and
+marked as seen now. Rows are written --batch-size at a time, each batch in its own transaction, so
+a bad row only aborts the import from its batch onwards.
+
+The format is taken from each file's extension (.csv or .jsonl), unless --format is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		formatStr, _ := cmd.Flags().GetString("format")
+		format := export.Format(formatStr)
+		if format != "" && !format.Valid() {
+			return errors.Errorf("unknown format: %s", format)
+		}
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+		if batchSize <= 0 {
+			return errors.Errorf("invalid batch size: %d", batchSize)
+		}
+		if len(args) == 0 {
+			args = []string{"-"}
+		}
+
+		db, err := dbConnect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		ctx := context.Background()
+		for _, path := range args {
+			start := time.Now()
+			n, err := importCharacters(ctx, db, path, format, batchSize)
+			if err != nil {
+				return errors.Wrap(err, path)
+			}
+			zap.L().Info("Imported characters",
+				zap.String("path", path),
+				zap.Int("count", n),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+		return nil
+	},
+}
+
+// importCharacters imports the characters in a file, or stdin if it's "-", and returns how many
+// there were. If format is blank, it's taken from the file's extension.
+func importCharacters(ctx context.Context, db *gorm.DB, path string, format export.Format, batchSize int) (int, error) {
+	if format == "" {
+		format = export.Format(strings.TrimPrefix(filepath.Ext(path), "."))
+		if !format.Valid() {
+			return 0, errors.New("can't tell the format from the extension; pass --format")
+		}
+	}
+
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		in = f
+	}
+	r, err := export.NewReader(format, in, export.CharacterColumns)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for done := false; !done; {
+		var rows [][]interface{}
+		for len(rows) < batchSize {
+			row, err := r.Read()
+			if err == io.EOF {
+				done = true
+				break
+			}
+			if err != nil {
+				return n, err
+			}
+			rows = append(rows, row)
+		}
+		if err := importCharactersBatch(ctx, db, rows); err != nil {
+			return n, err
+		}
+		n += len(rows)
+		if !done {
+			zap.L().Debug("Imported batch", zap.Int("count", n))
+		}
+	}
+	return n, nil
+}
+
+// importCharactersBatch saves a batch of rows of export.CharacterColumns in a transaction.
+func importCharactersBatch(ctx context.Context, db *gorm.DB, rows [][]interface{}) (rerr error) {
+	if len(rows) == 0 {
+		return nil
+	}
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if rerr != nil {
+			rerr = multierr.Append(rerr, tx.Rollback().Error)
+		} else {
+			rerr = multierr.Append(rerr, tx.Commit().Error)
+		}
+	}()
+	var chs []*models.Character
+	var lvls []*models.Level
+	for _, row := range rows {
+		ch, chLvls, err := export.ParseCharacterRow(row)
+		if err != nil {
+			return errors.Wrapf(err, "character %v", row[0])
+		}
+		chs = append(chs, ch)
+		lvls = append(lvls, chLvls...)
+	}
+	return export.SaveCharacters(ctx, models.NewDataStore(tx), chs, lvls)
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importCharsCmd)
+	importCharsCmd.Flags().StringP("format", "f", "", "input format: csv or jsonl; defaults to the file extension")
+	importCharsCmd.Flags().Int("batch-size", 1000, "number of characters to write per transaction")
+}
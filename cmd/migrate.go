@@ -15,13 +15,22 @@ import (
 	"github.com/spf13/viper"
 )
 
-func migrationsDir() string {
+// migrationsDir returns the migrations directory for a dialect. Postgres migrations live directly
+// in migrations/, other dialects' in a subdirectory, eg. migrations/sqlite3/.
+func migrationsDir(dialect string) string {
 	wd, _ := os.Getwd()
-	return path.Join(wd, "migrations")
+	if dialect == "postgres" {
+		return path.Join(wd, "migrations")
+	}
+	return path.Join(wd, "migrations", dialect)
 }
 
 func newMigrate() (*migrate.Migrate, error) {
-	return migrate.New("file://"+migrationsDir(), viper.GetString("db"))
+	dialect, err := dbDialect()
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New("file://"+migrationsDir(dialect), viper.GetString("db"))
 }
 
 // Helper to make error handling from migrate.Migrate.Close() cleaner.
@@ -41,16 +50,18 @@ var migrateCmd = &cobra.Command{
 var migrateCreateCmd = &cobra.Command{
 	Use:   "create NAME",
 	Short: "Create a new migration",
-	Long:  `Create a new migration.`,
+	Long:  `Create a new migration, for every supported database.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		dir := migrationsDir()
 		basename := fmt.Sprintf("%d_%s", time.Now().Unix(), args[0])
-		if _, err := os.Create(path.Join(dir, basename+".up.sql")); err != nil {
-			return err
-		}
-		if _, err := os.Create(path.Join(dir, basename+".down.sql")); err != nil {
-			return err
+		for _, dialect := range []string{"postgres", "sqlite3"} {
+			dir := migrationsDir(dialect)
+			if _, err := os.Create(path.Join(dir, basename+".up.sql")); err != nil {
+				return err
+			}
+			if _, err := os.Create(path.Join(dir, basename+".down.sql")); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/jinzhu/gorm"
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/liclac/gubal/fetcher"
+	"github.com/liclac/gubal/models"
+)
+
+// parseFailuresCmd represents the parse-failures command
+var parseFailuresCmd = &cobra.Command{
+	Use:   "parse-failures",
+	Short: "Manage pages the fetcher couldn't parse",
+	Long: `Manage pages the fetcher couldn't parse.
+
+Character pages that fail to parse, eg. because of a new world or job, are quarantined with the raw
+page, instead of being retried forever. Once the parser's been fixed (and fetcher.ParserVersion
+bumped), they can be re-parsed with gubal parse-failures rerun; if it was the page layout that
+changed, add a layout to a --layouts file and re-parse them with gubal parse-failures rerun --all.`,
+}
+
+// parseFailuresListCmd represents the parse-failures list command
+var parseFailuresListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List quarantined pages",
+	Long: `List quarantined pages.
+
+If there may be more results, a cursor for the next page is printed to stderr; pass it to --after
+to continue.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		after, _ := cmd.Flags().GetInt64("after")
+		limit, _ := cmd.Flags().GetInt("limit")
+		beforeVersion := 0
+		if stale, _ := cmd.Flags().GetBool("stale"); stale {
+			beforeVersion = fetcher.ParserVersion
+		}
+
+		db, err := dbConnect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		fs, err := models.NewDataStore(db).ParseFailures().List(context.Background(), after, limit, beforeVersion)
+		if err != nil {
+			return err
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			for _, f := range fs {
+				if err := enc.Encode(f); err != nil {
+					return err
+				}
+			}
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tREGION\tVERSION\tUPDATED\tERRORS")
+			for _, f := range fs {
+				fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\n",
+					f.CharacterID, f.Region, f.ParserVersion, f.UpdatedAt.Format("2006-01-02 15:04"),
+					strings.Join(f.Errors, "; "))
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+
+		if len(fs) == limit {
+			fmt.Fprintf(os.Stderr, "next: --after=%d\n", fs[len(fs)-1].CharacterID)
+		}
+		return nil
+	},
+}
+
+// parseFailuresRerunCmd represents the parse-failures rerun command
+var parseFailuresRerunCmd = &cobra.Command{
+	Use:   "rerun [id...]",
+	Short: "Re-parse quarantined pages",
+	Long: `Re-parse quarantined pages.
+
+Parses the given characters' quarantined pages again, with the current parser, or if none are given,
+every page quarantined by an older parser version (or every page at all, with --all). Pages that
+parse are saved like a fetch would, and released from quarantine; the rest are kept, with their
+errors updated. Each page is re-parsed in its own transaction.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		ids := make([]int64, len(args))
+		for i, arg := range args {
+			id, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return err
+			}
+			ids[i] = id
+		}
+
+		layouts, err := loadLayouts()
+		if err != nil {
+			return err
+		}
+
+		db, err := dbConnect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		ctx = fetcher.WithLayouts(ctx, layouts)
+
+		var fixed, failed int
+		rerun := func(id int64) error {
+			ok, err := reparseFailure(ctx, db, id)
+			if err != nil {
+				return err
+			}
+			if ok {
+				fixed++
+				zap.L().Info("Re-parsed character", zap.Int64("id", id))
+			} else {
+				failed++
+				zap.L().Info("Character still doesn't parse", zap.Int64("id", id))
+			}
+			return nil
+		}
+
+		if len(ids) > 0 {
+			for _, id := range ids {
+				if err := rerun(id); err != nil {
+					return err
+				}
+			}
+		} else {
+			beforeVersion := fetcher.ParserVersion
+			if all {
+				beforeVersion = 0
+			}
+			ds := models.NewDataStore(db)
+			for after := int64(0); ; {
+				fs, err := ds.ParseFailures().List(ctx, after, 100, beforeVersion)
+				if err != nil {
+					return err
+				}
+				for _, f := range fs {
+					if err := rerun(f.CharacterID); err != nil {
+						return err
+					}
+				}
+				if len(fs) < 100 {
+					break
+				}
+				after = fs[len(fs)-1].CharacterID
+			}
+		}
+		zap.L().Info("Re-parsed quarantined pages", zap.Int("fixed", fixed), zap.Int("failed", failed))
+		return nil
+	},
+}
+
+// reparseFailure re-parses a character's quarantined page in a transaction, and returns whether it
+// parsed this time.
+func reparseFailure(ctx context.Context, db *gorm.DB, id int64) (ok bool, rerr error) {
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return false, err
+	}
+	defer func() {
+		if rerr != nil {
+			rerr = multierr.Append(rerr, tx.Rollback().Error)
+		} else {
+			rerr = multierr.Append(rerr, tx.Commit().Error)
+		}
+	}()
+	ds := models.NewDataStore(tx)
+	f, err := ds.ParseFailures().Get(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return fetcher.ReparseFailure(models.WithDataStore(ctx, ds), f)
+}
+
+func init() {
+	rootCmd.AddCommand(parseFailuresCmd)
+
+	parseFailuresCmd.AddCommand(parseFailuresListCmd)
+	parseFailuresListCmd.Flags().Int64("after", 0, "character ID to continue from, as printed by a previous listing")
+	parseFailuresListCmd.Flags().Int("limit", 100, "max number of results")
+	parseFailuresListCmd.Flags().Bool("stale", false, "only list pages quarantined by an older parser version")
+	parseFailuresListCmd.Flags().Bool("json", false, "print results as JSON, one per line")
+
+	parseFailuresCmd.AddCommand(parseFailuresRerunCmd)
+	parseFailuresRerunCmd.Flags().Bool("all", false, "re-parse every page, not just ones quarantined by an older parser version")
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// queryCmd represents the query command
+var queryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Query the database",
+	Long:  `Query the database.`,
+}
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+}
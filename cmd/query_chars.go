@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/liclac/gubal/api"
+	"github.com/liclac/gubal/models"
+)
+
+// characterFilterFlags are the flags added by addCharacterFilterFlags that map straight onto API
+// search parameters; --level is handled separately, as it's repeatable.
+var characterFilterFlags = []string{
+	"name", "world", "race", "clan", "gender", "guardian", "city-state", "gc", "gc-rank", "title",
+}
+
+// addCharacterFilterFlags adds flags for filtering characters, shared by every command that does.
+func addCharacterFilterFlags(fs *pflag.FlagSet) {
+	fs.String("name", "", "name prefix, eg. \"Emi H\"; case insensitive")
+	fs.String("world", "", "world, eg. Ultros")
+	fs.String("race", "", "race, eg. AuRa")
+	fs.String("clan", "", "clan, eg. Raen")
+	fs.String("gender", "", "gender, ♀ or ♂")
+	fs.String("guardian", "", "guardian, eg. Menphina")
+	fs.String("city-state", "", "starting city-state, eg. Limsa")
+	fs.String("gc", "", "grand company, eg. Maelstrom")
+	fs.Int("gc-rank", 0, "grand company rank")
+	fs.String("title", "", "title, eg. \"Khloe's Friend\"")
+	fs.StringArray("level", nil, "level range, eg. PLD:50-60, PLD:50- or PLD:70; repeatable")
+}
+
+// characterQueryFromFlags builds a CharacterQuery from the flags added by addCharacterFilterFlags,
+// plus any extra flags that map onto search parameters, eg. "sort". It goes through the API's
+// parser, so the two can't drift apart.
+func characterQueryFromFlags(cmd *cobra.Command, extra ...string) (models.CharacterQuery, error) {
+	v := url.Values{}
+	for _, name := range append(characterFilterFlags, extra...) {
+		if f := cmd.Flags().Lookup(name); f.Changed {
+			v.Set(strings.Replace(name, "-", "_", -1), f.Value.String())
+		}
+	}
+	levels, err := cmd.Flags().GetStringArray("level")
+	if err != nil {
+		return models.CharacterQuery{}, err
+	}
+	v["level"] = levels
+	if desc, _ := cmd.Flags().GetBool("desc"); desc {
+		v.Set("order", "desc")
+	}
+	return api.ParseCharacterQuery(v)
+}
+
+// queryCharsCmd represents the query chars command
+var queryCharsCmd = &cobra.Command{
+	Use:   "chars",
+	Short: "Search for characters",
+	Long: `Search for characters.
+
+Takes the same filters as the API's /characters endpoint. If there may be more results, a cursor
+for the next page is printed to stderr; pass it to --after to continue.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		q, err := characterQueryFromFlags(cmd, "sort", "after", "limit")
+		if err != nil {
+			return err
+		}
+
+		db, err := dbConnect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		ctx := context.Background()
+		chars, err := models.NewDataStore(db).Characters().Search(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			for _, ch := range chars {
+				if err := enc.Encode(ch); err != nil {
+					return err
+				}
+			}
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tWORLD\tRACE\tCLAN\tGENDER\tUPDATED")
+			for _, ch := range chars {
+				fmt.Fprintf(w, "%d\t%s %s\t%s\t%s\t%s\t%s\t%s\n",
+					ch.ID, ch.FirstName, ch.LastName, ch.World, ch.Race, ch.Clan, ch.Gender,
+					ch.UpdatedAt.Format("2006-01-02 15:04"))
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+
+		if len(chars) == q.Limit {
+			fmt.Fprintf(os.Stderr, "next: --after=%s\n", q.CursorFor(chars[len(chars)-1]))
+		}
+		return nil
+	},
+}
+
+func init() {
+	queryCmd.AddCommand(queryCharsCmd)
+	addCharacterFilterFlags(queryCharsCmd.Flags())
+	queryCharsCmd.Flags().String("sort", "id", "sort by: id, first_name, last_name, updated_at or seen_at")
+	queryCharsCmd.Flags().Bool("desc", false, "sort in descending order")
+	queryCharsCmd.Flags().String("after", "", "cursor to continue from, as printed by a previous search")
+	queryCharsCmd.Flags().Int("limit", models.DefaultSearchLimit, "max number of results")
+	queryCharsCmd.Flags().Bool("json", false, "print results as JSON, one per line")
+}
@@ -46,7 +46,8 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("prod", "P", false, "run in production mode")
 	rootCmd.PersistentFlags().String("nsqd", "127.0.0.1:4150", "nsqd instance for publishing")
 	rootCmd.PersistentFlags().String("nsqlookupd", "127.0.0.1:4161", "nsqlookupd instance for consumption")
-	rootCmd.PersistentFlags().StringP("db", "d", "postgres:///gubal?sslmode=disable", "database connection string")
+	rootCmd.PersistentFlags().StringP("db", "d", "postgres:///gubal?sslmode=disable", "database connection string, eg. postgres:///gubal or sqlite3://gubal.db")
+	rootCmd.PersistentFlags().String("layouts", "", "YAML file describing the Lodestone's page layouts, instead of the built-in ones")
 	must(viper.BindPFlags(rootCmd.PersistentFlags()))
 }
 
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/liclac/gubal/api"
+	"github.com/liclac/gubal/models"
+	"github.com/liclac/gubal/rpc"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the API server",
+	Long:  `Run the API server.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := dbConnect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		prod, err := newNSQProducer()
+		if err != nil {
+			return err
+		}
+		defer prod.Stop()
+
+		ds := models.NewDataStore(db)
+		srv := &http.Server{
+			Addr:    viper.GetString("listen"),
+			Handler: api.NewHandler(ds, prod),
+		}
+		errC := make(chan error, 2)
+		go func() { errC <- srv.ListenAndServe() }()
+		zap.L().Info("Listening...", zap.String("addr", srv.Addr))
+
+		// Serve gRPC on a separate port, if asked to.
+		if addr := viper.GetString("grpc-listen"); addr != "" {
+			lis, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+			grpcSrv := rpc.NewGRPCServer(ds, prod)
+			go func() { errC <- grpcSrv.Serve(lis) }()
+			defer grpcSrv.GracefulStop()
+			zap.L().Info("Listening for gRPC...", zap.String("addr", addr))
+		}
+
+		// Serve until we get a signal, then give in-flight requests a moment to finish.
+		sigC := make(chan os.Signal, 1)
+		signal.Notify(sigC, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigC)
+		select {
+		case err := <-errC:
+			return err
+		case <-sigC:
+		}
+		zap.L().Info("Shutting down...")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return srv.Shutdown(ctx)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringP("listen", "l", "127.0.0.1:8080", "address to listen on")
+	serveCmd.Flags().String("grpc-listen", "", "address to serve gRPC on, eg. 127.0.0.1:8081; off if empty")
+	must(viper.BindPFlags(serveCmd.Flags()))
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Manage precomputed statistics",
+	Long:  `Manage precomputed statistics.`,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+}
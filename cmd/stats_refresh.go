@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/liclac/gubal/models"
+)
+
+// statsRefreshCmd represents the stats refresh command
+var statsRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Recompute statistics",
+	Long: `Recompute statistics.
+
+The stats served by the API are precomputed, rather than counted up on every request; this
+rebuilds them from the current contents of the database. Until it's first run, they're counted up
+live, which is slow on a large database. Pass --every to keep running and rebuild them periodically
+instead of just once.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		every, err := cmd.Flags().GetDuration("every")
+		if err != nil {
+			return err
+		}
+
+		db, err := dbConnect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		if every <= 0 {
+			return refreshStats(ctx, db)
+		}
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			if err := refreshStats(ctx, db); err != nil && ctx.Err() == nil {
+				zap.L().Error("Couldn't refresh stats", zap.Error(err))
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// refreshStats rebuilds precomputed stats in a transaction, so readers never see them half-built.
+func refreshStats(ctx context.Context, db *gorm.DB) (rerr error) {
+	start := time.Now()
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if rerr != nil {
+			rerr = multierr.Append(rerr, tx.Rollback().Error)
+		} else {
+			rerr = multierr.Append(rerr, tx.Commit().Error)
+		}
+	}()
+	if err := models.NewDataStore(tx).Stats().Refresh(ctx); err != nil {
+		return err
+	}
+	zap.L().Info("Refreshed stats", zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+func init() {
+	statsCmd.AddCommand(statsRefreshCmd)
+	statsRefreshCmd.Flags().Duration("every", 0, "keep running, and refresh this often, eg. 1h")
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/liclac/gubal/models"
+)
+
+// statsSnapshotCmd represents the stats snapshot command
+var statsSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record a census snapshot",
+	Long: `Record a census snapshot.
+
+Stores the current population per world, race, grand company and level bucket under today's date
+(or --date), so they can be charted over time through the API's /census endpoints. Taking another
+snapshot on the same date replaces it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) (rerr error) {
+		date := time.Now()
+		if s, _ := cmd.Flags().GetString("date"); s != "" {
+			d, err := time.Parse("2006-01-02", s)
+			if err != nil {
+				return err
+			}
+			date = d
+		}
+
+		db, err := dbConnect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		tx := db.Begin()
+		if err := tx.Error; err != nil {
+			return err
+		}
+		defer func() {
+			if rerr != nil {
+				rerr = multierr.Append(rerr, tx.Rollback().Error)
+			} else {
+				rerr = multierr.Append(rerr, tx.Commit().Error)
+			}
+		}()
+		start := time.Now()
+		if err := models.NewDataStore(tx).Census().Snapshot(context.Background(), date); err != nil {
+			return err
+		}
+		zap.L().Info("Recorded census snapshot",
+			zap.String("date", date.UTC().Format("2006-01-02")),
+			zap.Duration("duration", time.Since(start)),
+		)
+		return nil
+	},
+}
+
+func init() {
+	statsCmd.AddCommand(statsSnapshotCmd)
+	statsSnapshotCmd.Flags().String("date", "", "date to record the snapshot under, eg. 2018-03-18; defaults to today (UTC)")
+}
@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"log"
+	"os"
 	"strings"
 
 	"github.com/jinzhu/gorm"
 	nsq "github.com/nsqio/go-nsq"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"go.uber.org/multierr"
+
+	"github.com/liclac/gubal/fetcher"
 )
 
 func must(err error) {
@@ -16,22 +20,68 @@ func must(err error) {
 	}
 }
 
+// dbDialect returns the dialect for the configured database, from the db string's schema.
+func dbDialect() (string, error) {
+	parts := strings.SplitN(viper.GetString("db"), "://", 2)
+	if len(parts) != 2 {
+		return "", errors.New("db string lacks schema")
+	}
+	switch parts[0] {
+	case "postgres", "postgresql":
+		return "postgres", nil
+	case "sqlite3":
+		return "sqlite3", nil
+	default:
+		return "", errors.Errorf("unsupported database: %s", parts[0])
+	}
+}
+
 func dbConnect() (*gorm.DB, error) {
+	dialect, err := dbDialect()
+	if err != nil {
+		return nil, err
+	}
+
+	// Postgres takes the URI as-is, SQLite just wants a filename, eg. "sqlite3://gubal.db".
 	uri := viper.GetString("db")
-	parts := strings.SplitN(uri, "://", 2)
-	if len(parts) != 2 {
-		return nil, errors.New("db string lacks schema")
+	if dialect == "sqlite3" {
+		uri = strings.SplitN(uri, "://", 2)[1]
 	}
-	db, err := gorm.Open(parts[0], uri)
+	db, err := gorm.Open(dialect, uri)
 	if err != nil {
 		return nil, err
 	}
+	if dialect == "sqlite3" {
+		// SQLite doesn't enforce foreign keys by default, and only allows one writer at a time.
+		if err := db.Exec(`PRAGMA foreign_keys = ON`).Error; err != nil {
+			return nil, multierr.Append(err, db.Close())
+		}
+		db.DB().SetMaxOpenConns(1)
+	}
 	if !viper.GetBool("prod") {
 		db = db.LogMode(true).Debug()
 	}
 	return db, nil
 }
 
+// loadLayouts loads the page layouts to parse with from --layouts, or the built-in ones if unset.
+func loadLayouts() (fetcher.Layouts, error) {
+	path := viper.GetString("layouts")
+	if path == "" {
+		return fetcher.DefaultLayouts, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	ls, err := fetcher.LoadLayouts(f)
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+	return ls, nil
+}
+
 type nsqLogAdapter struct{}
 
 func (nsqLogAdapter) Output(calldepth int, s string) error { return log.Output(calldepth, s) }
@@ -3,22 +3,24 @@ from dash.dependencies import Input, Output
 import dash_core_components as dcc
 import dash_html_components as html
 import plotly.graph_objs as go
-from flask_sqlalchemy import SQLAlchemy
 import numpy as np
 import pandas as pd
+import requests
+import os
 
 TABLE_CLASSES = 'table table-hover table-sm'
 GC_COLORS = {
-    None: '#CCCCCC',
+    '': '#CCCCCC',
     'Adders': '#FFCC33',
     'Flames': '#AA77CC',
     'Maelstrom': '#FF5555',
 }
 
+# Base URL of a `gubal serve` instance to read stats from. They're only as fresh as the last
+# `gubal stats refresh`, so run that periodically, eg. with --every=1h.
+API_URL = os.environ.get('GUBAL_API', 'http://127.0.0.1:8080').rstrip('/')
+
 app = dash.Dash()
-app.server.config['SQLALCHEMY_DATABASE_URI'] = 'postgres:///gubal'
-app.server.config['SQLALCHEMY_TRACK_MODIFICATIONS'] = False
-db = SQLAlchemy(app.server)
 
 
 
@@ -48,16 +50,21 @@ def build_table(df, **kwargs):
         ]),
     ], **kwargs)
 
+def fetch_stats(name, **params):
+    res = requests.get('{}/stats/{}'.format(API_URL, name), params=params)
+    res.raise_for_status()
+    return pd.DataFrame(res.json())
+
 def build_gender_chart(**kwargs):
-    df = pd.read_sql('SELECT gender, COUNT(*) FROM characters GROUP BY gender ORDER BY gender DESC', db.engine, index_col='gender')
-    return go.Pie(labels=df.index.tolist(), values=df['count'], **kwargs)
+    df = fetch_stats('genders').sort_values('value', ascending=False)
+    return go.Pie(labels=df['value'].tolist(), values=df['count'], **kwargs)
 
 def build_race_chart(**kwargs):
-    df = pd.read_sql('SELECT race, COUNT(*) FROM characters GROUP BY race ORDER BY race DESC', db.engine, index_col='race')
-    return go.Pie(labels=df.index.tolist(), values=df['count'], **kwargs)
+    df = fetch_stats('races').sort_values('value', ascending=False)
+    return go.Pie(labels=df['value'].tolist(), values=df['count'], **kwargs)
 
 def build_race_clan_gender_chart(**kwargs):
-    df = pd.read_sql('SELECT race, clan, gender, COUNT(*) FROM characters GROUP BY race, clan, gender ORDER BY race, clan, gender DESC', db.engine, index_col=['race', 'clan', 'gender'])
+    df = fetch_stats('clans').sort_values(['race', 'clan', 'gender'], ascending=[True, True, False]).set_index(['race', 'clan', 'gender'])
 
     # TODO: clean up this mess
     races = df.index.get_level_values('race').unique()
@@ -70,28 +77,8 @@ def build_race_clan_gender_chart(**kwargs):
     return [go.Bar(x=races, **s, **kwargs) for s in series]
 
 def build_level_breakdown(**kwargs):
-    df = pd.read_sql('''
-        SELECT
-            CASE
-                WHEN level = 1 THEN 1
-                WHEN level > 1 AND level < 15 THEN 2
-                WHEN level = 15 THEN 15
-                WHEN level > 15 AND level < 30 THEN 16
-                WHEN level = 30 THEN 30
-                WHEN level > 30 AND level < 50 THEN 31
-                WHEN level = 50 THEN 50
-                WHEN level > 50 AND level < 60 THEN 51
-                WHEN level = 60 THEN 60
-                WHEN level > 60 AND level < 70 THEN 61
-                WHEN level = 70 THEN 70
-            END level_range,
-            job,
-            COUNT(*)
-        FROM levels
-        GROUP BY level_range, job
-        ORDER BY job, level_range ASC
-    ''', db.engine, index_col=['level_range', 'job'])
-    ranges = list(df.index.get_level_values('level_range').unique())
+    df = fetch_stats('levels').set_index(['min', 'job']).sort_index(level='min')
+    ranges = list(df.index.get_level_values('min').unique())
     jobs = list(df.index.get_level_values('job').unique())
     return [go.Bar(
         x=jobs,
@@ -100,8 +87,8 @@ def build_level_breakdown(**kwargs):
     ) for rng in ranges]
 
 def build_gc_chart(**kwargs):
-    df = pd.read_sql('SELECT gc, COUNT(*) FROM characters GROUP BY gc ORDER BY gc ASC', db.engine, index_col=['gc'])
-    gcs = df.index.tolist()
+    df = fetch_stats('gcs')
+    gcs = df['value'].tolist()
     colors = [GC_COLORS[gc] for gc in gcs]
     return go.Pie(
         labels=gcs,
@@ -114,8 +101,8 @@ def build_gc_chart(**kwargs):
     )
 
 def build_gc_breakdown(**kwargs):
-    df = pd.read_sql('SELECT gc, gc_rank, COUNT(*) FROM characters GROUP BY gc, gc_rank ORDER BY gc, gc_rank ASC', db.engine, index_col=['gc', 'gc_rank'])
-    gcs = list(df.index.get_level_values('gc').unique().dropna())
+    df = fetch_stats('gc-ranks').set_index(['gc', 'rank'])
+    gcs = list(df.index.get_level_values('gc').unique())
     return [go.Bar(
         y=df.loc[gc]['count'],
         name=gc,
@@ -124,20 +111,15 @@ def build_gc_breakdown(**kwargs):
     ) for gc in gcs]
 
 def build_world_breakdown(**kwargs):
-    df = pd.read_sql('SELECT world, COUNT(*) FROM characters GROUP BY world ORDER BY count DESC', db.engine, index_col=['world'])
-    worlds = list(df.index.values)
+    df = fetch_stats('worlds')
     return [go.Bar(
-        x=worlds,
+        x=df['value'].tolist(),
         y=df['count'],
         **kwargs,
     )]
 
-def build_title_table(**kwargs):
-    df = pd.read_sql('SELECT title, COUNT(*) FROM characters INNER JOIN character_titles ON characters.title_id = character_titles.id GROUP BY title ORDER BY count DESC LIMIT 10', db.engine)
-    return build_table(df, **kwargs)
-
-def build_top_table(col, limit=10, **kwargs):
-    df = pd.read_sql('SELECT {col}, COUNT(*) FROM characters GROUP BY {col} ORDER BY count DESC LIMIT {limit}'.format(col=col, limit=limit), db.engine)
+def build_top_table(name, col, limit=10, **kwargs):
+    df = fetch_stats(name, limit=limit).rename(columns={'value': col})
     return build_table(df, **kwargs)
 
 def build_layout():
@@ -232,15 +214,15 @@ def build_layout():
         html.Div([
             html.Div([
                 html.H5("Top 10 Titles"),
-                build_title_table(className=TABLE_CLASSES),
+                build_top_table('titles', 'title', className=TABLE_CLASSES),
             ], className='col-sm-4'),
             html.Div([
                 html.H5("Top 10 First Names"),
-                build_top_table('first_name', className=TABLE_CLASSES),
+                build_top_table('first-names', 'first_name', className=TABLE_CLASSES),
             ], className='col-sm-4'),
             html.Div([
                 html.H5("Top 10 Last Names"),
-                build_top_table('last_name', className=TABLE_CLASSES),
+                build_top_table('last-names', 'last_name', className=TABLE_CLASSES),
             ], className='col-sm-4'),
         ], className='row'),
     ], className='container')
@@ -0,0 +1,260 @@
+package export
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/liclac/gubal/models"
+)
+
+// DefaultChunkSize is how many records are fetched from the database at a time by default.
+const DefaultChunkSize = 1000
+
+// CharacterColumns are the columns of exported characters: every field of a Character, with its
+// title inlined, followed by a level column for every job, eg. level_pld. Levels a character
+// doesn't have are null.
+var CharacterColumns = func() []Column {
+	cols := []Column{
+		{Name: "id", Type: Int},
+		{Name: "first_name", Type: String},
+		{Name: "last_name", Type: String},
+		{Name: "title", Type: String, Optional: true},
+		{Name: "gender", Type: String},
+		{Name: "race", Type: String},
+		{Name: "clan", Type: String},
+		{Name: "guardian", Type: String},
+		{Name: "city_state", Type: String},
+		{Name: "world", Type: String},
+		{Name: "gc", Type: String, Optional: true},
+		{Name: "gc_rank", Type: Int},
+		{Name: "created_at", Type: Time},
+		{Name: "updated_at", Type: Time},
+		{Name: "seen_at", Type: Time},
+	}
+	for _, job := range models.Jobs {
+		cols = append(cols, Column{Name: LevelColumn(job), Type: Int, Optional: true})
+	}
+	return cols
+}()
+
+// TombstoneColumns are the columns of exported tombstones.
+var TombstoneColumns = []Column{
+	{Name: "id", Type: Int},
+	{Name: "created_at", Type: Time},
+}
+
+// LevelColumn returns the name of the column holding a job's level, eg. level_pld.
+func LevelColumn(job models.Job) string {
+	return "level_" + strings.ToLower(string(job))
+}
+
+// CharacterRow returns a character as a row of CharacterColumns.
+func CharacterRow(ch *models.Character, lvls []*models.Level) []interface{} {
+	row := []interface{}{
+		ch.ID,
+		ch.FirstName,
+		ch.LastName,
+		nil,
+		ch.Gender,
+		string(ch.Race),
+		string(ch.Clan),
+		string(ch.Guardian),
+		string(ch.CityState),
+		string(ch.World),
+		nil,
+		int64(ch.GCRank),
+		ch.CreatedAt,
+		ch.UpdatedAt,
+		ch.SeenAt,
+	}
+	if ch.Title != nil {
+		row[3] = ch.Title.Title
+	}
+	if ch.GC != nil {
+		row[10] = string(*ch.GC)
+	}
+	byJob := make(map[models.Job]int, len(lvls))
+	for _, lvl := range lvls {
+		byJob[lvl.Job] = lvl.Level
+	}
+	for _, job := range models.Jobs {
+		if lvl, ok := byJob[job]; ok {
+			row = append(row, int64(lvl))
+		} else {
+			row = append(row, nil)
+		}
+	}
+	return row
+}
+
+// ParseCharacterRow is the inverse of CharacterRow: it turns a row of CharacterColumns back into a
+// character and its levels. If it has a title, Title is set but hasn't been looked up; pass it to
+// SaveCharacters to take care of that.
+func ParseCharacterRow(row []interface{}) (*models.Character, []*models.Level, error) {
+	if err := checkRow(CharacterColumns, row); err != nil {
+		return nil, nil, err
+	}
+	ch := &models.Character{
+		ID:        row[0].(int64),
+		FirstName: row[1].(string),
+		LastName:  row[2].(string),
+		Gender:    row[4].(string),
+		Race:      models.CharacterRace(row[5].(string)),
+		Clan:      models.CharacterClan(row[6].(string)),
+		Guardian:  models.CharacterGuardian(row[7].(string)),
+		CityState: models.CityState(row[8].(string)),
+		World:     models.World(row[9].(string)),
+		GCRank:    int(row[11].(int64)),
+		CreatedAt: row[12].(time.Time),
+		UpdatedAt: row[13].(time.Time),
+		SeenAt:    row[14].(time.Time),
+	}
+	if title, ok := row[3].(string); ok {
+		ch.Title = &models.CharacterTitle{Title: title}
+	}
+	if gc, ok := row[10].(string); ok {
+		v := models.GrandCompany(gc)
+		if !v.Valid() {
+			return nil, nil, errors.Errorf("gc: invalid value: %s", gc)
+		}
+		ch.GC = &v
+	}
+	for _, v := range []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"race", string(ch.Race), ch.Race.Valid()},
+		{"clan", string(ch.Clan), ch.Clan.Valid()},
+		{"guardian", string(ch.Guardian), ch.Guardian.Valid()},
+		{"city_state", string(ch.CityState), ch.CityState.Valid()},
+		{"world", string(ch.World), ch.World.Valid()},
+	} {
+		if v.value != "" && !v.valid {
+			return nil, nil, errors.Errorf("%s: invalid value: %s", v.name, v.value)
+		}
+	}
+
+	var lvls []*models.Level
+	for i, job := range models.Jobs {
+		if lvl, ok := row[15+i].(int64); ok {
+			lvls = append(lvls, &models.Level{CharacterID: ch.ID, Job: job, Level: int(lvl)})
+		}
+	}
+	return ch, lvls, nil
+}
+
+// SaveCharacters saves characters and their levels as returned by ParseCharacterRow, like a fetch
+// would: their titles are looked up or created, then everything is upserted in bulk. Levels a
+// character already has but that aren't given are left alone.
+func SaveCharacters(ctx context.Context, ds models.DataStore, chs []*models.Character, lvls []*models.Level) error {
+	titles := make(map[string]*models.CharacterTitle)
+	for _, ch := range chs {
+		if ch.Title == nil {
+			continue
+		}
+		title, ok := titles[ch.Title.Title]
+		if !ok {
+			var err error
+			if title, err = ds.CharacterTitles().GetOrCreate(ctx, ch.Title.Title); err != nil {
+				return err
+			}
+			titles[title.Title] = title
+		}
+		ch.Title = title
+	}
+	if err := ds.Characters().SaveAll(ctx, chs); err != nil {
+		return err
+	}
+	if len(lvls) == 0 {
+		return nil
+	}
+	return ds.Levels().SetAll(ctx, lvls)
+}
+
+// Characters writes every character matching q to w, with their titles and levels, and returns how
+// many there were. They're fetched q.Limit at a time (DefaultChunkSize if unset), using q.After as
+// a cursor, so the whole set is never in memory at once.
+func Characters(ctx context.Context, ds models.DataStore, q models.CharacterQuery, w Writer) (int, error) {
+	if q.Limit <= 0 {
+		q.Limit = DefaultChunkSize
+	}
+	n := 0
+	for {
+		chars, err := ds.Characters().Search(ctx, q)
+		if err != nil {
+			return n, err
+		}
+		if len(chars) == 0 {
+			return n, nil
+		}
+
+		ids := make([]int64, len(chars))
+		var titleIDs []int
+		for i, ch := range chars {
+			ids[i] = ch.ID
+			if ch.TitleID.Valid {
+				titleIDs = append(titleIDs, int(ch.TitleID.Int64))
+			}
+		}
+		lvls, err := ds.Levels().ListMany(ctx, ids)
+		if err != nil {
+			return n, err
+		}
+		byChar := make(map[int64][]*models.Level, len(chars))
+		for _, lvl := range lvls {
+			byChar[lvl.CharacterID] = append(byChar[lvl.CharacterID], lvl)
+		}
+		titles, err := ds.CharacterTitles().GetMany(ctx, titleIDs)
+		if err != nil {
+			return n, err
+		}
+		byID := make(map[int64]*models.CharacterTitle, len(titles))
+		for _, title := range titles {
+			byID[int64(title.ID)] = title
+		}
+
+		for _, ch := range chars {
+			if ch.TitleID.Valid {
+				ch.Title = byID[ch.TitleID.Int64]
+			}
+			if err := w.Write(CharacterRow(ch, byChar[ch.ID])); err != nil {
+				return n, err
+			}
+			n++
+		}
+		if len(chars) < q.Limit {
+			return n, nil
+		}
+		q.After = q.CursorFor(chars[len(chars)-1])
+	}
+}
+
+// Tombstones writes every tombstone to w, and returns how many there were. They're fetched
+// chunkSize at a time (DefaultChunkSize if 0).
+func Tombstones(ctx context.Context, ds models.DataStore, chunkSize int, w Writer) (int, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	n := 0
+	var after int64
+	for {
+		tss, err := ds.CharacterTombstones().List(ctx, after, chunkSize)
+		if err != nil {
+			return n, err
+		}
+		for _, ts := range tss {
+			if err := w.Write([]interface{}{ts.ID, ts.CreatedAt}); err != nil {
+				return n, err
+			}
+			n++
+		}
+		if len(tss) < chunkSize {
+			return n, nil
+		}
+		after = tss[len(tss)-1].ID
+	}
+}
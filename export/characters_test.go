@@ -0,0 +1,157 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liclac/gubal/models"
+)
+
+func TestCharacterColumns(t *testing.T) {
+	ch := &models.Character{ID: 1}
+	assert.Len(t, CharacterRow(ch, nil), len(CharacterColumns))
+	assert.NoError(t, checkRow(CharacterColumns, CharacterRow(ch, nil)))
+	assert.Equal(t, "level_pld", LevelColumn(models.PLD))
+}
+
+func TestCharacters(t *testing.T) {
+	ctx := context.Background()
+	ds := models.NewMemoryDataStore()
+
+	title, err := ds.CharacterTitles().GetOrCreate(ctx, "Khloe's Friend")
+	require.NoError(t, err)
+	gc := models.Maelstrom
+	for _, ch := range []*models.Character{
+		{ID: 1, FirstName: "Emi", LastName: "Hawke", Title: title, GC: &gc, GCRank: 9, World: models.Ultros},
+		{ID: 2, FirstName: "Zed", LastName: "Adams", World: models.Ultros},
+		{ID: 3, FirstName: "Anna", LastName: "Adams", World: models.Odin},
+	} {
+		require.NoError(t, ds.Characters().Save(ctx, ch))
+	}
+	require.NoError(t, ds.Levels().Set(ctx, &models.Level{CharacterID: 1, Job: models.SCH, Level: 70}))
+	require.NoError(t, ds.Levels().Set(ctx, &models.Level{CharacterID: 1, Job: models.PLD, Level: 62}))
+	require.NoError(t, ds.CharacterTombstones().Create(ctx, 10))
+	require.NoError(t, ds.CharacterTombstones().Create(ctx, 11))
+	require.NoError(t, ds.CharacterTombstones().Create(ctx, 12))
+
+	t.Run("Characters", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := NewWriter(CSV, &buf, CharacterColumns)
+		require.NoError(t, err)
+		n, err := Characters(ctx, ds, models.CharacterQuery{World: models.Ultros, Limit: 1}, w)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		assert.Equal(t, 2, n)
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		rows := make([]map[string]string, len(records)-1)
+		for i, rec := range records[1:] {
+			rows[i] = make(map[string]string)
+			for j, col := range CharacterColumns {
+				rows[i][col.Name] = rec[j]
+			}
+		}
+		assert.Equal(t, "1", rows[0]["id"])
+		assert.Equal(t, "Khloe's Friend", rows[0]["title"])
+		assert.Equal(t, "Maelstrom", rows[0]["gc"])
+		assert.Equal(t, "9", rows[0]["gc_rank"])
+		assert.Equal(t, "62", rows[0]["level_pld"])
+		assert.Equal(t, "70", rows[0]["level_sch"])
+		assert.Equal(t, "", rows[0]["level_war"])
+		_, err = time.Parse(TimeFormat, rows[0]["seen_at"])
+		assert.NoError(t, err)
+		assert.Equal(t, "2", rows[1]["id"])
+		assert.Equal(t, "", rows[1]["title"])
+		assert.Equal(t, "", rows[1]["gc"])
+	})
+
+	t.Run("Tombstones", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := NewWriter(JSONL, &buf, TombstoneColumns)
+		require.NoError(t, err)
+		n, err := Tombstones(ctx, ds, 2, w)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		assert.Equal(t, 3, n)
+		assert.Equal(t, 3, bytes.Count(buf.Bytes(), []byte("\n")))
+	})
+}
+
+func TestParseCharacterRow(t *testing.T) {
+	gc := models.Maelstrom
+	ch := &models.Character{
+		ID: 1, FirstName: "Emi", LastName: "Hawke", Gender: "♀",
+		Race: models.AuRa, Clan: models.AuRaRaen, Guardian: models.Menphina, CityState: models.Limsa,
+		World: models.Ultros, Title: &models.CharacterTitle{ID: 5, Title: "Khloe's Friend"},
+		GC: &gc, GCRank: 9,
+		CreatedAt: time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2018, 3, 2, 12, 0, 0, 0, time.UTC),
+		SeenAt:    time.Date(2018, 3, 3, 12, 0, 0, 0, time.UTC),
+	}
+	lvls := []*models.Level{
+		{CharacterID: 1, Job: models.PLD, Level: 62},
+		{CharacterID: 1, Job: models.SCH, Level: 70},
+	}
+	ch2, lvls2, err := ParseCharacterRow(CharacterRow(ch, lvls))
+	require.NoError(t, err)
+	assert.Equal(t, lvls, lvls2)
+	assert.Equal(t, &models.CharacterTitle{Title: "Khloe's Friend"}, ch2.Title)
+	ch2.Title = ch.Title
+	assert.Equal(t, ch, ch2)
+
+	t.Run("Blank", func(t *testing.T) {
+		ch, lvls, err := ParseCharacterRow(CharacterRow(&models.Character{ID: 2}, nil))
+		require.NoError(t, err)
+		assert.Equal(t, &models.Character{ID: 2}, ch)
+		assert.Empty(t, lvls)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		row := CharacterRow(&models.Character{ID: 2, World: "Nowhere"}, nil)
+		_, _, err := ParseCharacterRow(row)
+		assert.EqualError(t, err, "world: invalid value: Nowhere")
+		row = CharacterRow(&models.Character{ID: 2}, nil)
+		row[10] = "Nobody"
+		_, _, err = ParseCharacterRow(row)
+		assert.EqualError(t, err, "gc: invalid value: Nobody")
+	})
+}
+
+func TestSaveCharacters(t *testing.T) {
+	ctx := context.Background()
+	ds := models.NewMemoryDataStore()
+	require.NoError(t, ds.Levels().Set(ctx, &models.Level{CharacterID: 1, Job: models.WAR, Level: 30}))
+
+	chs := []*models.Character{
+		{ID: 1, FirstName: "Emi", Title: &models.CharacterTitle{Title: "Khloe's Friend"}},
+		{ID: 2, FirstName: "Zed", Title: &models.CharacterTitle{Title: "Khloe's Friend"}},
+		{ID: 3, FirstName: "Anna"},
+	}
+	require.NoError(t, SaveCharacters(ctx, ds, chs, []*models.Level{{CharacterID: 1, Job: models.PLD, Level: 62}}))
+
+	title, err := ds.CharacterTitles().GetOrCreate(ctx, "Khloe's Friend")
+	require.NoError(t, err)
+	saved, err := ds.Characters().Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Emi", saved.FirstName)
+	assert.Equal(t, int64(title.ID), saved.TitleID.Int64)
+	saved, err = ds.Characters().Get(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(title.ID), saved.TitleID.Int64)
+	saved, err = ds.Characters().Get(ctx, 3)
+	require.NoError(t, err)
+	assert.False(t, saved.TitleID.Valid)
+
+	lvls, err := ds.Levels().List(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, lvls, 2)
+	assert.Equal(t, models.PLD, lvls[0].Job)
+	assert.Equal(t, models.WAR, lvls[1].Job)
+}
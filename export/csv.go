@@ -0,0 +1,106 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TimeFormat is how times are formatted in text formats. They're always in UTC.
+const TimeFormat = time.RFC3339Nano
+
+// csvWriter writes a CSV file with a header row. Nulls are empty.
+type csvWriter struct {
+	w    *csv.Writer
+	cols []Column
+	buf  []string
+}
+
+func newCSVWriter(w io.Writer, cols []Column) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w), cols: cols, buf: make([]string, len(cols))}
+	for i, col := range cols {
+		cw.buf[i] = col.Name
+	}
+	return cw, cw.w.Write(cw.buf)
+}
+
+func (w *csvWriter) Write(row []interface{}) error {
+	if err := checkRow(w.cols, row); err != nil {
+		return err
+	}
+	for i, v := range row {
+		switch v := v.(type) {
+		case nil:
+			w.buf[i] = ""
+		case string:
+			w.buf[i] = v
+		case int64:
+			w.buf[i] = strconv.FormatInt(v, 10)
+		case time.Time:
+			w.buf[i] = v.UTC().Format(TimeFormat)
+		}
+	}
+	return w.w.Write(w.buf)
+}
+
+func (w *csvWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// csvReader reads a CSV file with a header row, as written by csvWriter.
+type csvReader struct {
+	r    *csv.Reader
+	cols []Column
+	idx  []int // Index of each column in a record, or -1 if it's missing.
+	line int
+}
+
+func newCSVReader(r io.Reader, cols []Column) (*csvReader, error) {
+	cr := &csvReader{r: csv.NewReader(r), cols: cols, idx: make([]int, len(cols)), line: 1}
+	cr.r.ReuseRecord = true
+	header, err := cr.r.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, col := range cols {
+		cr.idx[i] = -1
+		for j, name := range header {
+			if name == col.Name {
+				cr.idx[i] = j
+				break
+			}
+		}
+		if cr.idx[i] == -1 && !col.Optional {
+			return nil, errors.Errorf("missing column: %s", col.Name)
+		}
+	}
+	return cr, nil
+}
+
+func (r *csvReader) Read() ([]interface{}, error) {
+	rec, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	r.line++
+	row := make([]interface{}, len(r.cols))
+	for i, col := range r.cols {
+		if r.idx[i] == -1 {
+			continue
+		}
+		if row[i], err = parseValue(col, rec[r.idx[i]]); err != nil {
+			return nil, errors.Wrapf(err, "line %d", r.line)
+		}
+	}
+	if err := checkRow(r.cols, row); err != nil {
+		return nil, errors.Wrapf(err, "line %d", r.line)
+	}
+	return row, nil
+}
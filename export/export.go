@@ -0,0 +1,154 @@
+// Package export writes tables out in formats that are easy to load into other tools, eg. pandas
+// or DuckDB, without access to the database, and reads them back in.
+package export
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Format is a constant type for an export format.
+type Format string
+
+// Format constants.
+const (
+	CSV     Format = "csv"
+	JSONL   Format = "jsonl"
+	Parquet Format = "parquet"
+)
+
+// Formats lists every known Format.
+var Formats = []Format{
+	CSV,
+	JSONL,
+	Parquet,
+}
+
+// Valid returns whether this is a known Format.
+func (v Format) Valid() bool {
+	for _, known := range Formats {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ColumnType is the type of a Column.
+type ColumnType int
+
+// ColumnType constants, and the Go types of their values.
+const (
+	String ColumnType = iota // string
+	Int                      // int64
+	Time                     // time.Time
+)
+
+// A Column is a column in an exported table. Optional columns may be nil.
+type Column struct {
+	Name     string
+	Type     ColumnType
+	Optional bool
+}
+
+// A Writer writes rows to a table. Every row has one value per column, in order.
+type Writer interface {
+	// Write writes a row.
+	Write(row []interface{}) error
+
+	// Close flushes anything buffered, and finishes the table. It doesn't close the underlying
+	// io.Writer.
+	Close() error
+}
+
+// NewWriter returns a Writer that writes a table with the given columns to w.
+func NewWriter(format Format, w io.Writer, cols []Column) (Writer, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w, cols)
+	case JSONL:
+		return newJSONLWriter(w, cols), nil
+	case Parquet:
+		return newParquetWriter(w, cols)
+	default:
+		return nil, errors.Errorf("unknown format: %s", format)
+	}
+}
+
+// A Reader reads rows from a table written by a Writer. Columns are matched up by name, so they
+// may be in any order; unknown ones are ignored, and missing optional ones are nil.
+type Reader interface {
+	// Read returns the next row, with one value per column, or io.EOF after the last one.
+	Read() ([]interface{}, error)
+}
+
+// NewReader returns a Reader that reads a table with the given columns from r. Only text formats
+// can be read.
+func NewReader(format Format, r io.Reader, cols []Column) (Reader, error) {
+	switch format {
+	case CSV:
+		return newCSVReader(r, cols)
+	case JSONL:
+		return newJSONLReader(r, cols), nil
+	case Parquet:
+		return nil, errors.Errorf("can't read format: %s", format)
+	default:
+		return nil, errors.Errorf("unknown format: %s", format)
+	}
+}
+
+// parseValue parses a value of a column from its text form; empty strings are null, unless it's a
+// non-optional string column.
+func parseValue(col Column, s string) (interface{}, error) {
+	if s == "" && (col.Optional || col.Type != String) {
+		return nil, nil
+	}
+	switch col.Type {
+	case Int:
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("%s: invalid value: %q", col.Name, s)
+		}
+		return v, nil
+	case Time:
+		v, err := time.Parse(TimeFormat, s)
+		if err != nil {
+			return nil, errors.Errorf("%s: invalid value: %q", col.Name, s)
+		}
+		return v, nil
+	default:
+		return s, nil
+	}
+}
+
+// checkRow returns an error if a row doesn't match the columns.
+func checkRow(cols []Column, row []interface{}) error {
+	if len(row) != len(cols) {
+		return errors.Errorf("row has %d values, expected %d", len(row), len(cols))
+	}
+	for i, col := range cols {
+		v := row[i]
+		if v == nil {
+			if !col.Optional {
+				return errors.Errorf("%s: can't be null", col.Name)
+			}
+			continue
+		}
+		ok := false
+		switch col.Type {
+		case String:
+			_, ok = v.(string)
+		case Int:
+			_, ok = v.(int64)
+		case Time:
+			_, ok = v.(time.Time)
+		}
+		if !ok {
+			return errors.Errorf("%s: invalid value: %#v", col.Name, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testColumns = []Column{
+	{Name: "id", Type: Int},
+	{Name: "name", Type: String},
+	{Name: "title", Type: String, Optional: true},
+	{Name: "seen_at", Type: Time},
+}
+
+var testRows = [][]interface{}{
+	{int64(1), "Emi Hawke", "Khloe's Friend", time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)},
+	{int64(2), "Zed \"Z\" Adams", nil, time.Date(2018, 3, 2, 13, 0, 0, 0, time.FixedZone("JST", 9*60*60))},
+}
+
+// writeTable writes rows to a table in a format, and returns it.
+func writeTable(t *testing.T, format Format, cols []Column, rows [][]interface{}) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf, cols)
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, w.Write(row))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestCSV(t *testing.T) {
+	assert.Equal(t, `id,name,title,seen_at
+1,Emi Hawke,Khloe's Friend,2018-03-01T12:00:00Z
+2,"Zed ""Z"" Adams",,2018-03-02T04:00:00Z
+`, string(writeTable(t, CSV, testColumns, testRows)))
+}
+
+func TestJSONL(t *testing.T) {
+	assert.Equal(t, `{"id":1,"name":"Emi Hawke","title":"Khloe's Friend","seen_at":"2018-03-01T12:00:00Z"}
+{"id":2,"name":"Zed \"Z\" Adams","title":null,"seen_at":"2018-03-02T04:00:00Z"}
+`, string(writeTable(t, JSONL, testColumns, testRows)))
+}
+
+func TestInvalidRows(t *testing.T) {
+	for _, format := range Formats {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(format, &buf, testColumns)
+			require.NoError(t, err)
+			assert.EqualError(t, w.Write([]interface{}{int64(1)}), "row has 1 values, expected 4")
+			assert.EqualError(t, w.Write([]interface{}{int64(1), nil, nil, time.Now()}), "name: can't be null")
+			assert.EqualError(t, w.Write([]interface{}{1, "Emi", nil, time.Now()}), "id: invalid value: 1")
+		})
+	}
+
+	_, err := NewWriter("xml", &bytes.Buffer{}, testColumns)
+	assert.EqualError(t, err, "unknown format: xml")
+}
+
+// readTable reads every row from a table in a format.
+func readTable(t *testing.T, format Format, cols []Column, data string) ([][]interface{}, error) {
+	r, err := NewReader(format, strings.NewReader(data), cols)
+	if err != nil {
+		return nil, err
+	}
+	var rows [][]interface{}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestRead(t *testing.T) {
+	for _, format := range []Format{CSV, JSONL} {
+		t.Run(string(format), func(t *testing.T) {
+			rows, err := readTable(t, format, testColumns, string(writeTable(t, format, testColumns, testRows)))
+			require.NoError(t, err)
+			require.Len(t, rows, 2)
+			assert.Equal(t, testRows[0], rows[0])
+			assert.Equal(t, []interface{}{int64(2), "Zed \"Z\" Adams", nil}, rows[1][:3])
+			assert.True(t, testRows[1][3].(time.Time).Equal(rows[1][3].(time.Time)))
+		})
+	}
+
+	_, err := NewReader(Parquet, strings.NewReader(""), testColumns)
+	assert.EqualError(t, err, "can't read format: parquet")
+	_, err = NewReader("xml", strings.NewReader(""), testColumns)
+	assert.EqualError(t, err, "unknown format: xml")
+}
+
+func TestReadCSV(t *testing.T) {
+	t.Run("Columns", func(t *testing.T) {
+		rows, err := readTable(t, CSV, testColumns, "extra,seen_at,name,id\nx,2018-03-01T12:00:00Z,Emi Hawke,1\n")
+		require.NoError(t, err)
+		assert.Equal(t, [][]interface{}{
+			{int64(1), "Emi Hawke", nil, time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)},
+		}, rows)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := readTable(t, CSV, testColumns, "")
+		assert.EqualError(t, err, "missing header")
+	})
+	t.Run("Missing", func(t *testing.T) {
+		_, err := readTable(t, CSV, testColumns, "id,title,seen_at\n")
+		assert.EqualError(t, err, "missing column: name")
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		rows, err := readTable(t, CSV, testColumns, "id,name,seen_at\n1,Emi,2018-03-01T12:00:00Z\nx,Zed,2018-03-01T12:00:00Z\n")
+		assert.Len(t, rows, 1)
+		assert.EqualError(t, err, `line 3: id: invalid value: "x"`)
+	})
+	t.Run("Null", func(t *testing.T) {
+		_, err := readTable(t, CSV, testColumns, "id,name,seen_at\n1,Emi,\n")
+		assert.EqualError(t, err, "line 2: seen_at: can't be null")
+	})
+}
+
+func TestReadJSONL(t *testing.T) {
+	t.Run("Columns", func(t *testing.T) {
+		rows, err := readTable(t, JSONL, testColumns, `{"extra":true,"seen_at":"2018-03-01T12:00:00Z","name":"Emi Hawke","id":1}`+"\n\n")
+		require.NoError(t, err)
+		assert.Equal(t, [][]interface{}{
+			{int64(1), "Emi Hawke", nil, time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)},
+		}, rows)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := readTable(t, JSONL, testColumns, `{"id":"1","name":"Emi","seen_at":"2018-03-01T12:00:00Z"}`)
+		assert.EqualError(t, err, `line 1: id: invalid value: "1"`)
+	})
+	t.Run("Null", func(t *testing.T) {
+		_, err := readTable(t, JSONL, testColumns, `{"id":1,"name":null,"seen_at":"2018-03-01T12:00:00Z"}`)
+		assert.EqualError(t, err, "line 1: name: can't be null")
+	})
+	t.Run("Syntax", func(t *testing.T) {
+		_, err := readTable(t, JSONL, testColumns, "{")
+		assert.EqualError(t, err, "line 1: unexpected end of JSON input")
+	})
+}
@@ -0,0 +1,119 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// jsonlWriter writes one JSON object per row, with keys in column order.
+type jsonlWriter struct {
+	w    *bufio.Writer
+	cols []Column
+	keys [][]byte
+}
+
+func newJSONLWriter(w io.Writer, cols []Column) *jsonlWriter {
+	jw := &jsonlWriter{w: bufio.NewWriter(w), cols: cols, keys: make([][]byte, len(cols))}
+	for i, col := range cols {
+		jw.keys[i], _ = json.Marshal(col.Name)
+	}
+	return jw
+}
+
+func (w *jsonlWriter) Write(row []interface{}) error {
+	if err := checkRow(w.cols, row); err != nil {
+		return err
+	}
+	w.w.WriteByte('{')
+	for i, v := range row {
+		if i > 0 {
+			w.w.WriteByte(',')
+		}
+		w.w.Write(w.keys[i])
+		w.w.WriteByte(':')
+		if t, ok := v.(time.Time); ok {
+			v = t.UTC().Format(TimeFormat)
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		w.w.Write(data)
+	}
+	w.w.WriteByte('}')
+	return w.w.WriteByte('\n')
+}
+
+func (w *jsonlWriter) Close() error {
+	return w.w.Flush()
+}
+
+// jsonlReader reads one JSON object per line, as written by jsonlWriter. Blank lines are skipped.
+type jsonlReader struct {
+	s    *bufio.Scanner
+	cols []Column
+	line int
+}
+
+func newJSONLReader(r io.Reader, cols []Column) *jsonlReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1024*1024)
+	return &jsonlReader{s: s, cols: cols}
+}
+
+func (r *jsonlReader) Read() ([]interface{}, error) {
+	for r.s.Scan() {
+		r.line++
+		if len(bytes.TrimSpace(r.s.Bytes())) == 0 {
+			continue
+		}
+		row, err := r.parse(r.s.Bytes())
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", r.line)
+		}
+		return row, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// parse parses a line into a row.
+func (r *jsonlReader) parse(line []byte) ([]interface{}, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(line, &obj); err != nil {
+		return nil, err
+	}
+	row := make([]interface{}, len(r.cols))
+	for i, col := range r.cols {
+		raw, ok := obj[col.Name]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+		var err error
+		switch col.Type {
+		case Int:
+			var v int64
+			err = json.Unmarshal(raw, &v)
+			row[i] = v
+		case Time:
+			var v time.Time
+			err = json.Unmarshal(raw, &v)
+			row[i] = v
+		default:
+			var v string
+			err = json.Unmarshal(raw, &v)
+			row[i] = v
+		}
+		if err != nil {
+			return nil, errors.Errorf("%s: invalid value: %s", col.Name, raw)
+		}
+	}
+	return row, checkRow(r.cols, row)
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// ParquetRowGroupSize is the number of rows buffered up and written as each Parquet row group.
+var ParquetRowGroupSize = 10000
+
+// parquetWriter writes a flat, Snappy-compressed Parquet file, with statistics for every column
+// chunk, using parquet-go.
+type parquetWriter struct {
+	pw   *writer.CSVWriter
+	cols []Column
+	rows int
+}
+
+func newParquetWriter(w io.Writer, cols []Column) (*parquetWriter, error) {
+	md := make([]string, len(cols))
+	for i, col := range cols {
+		md[i] = parquetSchema(col)
+	}
+	pw, err := writer.NewCSVWriterFromWriter(md, w, 1)
+	if err != nil {
+		return nil, err
+	}
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+	return &parquetWriter{pw: pw, cols: cols}, nil
+}
+
+func (w *parquetWriter) Write(row []interface{}) error {
+	if err := checkRow(w.cols, row); err != nil {
+		return err
+	}
+	rec := make([]interface{}, len(row))
+	for i, v := range row {
+		if t, ok := v.(time.Time); ok {
+			v = t.UnixMicro()
+		}
+		rec[i] = v
+	}
+	if err := w.pw.Write(rec); err != nil {
+		return err
+	}
+	if w.rows++; w.rows%ParquetRowGroupSize == 0 {
+		return w.pw.Flush(true)
+	}
+	return nil
+}
+
+func (w *parquetWriter) Close() error {
+	return w.pw.WriteStop()
+}
+
+// parquetSchema returns parquet-go's schema metadata for a column.
+func parquetSchema(col Column) string {
+	rep := "REQUIRED"
+	if col.Optional {
+		rep = "OPTIONAL"
+	}
+	switch col.Type {
+	case String:
+		return fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=%s", col.Name, rep)
+	case Time:
+		return fmt.Sprintf("name=%s, type=INT64, convertedtype=TIMESTAMP_MICROS, repetitiontype=%s", col.Name, rep)
+	default:
+		return fmt.Sprintf("name=%s, type=INT64, repetitiontype=%s", col.Name, rep)
+	}
+}
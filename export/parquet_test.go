@@ -0,0 +1,114 @@
+package export
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestParquet(t *testing.T) {
+	defer func(n int) { ParquetRowGroupSize = n }(ParquetRowGroupSize)
+	ParquetRowGroupSize = 1
+
+	data := writeTable(t, Parquet, testColumns, testRows)
+	require.True(t, len(data) > 12)
+	assert.Equal(t, "PAR1", string(data[:4]))
+	assert.Equal(t, "PAR1", string(data[len(data)-4:]))
+}
+
+// TestParquetRoundTrip reads files back with another Parquet implementation, and checks that every
+// value, including nulls and timestamps, comes back as it was written.
+func TestParquetRoundTrip(t *testing.T) {
+	defer func(n int) { ParquetRowGroupSize = n }(ParquetRowGroupSize)
+
+	cols := []Column{
+		{Name: "id", Type: Int},
+		{Name: "name", Type: String},
+		{Name: "title", Type: String, Optional: true},
+		{Name: "seen_at", Type: Time},
+		{Name: "gc_rank", Type: Int, Optional: true},
+		{Name: "deleted_at", Type: Time, Optional: true},
+	}
+	at := time.Date(2018, 3, 1, 12, 0, 0, 123456000, time.UTC)
+	rows := [][]interface{}{
+		{int64(1), "Emi Hawke", "Khloe's Friend", at, int64(9), nil},
+		{int64(2), "Zed \"Z\" Adams", nil, at.In(time.FixedZone("JST", 9*60*60)), nil, at.Add(-time.Hour)},
+		{int64(-3), "", "", time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC), int64(0), nil},
+		{int64(4), "Anna Zed", nil, at.Add(time.Microsecond), nil, nil},
+		{int64(5), "エミ", "ホーク", at.Add(24 * time.Hour), int64(-1), at},
+	}
+
+	for _, groupSize := range []int{1, 2, 100} {
+		t.Run(fmt.Sprintf("Groups of %d", groupSize), func(t *testing.T) {
+			ParquetRowGroupSize = groupSize
+			f, err := buffer.NewBufferFile(writeTable(t, Parquet, cols, rows))
+			require.NoError(t, err)
+			pr, err := reader.NewParquetColumnReader(f, 1)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(rows)), pr.GetNumRows())
+			require.Len(t, pr.Footer.RowGroups, (len(rows)+groupSize-1)/groupSize)
+
+			// The schema declares every column's type, and that timestamps are in microseconds.
+			require.Len(t, pr.Footer.Schema, len(cols)+1)
+			for i, col := range cols {
+				elem := pr.Footer.Schema[i+1]
+				assert.Equal(t, col.Name, pr.SchemaHandler.Infos[i+1].ExName)
+				rep := parquet.FieldRepetitionType_REQUIRED
+				if col.Optional {
+					rep = parquet.FieldRepetitionType_OPTIONAL
+				}
+				assert.Equal(t, rep, elem.GetRepetitionType(), col.Name)
+				switch col.Type {
+				case String:
+					assert.Equal(t, parquet.Type_BYTE_ARRAY, elem.GetType(), col.Name)
+					assert.Equal(t, parquet.ConvertedType_UTF8, elem.GetConvertedType(), col.Name)
+				case Int:
+					assert.Equal(t, parquet.Type_INT64, elem.GetType(), col.Name)
+					assert.False(t, elem.IsSetConvertedType(), col.Name)
+				case Time:
+					assert.Equal(t, parquet.Type_INT64, elem.GetType(), col.Name)
+					assert.Equal(t, parquet.ConvertedType_TIMESTAMP_MICROS, elem.GetConvertedType(), col.Name)
+				}
+			}
+
+			// Every column chunk is compressed, and has statistics.
+			for _, rg := range pr.Footer.RowGroups {
+				for i, cc := range rg.Columns {
+					assert.Equal(t, parquet.CompressionCodec_SNAPPY, cc.MetaData.Codec, cols[i].Name)
+					assert.NotNil(t, cc.MetaData.Statistics, cols[i].Name)
+				}
+			}
+
+			// Every value reads back as it was written; nulls are nil, with a definition level of 0.
+			for i, col := range cols {
+				values, _, dls, err := pr.ReadColumnByIndex(int64(i), int64(len(rows)))
+				require.NoError(t, err)
+				require.Len(t, values, len(rows), col.Name)
+				require.Len(t, dls, len(rows), col.Name)
+				for j, row := range rows {
+					expect, dl := row[i], int32(0)
+					if ts, ok := expect.(time.Time); ok {
+						expect = ts.UnixMicro()
+					}
+					if col.Optional && expect != nil {
+						dl = 1
+					}
+					assert.Equal(t, expect, values[j], "%s[%d]", col.Name, j)
+					assert.Equal(t, dl, dls[j], "%s[%d]", col.Name, j)
+				}
+			}
+		})
+	}
+}
+
+func TestParquetEmpty(t *testing.T) {
+	data := writeTable(t, Parquet, testColumns, nil)
+	assert.Equal(t, "PAR1", string(data[:4]))
+	assert.Equal(t, "PAR1", string(data[len(data)-4:]))
+}
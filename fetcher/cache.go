@@ -0,0 +1,127 @@
+package fetcher
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// The cache is content-addressed: response bodies are stored under their SHA-256 hash in
+// "objects/", and every cache key (eg. "char_12345") has an index file in "index/", which records
+// the hash of every distinct version of the page we've processed, oldest first.
+const (
+	cacheObjectsDir = "objects"
+	cacheIndexDir   = "index"
+)
+
+// A cacheEntry is a line in a cache key's index.
+type cacheEntry struct {
+	Time time.Time
+	Hash string
+}
+
+// cacheHash returns the hash under which a body is stored.
+func cacheHash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+func cacheObjectPath(hash string) string {
+	return path.Join(cacheObjectsDir, hash[:2], hash)
+}
+
+func cacheIndexPath(key string) string {
+	return path.Join(cacheIndexDir, key)
+}
+
+// cacheReadObject reads a stored body by hash.
+func cacheReadObject(fs afero.Fs, hash string) ([]byte, error) {
+	return afero.ReadFile(fs, cacheObjectPath(hash))
+}
+
+// cacheWriteObject stores a body, if it isn't already stored. Returns whether it was.
+func cacheWriteObject(fs afero.Fs, body []byte) (hash string, existed bool, err error) {
+	hash = cacheHash(body)
+	filename := cacheObjectPath(hash)
+	if existed, err = afero.Exists(fs, filename); err != nil || existed {
+		return hash, existed, err
+	}
+	if err := fs.MkdirAll(path.Dir(filename), 0755); err != nil {
+		return hash, false, err
+	}
+	return hash, false, afero.WriteFile(fs, filename, body, 0644)
+}
+
+// cacheHistory returns a key's index, oldest first. A nil fs has no history.
+func cacheHistory(fs afero.Fs, key string) ([]cacheEntry, error) {
+	if fs == nil {
+		return nil, nil
+	}
+	f, err := fs.Open(cacheIndexPath(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []cacheEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if l := len(fields); l != 2 {
+			return nil, errors.Errorf("malformed cache index line for %s: wrong number of fields: %d", key, l)
+		}
+		t, err := time.Parse(time.RFC3339, fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "malformed cache index line for %s", key)
+		}
+		entries = append(entries, cacheEntry{Time: t, Hash: fields[1]})
+	}
+	return entries, scanner.Err()
+}
+
+// cacheLatest returns the most recently recorded hash for a key, or "" if there is none.
+func cacheLatest(fs afero.Fs, key string) (string, error) {
+	entries, err := cacheHistory(fs, key)
+	if err != nil || len(entries) == 0 {
+		return "", err
+	}
+	return entries[len(entries)-1].Hash, nil
+}
+
+// cacheRecord appends a hash to a key's index, unless it's already the latest one.
+// This should only be done once the content has been fully processed, or retries of a failed job
+// will mistake the page for unchanged. A nil fs records nothing.
+func cacheRecord(fs afero.Fs, key, hash string) error {
+	if fs == nil {
+		return nil
+	}
+	latest, err := cacheLatest(fs, key)
+	if err != nil || latest == hash {
+		return err
+	}
+
+	filename := cacheIndexPath(key)
+	if err := fs.MkdirAll(path.Dir(filename), 0755); err != nil {
+		return err
+	}
+	f, err := fs.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(f, "%s %s\n", time.Now().UTC().Format(time.RFC3339), hash); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,64 @@
+package fetcher
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	key := "char_1234"
+	body1 := []byte("version 1")
+	body2 := []byte("version 2")
+
+	// A key that's never been recorded has no history.
+	t.Run("Empty", func(t *testing.T) {
+		latest, err := cacheLatest(fs, key)
+		require.NoError(t, err)
+		assert.Equal(t, "", latest)
+	})
+
+	// Objects are only written once.
+	t.Run("Write", func(t *testing.T) {
+		hash, existed, err := cacheWriteObject(fs, body1)
+		require.NoError(t, err)
+		assert.False(t, existed)
+		assert.Equal(t, cacheHash(body1), hash)
+
+		_, existed, err = cacheWriteObject(fs, body1)
+		require.NoError(t, err)
+		assert.True(t, existed)
+
+		data, err := cacheReadObject(fs, hash)
+		require.NoError(t, err)
+		assert.Equal(t, body1, data)
+	})
+
+	// Recording the same hash twice in a row only adds one entry.
+	t.Run("Record", func(t *testing.T) {
+		require.NoError(t, cacheRecord(fs, key, cacheHash(body1)))
+		require.NoError(t, cacheRecord(fs, key, cacheHash(body1)))
+		require.NoError(t, cacheRecord(fs, key, cacheHash(body2)))
+
+		history, err := cacheHistory(fs, key)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, cacheHash(body1), history[0].Hash)
+		assert.Equal(t, cacheHash(body2), history[1].Hash)
+
+		latest, err := cacheLatest(fs, key)
+		require.NoError(t, err)
+		assert.Equal(t, cacheHash(body2), latest)
+	})
+
+	// A nil cache has no history and records nothing.
+	t.Run("Nil", func(t *testing.T) {
+		require.NoError(t, cacheRecord(nil, key, cacheHash(body1)))
+		latest, err := cacheLatest(nil, key)
+		require.NoError(t, err)
+		assert.Equal(t, "", latest)
+	})
+}
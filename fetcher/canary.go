@@ -0,0 +1,210 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/liclac/gubal/lib"
+	"github.com/liclac/gubal/models"
+)
+
+// A Canary is a known character, whose page is fetched to detect changes in the Lodestone's layout
+// before they break every other fetch. Expect holds what the page should parse to; its ID is
+// ignored. Levels holds every unlocked job's level; jobs not listed are expected to be locked.
+// Region is the Lodestone to fetch it from and Page the version of the page to fetch; as with
+// jobs, the default is NA's mobile page.
+type Canary struct {
+	ID     int64              `json:"id"`
+	Region Region             `json:"region,omitempty"`
+	Page   Page               `json:"page,omitempty"`
+	Expect models.Character   `json:"expect"`
+	Levels map[models.Job]int `json:"levels"`
+}
+
+// A CanaryResult reports how a Canary's page differed from what was expected.
+type CanaryResult struct {
+	ID int64 `json:"id"`
+
+	// FetchError is why the page couldn't be fetched, if it couldn't; nothing else is checked then.
+	FetchError string `json:"fetch_error,omitempty"`
+
+	// Selectors that no longer match anything on the page; a missing profile block is reported
+	// as its title selector, followed by the title, eg. `.character-block__name "Nameday"`.
+	Selectors []string `json:"selectors,omitempty"`
+
+	// Errors from parsing the page.
+	Errors []string `json:"errors,omitempty"`
+
+	// Fields that parsed to something other than expected, eg. "world: expected Ultros, got Odin".
+	Mismatches []string `json:"mismatches,omitempty"`
+}
+
+// OK returns whether the page was fetched, and looked exactly like expected.
+func (r CanaryResult) OK() bool {
+	return r.FetchError == "" && len(r.Selectors) == 0 && len(r.Errors) == 0 && len(r.Mismatches) == 0
+}
+
+// LoadCanaries reads a JSON array of Canaries.
+func LoadCanaries(r io.Reader) ([]Canary, error) {
+	var canaries []Canary
+	if err := json.NewDecoder(r).Decode(&canaries); err != nil {
+		return nil, err
+	}
+	for i, c := range canaries {
+		if c.ID == 0 {
+			return nil, errors.Errorf("canary #%d has no id", i+1)
+		}
+		if !c.Region.OrDefault().Valid() {
+			return nil, errors.Errorf("canary #%d has an unknown region: %s", i+1, c.Region)
+		}
+		if c.Page != "" && !c.Page.Valid() {
+			return nil, errors.Errorf("canary #%d has an unknown page: %s", i+1, c.Page)
+		}
+	}
+	return canaries, nil
+}
+
+// RunCanary fetches a canary's page, bypassing the cache, and checks it against what's expected.
+// Nothing is written to the database. An error is only returned if the page couldn't be fetched;
+// problems with the page itself are reported in the result.
+func RunCanary(ctx context.Context, c Canary) (*CanaryResult, error) {
+	ctx, span := tracer.Start(ctx, "RunCanary")
+	res, err := runCanary(ctx, c)
+	lib.EndSpan(span, err)
+	return res, err
+}
+
+func runCanary(ctx context.Context, c Canary) (*CanaryResult, error) {
+	page := c.Page
+	if page == "" {
+		page = PageMobile
+	}
+	status, body, err := getPage(ctx, nil, c.Region, page, "/character/"+strconv.FormatInt(c.ID, 10)+"/")
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, errors.Errorf("incorrect HTTP status code when fetching canary %d: %d", c.ID, status)
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &CanaryResult{ID: c.ID, Selectors: checkCanarySelectors(c, doc, GetLayouts(ctx).Detect(doc))}
+	ch, levels, err := FetchCharacterJob{ID: c.ID, Region: c.Region}.parse(ctx, body)
+	if err != nil {
+		for _, err := range multierr.Errors(err) {
+			res.Errors = append(res.Errors, err.Error())
+		}
+		return res, nil
+	}
+	res.Mismatches = compareCanary(c, ch, levels)
+	return res, nil
+}
+
+// checkCanarySelectors returns the layout's selectors that don't match anything on the page. Parts
+// that can legitimately be absent, like the title, are only checked if the canary expects them. If
+// no layout matches the page at all, there's nothing to check; parsing it will report that.
+func checkCanarySelectors(c Canary, doc *goquery.Document, l *Layout) []string {
+	if l == nil {
+		return nil
+	}
+	names := l.Blocks[c.Region.Language()]
+	var missing []string
+	check := func(sel *goquery.Selection, name string) {
+		if sel.Length() == 0 {
+			missing = append(missing, name)
+		}
+	}
+
+	check(doc.Find(l.Selectors.Name), l.Selectors.Name)
+	if c.Expect.Title != nil {
+		check(doc.Find(l.Selectors.Title), l.Selectors.Title)
+	}
+	check(doc.Find(l.Selectors.World), l.Selectors.World)
+
+	blocks := doc.Find(l.Selectors.Block)
+	check(blocks, l.Selectors.Block)
+	check(blocks.Find(l.Selectors.BlockName), l.Selectors.BlockName)
+	check(blocks.Find(l.Selectors.BlockProfile), l.Selectors.BlockProfile)
+	titles := make(map[string]bool)
+	blocks.Find(l.Selectors.BlockName).Each(func(i int, sel *goquery.Selection) {
+		titles[trim(sel.Text())] = true
+	})
+	expectTitles := []string{names.RaceClanGender, names.Nameday, names.CityState}
+	if c.Expect.GC != nil {
+		expectTitles = append(expectTitles, names.GrandCompany)
+	}
+	for _, title := range expectTitles {
+		if !titles[title] {
+			missing = append(missing, fmt.Sprintf("%s %q", l.Selectors.BlockName, title))
+		}
+	}
+
+	if len(c.Levels) > 0 {
+		jobs := doc.Find(l.Selectors.Job)
+		check(jobs, l.Selectors.Job)
+		if l.Selectors.JobLevel != "" {
+			check(jobs.Find(l.Selectors.JobLevel), l.Selectors.JobLevel)
+		}
+		if attr := l.Selectors.JobNameAttr; attr != "" {
+			check(jobs.Find(l.Selectors.JobName).Filter("["+attr+"]"), l.Selectors.JobName+"["+attr+"]")
+		} else {
+			check(jobs.Find(l.Selectors.JobName), l.Selectors.JobName)
+		}
+	}
+	return missing
+}
+
+// compareCanary returns the fields of a parsed character that differ from the canary's.
+func compareCanary(c Canary, ch *models.Character, levels []*models.Level) []string {
+	var diffs []string
+	diff := func(field string, expect, got interface{}) {
+		if expect != got {
+			diffs = append(diffs, fmt.Sprintf("%s: expected %v, got %v", field, expect, got))
+		}
+	}
+	titleOf := func(ch *models.Character) string {
+		if ch.Title == nil {
+			return ""
+		}
+		return ch.Title.Title
+	}
+	gcOf := func(ch *models.Character) models.GrandCompany {
+		if ch.GC == nil {
+			return ""
+		}
+		return *ch.GC
+	}
+
+	diff("first_name", c.Expect.FirstName, ch.FirstName)
+	diff("last_name", c.Expect.LastName, ch.LastName)
+	diff("title", titleOf(&c.Expect), titleOf(ch))
+	diff("world", c.Expect.World, ch.World)
+	diff("race", c.Expect.Race, ch.Race)
+	diff("clan", c.Expect.Clan, ch.Clan)
+	diff("gender", c.Expect.Gender, ch.Gender)
+	diff("guardian", c.Expect.Guardian, ch.Guardian)
+	diff("city_state", c.Expect.CityState, ch.CityState)
+	diff("gc", gcOf(&c.Expect), gcOf(ch))
+	diff("gc_rank", c.Expect.GCRank, ch.GCRank)
+
+	got := make(map[models.Job]int, len(levels))
+	for _, lvl := range levels {
+		got[lvl.Job] = lvl.Level
+	}
+	for _, job := range models.Jobs {
+		diff("level "+string(job), c.Levels[job], got[job])
+	}
+	return diffs
+}
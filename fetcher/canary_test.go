@@ -0,0 +1,182 @@
+package fetcher
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liclac/gubal/models"
+)
+
+func testCanaryEmiHawke() Canary {
+	maelstrom := models.Maelstrom
+	return Canary{
+		ID: 7248246,
+		Expect: models.Character{
+			FirstName: "Emi",
+			LastName:  "Hawke",
+			Race:      models.AuRa,
+			Clan:      models.AuRaRaen,
+			Gender:    "♀",
+			Guardian:  models.Oschon,
+			CityState: models.Gridania,
+			World:     models.Ultros,
+			Title:     &models.CharacterTitle{Title: "Khloe's Friend"},
+			GC:        &maelstrom,
+			GCRank:    9,
+		},
+		Levels: map[models.Job]int{
+			models.PLD: 62, models.WAR: 60, models.DRK: 33,
+			models.WHM: 51, models.SCH: 70, models.AST: 50,
+			models.MNK: 68, models.DRG: 60, models.NIN: 61, models.SAM: 53,
+			models.BRD: 38, models.BLM: 70, models.SMN: 70, models.RDM: 52,
+			models.ARM: 14, models.LTW: 50, models.WVR: 16, models.ALC: 17, models.CUL: 12,
+			models.MIN: 60, models.BOT: 13, models.FSH: 60,
+		},
+	}
+}
+
+func TestLoadCanaries(t *testing.T) {
+	canaries, err := LoadCanaries(strings.NewReader(`[
+		{"id": 7248246, "expect": {"first_name": "Emi", "world": "Ultros", "title": {"title": "Khloe's Friend"}}, "levels": {"SCH": 70}}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, canaries, 1)
+	assert.Equal(t, int64(7248246), canaries[0].ID)
+	assert.Equal(t, "Emi", canaries[0].Expect.FirstName)
+	assert.Equal(t, models.Ultros, canaries[0].Expect.World)
+	assert.Equal(t, "Khloe's Friend", canaries[0].Expect.Title.Title)
+	assert.Equal(t, map[models.Job]int{models.SCH: 70}, canaries[0].Levels)
+
+	t.Run("No ID", func(t *testing.T) {
+		_, err := LoadCanaries(strings.NewReader(`[{"expect": {}}]`))
+		assert.EqualError(t, err, "canary #1 has no id")
+	})
+	t.Run("Unknown Page", func(t *testing.T) {
+		_, err := LoadCanaries(strings.NewReader(`[{"id": 1, "page": "tablet"}]`))
+		assert.EqualError(t, err, "canary #1 has an unknown page: tablet")
+	})
+}
+
+func TestRunCanary(t *testing.T) {
+	testdata := map[string]struct {
+		HTML   string
+		Canary func(c *Canary)
+		Result CanaryResult
+	}{
+		"OK": {
+			HTML:   testHTMLEmiHawke,
+			Result: CanaryResult{},
+		},
+		"Desktop": {
+			HTML:   testHTMLEmiHawkeDesktop,
+			Canary: func(c *Canary) { c.Page = PageDesktop },
+			Result: CanaryResult{},
+		},
+		"Changed": {
+			HTML: testHTMLEmiHawke,
+			Canary: func(c *Canary) {
+				c.Expect.World = models.Odin
+				c.Levels[models.SCH] = 60
+				delete(c.Levels, models.FSH)
+			},
+			Result: CanaryResult{Mismatches: []string{
+				"world: expected Odin, got Ultros",
+				"level SCH: expected 60, got 70",
+				"level FSH: expected 0, got 60",
+			}},
+		},
+		"Renamed Selector": {
+			HTML: strings.Replace(testHTMLEmiHawke, `class="frame__chara__world"`, `class="frame__chara__home"`, 1),
+			Result: CanaryResult{
+				Selectors: []string{".frame__chara__world"},
+				Errors:    []string{"unknown world: ''"},
+			},
+		},
+		"Renamed Block": {
+			HTML: strings.Replace(testHTMLEmiHawke, ">City-state<", ">City-State<", 1),
+			Result: CanaryResult{
+				Selectors:  []string{`.character-block__name "City-state"`},
+				Mismatches: []string{"city_state: expected Gridania, got "},
+			},
+		},
+		"No Jobs": {
+			HTML: strings.Replace(testHTMLEmiHawke, `character__job`, `character__classes`, -1),
+			Result: CanaryResult{
+				Selectors: []string{"ul.character__job li", ".character__job__level", ".character__job__name"},
+				Mismatches: []string{
+					"level PLD: expected 62, got 0",
+					"level WAR: expected 60, got 0",
+					"level DRK: expected 33, got 0",
+					"level WHM: expected 51, got 0",
+					"level SCH: expected 70, got 0",
+					"level AST: expected 50, got 0",
+					"level MNK: expected 68, got 0",
+					"level DRG: expected 60, got 0",
+					"level NIN: expected 61, got 0",
+					"level SAM: expected 53, got 0",
+					"level BRD: expected 38, got 0",
+					"level BLM: expected 70, got 0",
+					"level SMN: expected 70, got 0",
+					"level RDM: expected 52, got 0",
+					"level ARM: expected 14, got 0",
+					"level LTW: expected 50, got 0",
+					"level WVR: expected 16, got 0",
+					"level ALC: expected 17, got 0",
+					"level CUL: expected 12, got 0",
+					"level MIN: expected 60, got 0",
+					"level BOT: expected 13, got 0",
+					"level FSH: expected 60, got 0",
+				},
+			},
+		},
+	}
+	for name, data := range testdata {
+		t.Run(name, func(t *testing.T) {
+			testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "/character/7248246/", req.URL.Path)
+				io.WriteString(rw, data.HTML)
+			}))
+			realLodestoneBaseURL := LodestoneBaseURL
+			LodestoneBaseURL = testsrv.URL
+			defer func() {
+				testsrv.Close()
+				LodestoneBaseURL = realLodestoneBaseURL
+			}()
+
+			c := testCanaryEmiHawke()
+			if data.Canary != nil {
+				data.Canary(&c)
+			}
+			res, err := RunCanary(context.Background(), c)
+			require.NoError(t, err)
+			data.Result.ID = c.ID
+			assert.Equal(t, &data.Result, res)
+			assert.Equal(t, len(data.Result.Selectors)+len(data.Result.Errors)+len(data.Result.Mismatches) == 0, res.OK())
+		})
+	}
+}
+
+func TestRunCanaryNotFound(t *testing.T) {
+	testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	realLodestoneBaseURL := LodestoneBaseURL
+	LodestoneBaseURL = testsrv.URL
+	defer func() {
+		testsrv.Close()
+		LodestoneBaseURL = realLodestoneBaseURL
+	}()
+
+	_, err := RunCanary(context.Background(), Canary{ID: 1234})
+	assert.EqualError(t, err, "incorrect HTTP status code when fetching canary 1234: 404")
+
+	// The canary command records it in the canary's result instead, so it can check the rest.
+	assert.False(t, CanaryResult{ID: 1234, FetchError: err.Error()}.OK())
+}
@@ -3,13 +3,15 @@ package fetcher
 import (
 	"bytes"
 	"context"
-	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"gopkg.in/guregu/null.v3"
@@ -22,9 +24,19 @@ func init() { registerJob(func() Job { return &FetchCharacterJob{} }) }
 
 // FetchCharacterJob fetches a character.
 // A character that isn't found instead creates a CharacterTombstone in the database to signal this.
+// If the page hasn't changed since it was last processed, the character is only marked as seen,
+// unless Force is set. A page that can't be parsed is quarantined as a ParseFailure, instead of
+// failing the job, as retrying it would only fail the same way. Characters are fetched from the
+// NA Lodestone, unless another Region is given.
+//
+// Pages lists the versions of the page to try, in order; if one can't be parsed, the next one is
+// fetched instead, and only if none of them parse is the first one quarantined. The default is to
+// only fetch the mobile page; eg. ["desktop", "mobile"] prefers the desktop page instead.
 type FetchCharacterJob struct {
-	ID    int64 `json:"id"`
-	Force bool  `json:"force"`
+	ID     int64  `json:"id"`
+	Force  bool   `json:"force"`
+	Region Region `json:"region,omitempty"`
+	Pages  []Page `json:"pages,omitempty"`
 }
 
 // Type returns the type for a job.
@@ -35,69 +47,203 @@ func (j FetchCharacterJob) Run(ctx context.Context) (rjobs []Job, rerr error) {
 	ds := models.GetDataStore(ctx)
 
 	idStr := strconv.FormatInt(j.ID, 10)
-	lib.GetLogger(ctx).Info("Fetching Character", zap.Int64("id", j.ID))
+	lib.GetLogger(ctx).Info("Fetching Character", zap.Int64("id", j.ID), zap.String("region", string(j.Region.OrDefault())))
+	if !j.Region.OrDefault().Valid() {
+		return nil, errors.Errorf("unknown region: %s", j.Region)
+	}
+	pages := j.Pages
+	if len(pages) == 0 {
+		pages = DefaultPages
+	}
+	for _, page := range pages {
+		if !page.Valid() {
+			return nil, errors.Errorf("unknown page: %s", page)
+		}
+	}
 
 	// Check if the character has a tombstone, bail out if so.
-	dead, err := ds.CharacterTombstones().Check(j.ID)
+	dead, err := ds.CharacterTombstones().Check(ctx, j.ID)
 	if dead || err != nil {
 		return nil, err
 	}
 
-	// Read the character's public status page.
-	req, err := http.NewRequest("GET", LodestoneBaseURL+"/character/"+idStr+"/", nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", UserAgent)
-	// resp, err := http.DefaultClient.Do(req)
-	resp, err := doRequestWithCache(GetCacheFS(ctx), "char_"+idStr, req)
-	if err != nil {
-		return nil, err
+	var failedBody []byte
+	var failedErr error
+	cacheFS := GetCacheFS(ctx)
+	for _, page := range pages {
+		// Read the character's public status page.
+		status, body, err := getPage(ctx, cacheFS, j.Region, page, "/character/"+idStr+"/")
+		if err != nil {
+			return nil, err
+		}
+
+		// Bail out if the response code isn't 200 OK.
+		switch status {
+		case http.StatusOK:
+			// All quiet on the response front.
+		case http.StatusNotFound:
+			// The character doesn't exist, create a tombstone in the database to mark this and abort.
+			lib.GetLogger(ctx).Info("Character does not exist; creating tombstone", zap.Int64("id", j.ID))
+			if err := ds.CharacterTombstones().Create(ctx, j.ID); err != nil {
+				return nil, err
+			}
+			metricTombstonesCreated.Inc()
+			return nil, nil
+		default:
+			return nil, errors.Errorf("incorrect HTTP status code when fetching character data: %d", status)
+		}
+
+		// If the page is identical to the last one we processed, there's nothing new to parse or
+		// save, just note that the character is still around. A character that's somehow missing
+		// from the database despite that (eg. after a rollback) is parsed like normal.
+		cacheKey := j.cacheKey(page)
+		hash := cacheHash(body)
+		if !j.Force {
+			latest, err := cacheLatest(cacheFS, cacheKey)
+			if err != nil {
+				return nil, err
+			}
+			if latest == hash {
+				lib.GetLogger(ctx).Info("Character is unchanged", zap.Int64("id", j.ID))
+				err := ds.Characters().Touch(ctx, j.ID)
+				if !gorm.IsRecordNotFoundError(err) {
+					return nil, err
+				}
+				lib.GetLogger(ctx).Warn("Unchanged character is missing from the database", zap.Int64("id", j.ID))
+			}
+		}
+
+		// Actually parse the page! If it can't be parsed, try the next one; it isn't recorded in
+		// the cache, so it'll be parsed again if it's fetched again, eg. once the parser's been fixed.
+		char, levels, err := j.parse(ctx, body)
+		if err != nil {
+			lib.GetLogger(ctx).Warn("Couldn't parse character", zap.Int64("id", j.ID), zap.String("page", string(page)), zap.Error(err))
+			if failedErr == nil {
+				failedBody, failedErr = body, err
+			}
+			continue
+		}
+		if err := j.save(ctx, char, levels); err != nil {
+			return nil, err
+		}
+		return nil, cacheRecord(cacheFS, cacheKey, hash)
 	}
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+
+	// None of the pages parsed; quarantine the one that was asked for first.
+	lib.GetLogger(ctx).Warn("Couldn't parse any page for character; quarantining it", zap.Int64("id", j.ID))
+	return nil, j.quarantine(ctx, failedBody, failedErr)
+}
+
+// cacheKey returns the key a version of the character's page is tracked under in the cache. Each
+// region's pages are in a different language, and each version of them has different markup, so
+// they're tracked separately; NA's mobile pages keep their original key.
+func (j FetchCharacterJob) cacheKey(page Page) string {
+	key := "char_"
+	if region := j.Region.OrDefault(); region != DefaultRegion {
+		key += string(region) + "_"
 	}
-	if err := resp.Body.Close(); err != nil {
-		return nil, err
+	if page != PageMobile {
+		key += string(page) + "_"
 	}
+	return key + strconv.FormatInt(j.ID, 10)
+}
 
-	// Bail out if the response code isn't 200 OK.
-	switch resp.StatusCode {
-	case http.StatusOK:
-		// All quiet on the response front.
-	case http.StatusNotFound:
-		// The character doesn't exist, create a tombstone in the database to mark this and abort.
-		lib.GetLogger(ctx).Info("Character does not exist; creating tombstone", zap.Int64("id", j.ID))
-		return nil, ds.CharacterTombstones().Create(j.ID)
-	default:
-		return nil, errors.Errorf("incorrect HTTP status code when fetching character data: %d", resp.StatusCode)
+// ReparseFailure parses a quarantined page again, with the current parser. If it parses, the
+// character is saved and the ParseFailure deleted, otherwise it's updated with the new errors.
+// Returns whether it parsed; an error is only returned if something else went wrong.
+func ReparseFailure(ctx context.Context, f *models.ParseFailure) (bool, error) {
+	j := FetchCharacterJob{ID: f.CharacterID, Region: Region(f.Region)}
+	char, levels, err := j.parse(ctx, []byte(f.HTML))
+	if err != nil {
+		return false, j.quarantine(ctx, []byte(f.HTML), err)
 	}
+	return true, j.save(ctx, char, levels)
+}
 
-	// Actually parse the page! Parsing steps are split into smaller pieces for maintainability,
-	// and are combined into one big multierr so we can check them all in one fell swoop.
+// parse parses a character's page, using the first of the context's layouts that matches it; only
+// the layout differs between versions of the page, so they all produce the same character.
+// Parsing steps are split into smaller pieces for maintainability, and are combined into one big
+// multierr so we can check them all in one fell swoop. Parsing doesn't touch the database, so any
+// error means the page itself is unparseable.
+func (j FetchCharacterJob) parse(ctx context.Context, body []byte) (*models.Character, []*models.Level, error) {
 	doc, err := goquery.NewDocumentFromReader(bytes.NewBuffer(body))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	l := GetLayouts(ctx).Detect(doc)
+	if l == nil {
+		return nil, nil, errors.New("page doesn't match any known layout")
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("lodestone.layout", l.Version))
 
 	char := models.Character{ID: j.ID}
+	var levels []*models.Level
 	if err := multierr.Combine(
-		j.parseName(ctx, &char, doc),
-		j.parseTitle(ctx, &char, doc),
-		j.parseWorld(ctx, &char, doc),
-		j.parseBlocks(ctx, &char, doc),
-		j.parseJobs(ctx, &char, doc),
+		parseStep(ctx, "name", func(ctx context.Context) error { return j.parseName(ctx, &char, doc, l) }),
+		parseStep(ctx, "title", func(ctx context.Context) error { return j.parseTitle(ctx, &char, doc, l) }),
+		parseStep(ctx, "world", func(ctx context.Context) error { return j.parseWorld(ctx, &char, doc, l) }),
+		parseStep(ctx, "blocks", func(ctx context.Context) error { return j.parseBlocks(ctx, &char, doc, l) }),
+		parseStep(ctx, "jobs", func(ctx context.Context) error { return j.parseJobs(ctx, &char, &levels, doc, l) }),
 	); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return &char, levels, nil
+}
 
-	return nil, ds.Characters().Save(&char)
+// save saves a parsed character, and clears any previous ParseFailure for it.
+func (j FetchCharacterJob) save(ctx context.Context, char *models.Character, levels []*models.Level) error {
+	ds := models.GetDataStore(ctx)
+
+	// Titles are stored separately; look up the parsed one's ID.
+	if char.Title != nil {
+		title, err := ds.CharacterTitles().GetOrCreate(ctx, char.Title.Title)
+		if err != nil {
+			return err
+		}
+		char.Title = title
+	}
+
+	// Levels are written in one go, after the character they belong to.
+	if err := ds.Characters().Save(ctx, char); err != nil {
+		return err
+	}
+	if len(levels) > 0 {
+		if err := ds.Levels().SetAll(ctx, levels); err != nil {
+			return err
+		}
+	}
+	return ds.ParseFailures().Delete(ctx, j.ID)
+}
+
+// quarantine stores a page that couldn't be parsed as a ParseFailure, with every error from it.
+func (j FetchCharacterJob) quarantine(ctx context.Context, body []byte, err error) error {
+	var msgs models.ErrorList
+	for _, err := range multierr.Errors(err) {
+		msgs = append(msgs, err.Error())
+	}
+	return models.GetDataStore(ctx).ParseFailures().Save(ctx, &models.ParseFailure{
+		CharacterID:   j.ID,
+		Region:        string(j.Region.OrDefault()),
+		ParserVersion: ParserVersion,
+		Errors:        msgs,
+		HTML:          string(body),
+	})
+}
+
+// parseStep runs a parsing step in a span, and counts it in metrics if it fails.
+func parseStep(ctx context.Context, step string, fn func(context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "parse "+step)
+	err := fn(ctx)
+	if err != nil {
+		metricParseErrors.WithLabelValues(step).Inc()
+	}
+	lib.EndSpan(span, err)
+	return err
 }
 
 // parseName parses the character's FirstName and LastName from the page.
-func (j FetchCharacterJob) parseName(ctx context.Context, ch *models.Character, doc *goquery.Document) error {
-	fullName := trim(doc.Find(".frame__chara__name").First().Text())
+func (j FetchCharacterJob) parseName(ctx context.Context, ch *models.Character, doc *goquery.Document, l *Layout) error {
+	fullName := trim(doc.Find(l.Selectors.Name).First().Text())
 	firstAndLast := strings.SplitN(fullName, " ", 2)
 	if l := len(firstAndLast); l != 2 {
 		return errors.Errorf("malformed name: \"%s\" (%d components)", fullName, l)
@@ -107,174 +253,49 @@ func (j FetchCharacterJob) parseName(ctx context.Context, ch *models.Character,
 	return nil
 }
 
-// parseTitle parses the character's title from the page; titles are stored externally.
-func (j FetchCharacterJob) parseTitle(ctx context.Context, ch *models.Character, doc *goquery.Document) error {
-	titleStr := trim(doc.Find(".frame__chara__title").First().Text())
+// parseTitle parses the character's title from the page; titles are stored externally, so its ID
+// is looked up when the character is saved.
+func (j FetchCharacterJob) parseTitle(ctx context.Context, ch *models.Character, doc *goquery.Document, l *Layout) error {
+	titleStr := trim(doc.Find(l.Selectors.Title).First().Text())
 	if titleStr == "" {
 		ch.Title = nil
 		ch.TitleID = null.Int{}
 		return nil
 	}
-	title, err := models.GetDataStore(ctx).CharacterTitles().GetOrCreate(titleStr)
-	ch.Title = title
-	return err
+	ch.Title = &models.CharacterTitle{Title: titleStr}
+	return nil
 }
 
-func (j FetchCharacterJob) parseWorld(ctx context.Context, ch *models.Character, doc *goquery.Document) error {
-	world := trim(doc.Find(".frame__chara__world").First().Text())
-	switch world {
-	case "Aegis":
-		ch.World = models.Aegis
-	case "Atomos":
-		ch.World = models.Atomos
-	case "Carbuncle":
-		ch.World = models.Carbuncle
-	case "Garuda":
-		ch.World = models.Garuda
-	case "Gungnir":
-		ch.World = models.Gungnir
-	case "Kujata":
-		ch.World = models.Kujata
-	case "Ramuh":
-		ch.World = models.Ramuh
-	case "Tonberry":
-		ch.World = models.Tonberry
-	case "Typhon":
-		ch.World = models.Typhon
-	case "Unicorn":
-		ch.World = models.Unicorn
-	case "Alexander":
-		ch.World = models.Alexander
-	case "Bahamut":
-		ch.World = models.Bahamut
-	case "Durandal":
-		ch.World = models.Durandal
-	case "Fenrir":
-		ch.World = models.Fenrir
-	case "Ifrit":
-		ch.World = models.Ifrit
-	case "Ridill":
-		ch.World = models.Ridill
-	case "Tiamat":
-		ch.World = models.Tiamat
-	case "Ultima":
-		ch.World = models.Ultima
-	case "Valefor":
-		ch.World = models.Valefor
-	case "Yojimbo":
-		ch.World = models.Yojimbo
-	case "Zeromus":
-		ch.World = models.Zeromus
-	case "Anima":
-		ch.World = models.Anima
-	case "Asura":
-		ch.World = models.Asura
-	case "Belias":
-		ch.World = models.Belias
-	case "Chocobo":
-		ch.World = models.Chocobo
-	case "Hades":
-		ch.World = models.Hades
-	case "Ixion":
-		ch.World = models.Ixion
-	case "Mandragora":
-		ch.World = models.Mandragora
-	case "Masamune":
-		ch.World = models.Masamune
-	case "Pandaemonium":
-		ch.World = models.Pandaemonium
-	case "Shinryu":
-		ch.World = models.Shinryu
-	case "Titan":
-		ch.World = models.Titan
-	case "Adamantoise":
-		ch.World = models.Adamantoise
-	case "Balmung":
-		ch.World = models.Balmung
-	case "Cactuar":
-		ch.World = models.Cactuar
-	case "Coeurl":
-		ch.World = models.Coeurl
-	case "Faerie":
-		ch.World = models.Faerie
-	case "Gilgamesh":
-		ch.World = models.Gilgamesh
-	case "Goblin":
-		ch.World = models.Goblin
-	case "Jenova":
-		ch.World = models.Jenova
-	case "Mateus":
-		ch.World = models.Mateus
-	case "Midgardsormr":
-		ch.World = models.Midgardsormr
-	case "Sargatanas":
-		ch.World = models.Sargatanas
-	case "Siren":
-		ch.World = models.Siren
-	case "Zalera":
-		ch.World = models.Zalera
-	case "Behemoth":
-		ch.World = models.Behemoth
-	case "Brynhildr":
-		ch.World = models.Brynhildr
-	case "Diabolos":
-		ch.World = models.Diabolos
-	case "Excalibur":
-		ch.World = models.Excalibur
-	case "Exodus":
-		ch.World = models.Exodus
-	case "Famfrit":
-		ch.World = models.Famfrit
-	case "Hyperion":
-		ch.World = models.Hyperion
-	case "Lamia":
-		ch.World = models.Lamia
-	case "Leviathan":
-		ch.World = models.Leviathan
-	case "Malboro":
-		ch.World = models.Malboro
-	case "Ultros":
-		ch.World = models.Ultros
-	case "Cerberus":
-		ch.World = models.Cerberus
-	case "Lich":
-		ch.World = models.Lich
-	case "Louisoix":
-		ch.World = models.Louisoix
-	case "Moogle":
-		ch.World = models.Moogle
-	case "Odin":
-		ch.World = models.Odin
-	case "Omega":
-		ch.World = models.Omega
-	case "Phoenix":
-		ch.World = models.Phoenix
-	case "Ragnarok":
-		ch.World = models.Ragnarok
-	case "Shiva":
-		ch.World = models.Shiva
-	case "Zodiark":
-		ch.World = models.Zodiark
-	default:
-		return errors.Errorf("unknown world: '%s'", world)
+// parseWorld parses the character's world from the page; world names are the same in every region.
+func (j FetchCharacterJob) parseWorld(ctx context.Context, ch *models.Character, doc *goquery.Document, l *Layout) error {
+	world := trim(doc.Find(l.Selectors.World).First().Text())
+	if w := models.World(world); w.Valid() {
+		ch.World = w
+		return nil
 	}
-	return nil
+	return errors.Errorf("unknown world: '%s'", world)
 }
 
 // parseBlocks parses the blocks containing Race/Clan/Gender, Nameday/Guardian, City State and GC.
-func (j FetchCharacterJob) parseBlocks(ctx context.Context, ch *models.Character, doc *goquery.Document) error {
+func (j FetchCharacterJob) parseBlocks(ctx context.Context, ch *models.Character, doc *goquery.Document, l *Layout) error {
+	lang := j.Region.Language()
+	names, ok := l.Blocks[lang]
+	if !ok {
+		return errors.Errorf("layout %d has no block names for language: %s", l.Version, lang)
+	}
+
 	var errs []error
-	doc.Find(".character-block").Each(func(i int, sel *goquery.Selection) {
-		title := trim(sel.Find(".character-block__name").First().Text())
+	doc.Find(l.Selectors.Block).Each(func(i int, sel *goquery.Selection) {
+		title := trim(sel.Find(l.Selectors.BlockName).First().Text())
 		switch title {
-		case "Race/Clan/Gender":
-			errs = append(errs, j.parseRaceClanGenderBlock(ctx, ch, doc, sel))
-		case "Nameday":
-			errs = append(errs, j.parseNamedayGuardianBlock(ctx, ch, doc, sel))
-		case "City-state":
-			errs = append(errs, j.parseCityStateBlock(ctx, ch, doc, sel))
-		case "Grand Company":
-			errs = append(errs, j.parseGrandCompanyBlock(ctx, ch, doc, sel))
+		case names.RaceClanGender:
+			errs = append(errs, j.parseRaceClanGenderBlock(ctx, ch, doc, l, sel))
+		case names.Nameday:
+			errs = append(errs, j.parseNamedayGuardianBlock(ctx, ch, doc, l, sel))
+		case names.CityState:
+			errs = append(errs, j.parseCityStateBlock(ctx, ch, doc, l, sel))
+		case names.GrandCompany:
+			errs = append(errs, j.parseGrandCompanyBlock(ctx, ch, doc, l, sel))
 		default:
 			lib.GetLogger(ctx).Warn("unknown box on profile", zap.String("title", title))
 		}
@@ -282,9 +303,9 @@ func (j FetchCharacterJob) parseBlocks(ctx context.Context, ch *models.Character
 	return multierr.Combine(errs...)
 }
 
-func (j FetchCharacterJob) parseRaceClanGenderBlock(ctx context.Context, ch *models.Character, doc *goquery.Document, sel *goquery.Selection) error {
+func (j FetchCharacterJob) parseRaceClanGenderBlock(ctx context.Context, ch *models.Character, doc *goquery.Document, l *Layout, sel *goquery.Selection) error {
 	// Extract the blurb.
-	str := trim(sel.Find(".character-block__profile").Text())
+	str := trim(sel.Find(l.Selectors.BlockProfile).Text())
 
 	// Split it up into parts.
 	parts := strings.SplitN(str, "/", 2)
@@ -298,101 +319,39 @@ func (j FetchCharacterJob) parseRaceClanGenderBlock(ctx context.Context, ch *mod
 	ch.Gender = gender
 
 	// Assign race/clan.
-	switch raceClan {
-	case "HyurMidlander":
-		ch.Race = models.Hyur
-		ch.Clan = models.HyurMidlander
-	case "HyurHighlander":
-		ch.Race = models.Hyur
-		ch.Clan = models.HyurHighlander
-	case "ElezenWildwood":
-		ch.Race = models.Elezen
-		ch.Clan = models.ElezenWildwood
-	case "ElezenDuskwight":
-		ch.Race = models.Elezen
-		ch.Clan = models.ElezenDuskwight
-	case "LalafellPlainsfolk":
-		ch.Race = models.Lalafell
-		ch.Clan = models.LalafellPlainsfolk
-	case "LalafellDunesfolk":
-		ch.Race = models.Lalafell
-		ch.Clan = models.LalafellDunesfolk
-	case "Miqo'teSeeker of the Sun":
-		ch.Race = models.Miqote
-		ch.Clan = models.MiqoteSunSeeker
-	case "Miqo'teKeeper of the Moon":
-		ch.Race = models.Miqote
-		ch.Clan = models.MiqoteMoonKeeper
-	case "RoegadynSea Wolf":
-		ch.Race = models.Roegadyn
-		ch.Clan = models.RoegadynSeaWolf
-	case "RoegadynHellsguard":
-		ch.Race = models.Roegadyn
-		ch.Clan = models.RoegadynHellsguard
-	case "Au RaRaen":
-		ch.Race = models.AuRa
-		ch.Clan = models.AuRaRaen
-	case "Au RaXaela":
-		ch.Race = models.AuRa
-		ch.Clan = models.AuRaXaela
-	default:
+	race, clan, ok := j.Region.Language().Locale().RaceClan(raceClan)
+	if !ok {
 		return errors.Errorf("unknown race/clan string: \"%s\"", raceClan)
 	}
+	ch.Race = race
+	ch.Clan = clan
 	return nil
 }
 
-func (j FetchCharacterJob) parseNamedayGuardianBlock(ctx context.Context, ch *models.Character, doc *goquery.Document, sel *goquery.Selection) error {
+func (j FetchCharacterJob) parseNamedayGuardianBlock(ctx context.Context, ch *models.Character, doc *goquery.Document, l *Layout, sel *goquery.Selection) error {
 	// birth := trim(sel.Find(".character-block__birth").Text())
-	guardian := trim(sel.Find(".character-block__profile").Text())
-
-	switch {
-	case strings.HasPrefix(guardian, "Halone"):
-		ch.Guardian = models.Halone
-	case strings.HasPrefix(guardian, "Menphina"):
-		ch.Guardian = models.Menphina
-	case strings.HasPrefix(guardian, "Thaliak"):
-		ch.Guardian = models.Thaliak
-	case strings.HasPrefix(guardian, "Nymeia"):
-		ch.Guardian = models.Nymeia
-	case strings.HasPrefix(guardian, "Llymlaen"):
-		ch.Guardian = models.Llymlaen
-	case strings.HasPrefix(guardian, "Oschon"):
-		ch.Guardian = models.Oschon
-	case strings.HasPrefix(guardian, "Byregot"):
-		ch.Guardian = models.Byregot
-	case strings.HasPrefix(guardian, "Rhalgr"):
-		ch.Guardian = models.Rhalgr
-	case strings.HasPrefix(guardian, "Azeyma"):
-		ch.Guardian = models.Azeyma
-	case strings.HasPrefix(guardian, "Nald'thal"):
-		ch.Guardian = models.Naldthal
-	case strings.HasPrefix(guardian, "Nophica"):
-		ch.Guardian = models.Nophica
-	case strings.HasPrefix(guardian, "Althyk"):
-		ch.Guardian = models.Althyk
-	default:
+	guardian := trim(sel.Find(l.Selectors.BlockProfile).Text())
+
+	g, ok := j.Region.Language().Locale().Guardian(guardian)
+	if !ok {
 		return errors.Errorf("unknown guardian: '%s'", guardian)
 	}
+	ch.Guardian = g
 	return nil
 }
 
-func (j FetchCharacterJob) parseCityStateBlock(ctx context.Context, ch *models.Character, doc *goquery.Document, sel *goquery.Selection) error {
-	cityState := trim(sel.Find(".character-block__profile").Text())
-	switch cityState {
-	case "Gridania":
-		ch.CityState = models.Gridania
-	case "Ul'dah":
-		ch.CityState = models.Uldah
-	case "Limsa Lominsa":
-		ch.CityState = models.Limsa
-	default:
+func (j FetchCharacterJob) parseCityStateBlock(ctx context.Context, ch *models.Character, doc *goquery.Document, l *Layout, sel *goquery.Selection) error {
+	cityState := trim(sel.Find(l.Selectors.BlockProfile).Text())
+	cs, ok := j.Region.Language().Locale().CityStates[cityState]
+	if !ok {
 		return errors.Errorf("unknown city state: '%s'", cityState)
 	}
+	ch.CityState = cs
 	return nil
 }
 
-func (j FetchCharacterJob) parseGrandCompanyBlock(ctx context.Context, ch *models.Character, doc *goquery.Document, sel *goquery.Selection) error {
-	str := trim(sel.Find(".character-block__profile").Text())
+func (j FetchCharacterJob) parseGrandCompanyBlock(ctx context.Context, ch *models.Character, doc *goquery.Document, l *Layout, sel *goquery.Selection) error {
+	str := trim(sel.Find(l.Selectors.BlockProfile).Text())
 
 	parts := strings.SplitN(str, "/", 2)
 	if l := len(parts); l != 2 {
@@ -401,52 +360,28 @@ func (j FetchCharacterJob) parseGrandCompanyBlock(ctx context.Context, ch *model
 	gcName := trim(parts[0])
 	rankName := trim(parts[1])
 
-	switch gcName {
-	case "Maelstrom":
-		gc := models.Maelstrom
-		ch.GC = &gc
-	case "Order of the Twin Adder":
-		gc := models.Adders
+	// Unknown grand companies and ranks are left blank, rather than failing the whole page.
+	loc := j.Region.Language().Locale()
+	if gc, ok := loc.GrandCompanies[gcName]; ok {
 		ch.GC = &gc
-	case "Immortal Flames":
-		gc := models.Flames
-		ch.GC = &gc
-	}
-
-	switch {
-	case rankName == "":
-	case strings.HasSuffix(rankName, "Private Third Class"):
-		ch.GCRank = 1
-	case strings.HasSuffix(rankName, "Private Second Class"):
-		ch.GCRank = 2
-	case strings.HasSuffix(rankName, "Private First Class"):
-		ch.GCRank = 3
-	case strings.HasSuffix(rankName, "Corporal"):
-		ch.GCRank = 4
-	case strings.HasSuffix(rankName, "Sergeant Third Class"):
-		ch.GCRank = 5
-	case strings.HasSuffix(rankName, "Sergeant Second Class"):
-		ch.GCRank = 6
-	case strings.HasSuffix(rankName, "Sergeant First Class"):
-		ch.GCRank = 7
-	case strings.HasPrefix(rankName, "Chief") && strings.HasSuffix(rankName, "Sergeant"):
-		ch.GCRank = 8
-	case strings.HasPrefix(rankName, "Second") && strings.HasSuffix(rankName, "Lieutenant"):
-		ch.GCRank = 9
-	case strings.HasPrefix(rankName, "First") && strings.HasSuffix(rankName, "Lieutenant"):
-		ch.GCRank = 10
+		ch.GCRank = loc.GCRank(gc, rankName)
 	}
-
 	return nil
 }
 
-func (j FetchCharacterJob) parseJobs(ctx context.Context, ch *models.Character, doc *goquery.Document) error {
+// parseJobs parses the character's levels from the page into lvls; they're saved separately.
+func (j FetchCharacterJob) parseJobs(ctx context.Context, ch *models.Character, lvls *[]*models.Level, doc *goquery.Document, l *Layout) error {
+	jobs := j.Region.Language().Locale().Jobs
 	var errs []error
-	doc.Find("ul.character__job li").Each(func(i int, sel *goquery.Selection) {
+	doc.Find(l.Selectors.Job).Each(func(i int, sel *goquery.Selection) {
 		levelObj := models.Level{CharacterID: ch.ID}
 
 		// Parse level, skip over not yet unlocked jobs.
-		levelStr := trim(sel.Find(".character__job__level").First().Text())
+		levelSel := sel
+		if l.Selectors.JobLevel != "" {
+			levelSel = sel.Find(l.Selectors.JobLevel).First()
+		}
+		levelStr := trim(levelSel.Text())
 		if levelStr == "-" || levelStr == "" {
 			return
 		}
@@ -456,69 +391,23 @@ func (j FetchCharacterJob) parseJobs(ctx context.Context, ch *models.Character,
 		}
 		levelObj.Level = int(level)
 
-		// Parse the job name.
-		jobName := trim(sel.Find(".character__job__name").First().Text())
-		switch jobName {
-		case "":
+		// Parse the job name; a tooltip names the class after the job, eg. "Paladin / Gladiator".
+		nameSel := sel.Find(l.Selectors.JobName).First()
+		jobName := trim(nameSel.Text())
+		if l.Selectors.JobNameAttr != "" {
+			jobName = trim(strings.SplitN(nameSel.AttrOr(l.Selectors.JobNameAttr, ""), "/", 2)[0])
+		}
+		if jobName == "" {
 			return
-		case "Paladin", "Gladiator":
-			levelObj.Job = models.PLD
-		case "Warrior", "Marauder":
-			levelObj.Job = models.WAR
-		case "Dark Knight":
-			levelObj.Job = models.DRK
-		case "White Mage", "Conjurer":
-			levelObj.Job = models.WHM
-		case "Scholar":
-			levelObj.Job = models.SCH
-		case "Astrologian":
-			levelObj.Job = models.AST
-		case "Monk", "Pugilist":
-			levelObj.Job = models.MNK
-		case "Dragoon", "Lancer":
-			levelObj.Job = models.DRG
-		case "Ninja", "Rogue":
-			levelObj.Job = models.NIN
-		case "Samurai":
-			levelObj.Job = models.SAM
-		case "Bard", "Archer":
-			levelObj.Job = models.BRD
-		case "Machinist":
-			levelObj.Job = models.MCH
-		case "Black Mage", "Thaumaturge":
-			levelObj.Job = models.BLM
-		case "Summoner", "Arcanist":
-			levelObj.Job = models.SMN
-		case "Red Mage":
-			levelObj.Job = models.RDM
-		case "Carpenter":
-			levelObj.Job = models.CRP
-		case "Blacksmith":
-			levelObj.Job = models.BSM
-		case "Armorer":
-			levelObj.Job = models.ARM
-		case "Goldsmith":
-			levelObj.Job = models.GSM
-		case "Leatherworker":
-			levelObj.Job = models.LTW
-		case "Weaver":
-			levelObj.Job = models.WVR
-		case "Alchemist":
-			levelObj.Job = models.ALC
-		case "Culinarian":
-			levelObj.Job = models.CUL
-		case "Miner":
-			levelObj.Job = models.MIN
-		case "Botanist":
-			levelObj.Job = models.BOT
-		case "Fisher":
-			levelObj.Job = models.FSH
-		default:
+		}
+		job, ok := jobs[jobName]
+		if !ok {
 			errs = append(errs, errors.Errorf("unknown job: '%s'", jobName))
 			return
 		}
+		levelObj.Job = job
 
-		errs = append(errs, models.GetDataStore(ctx).Levels().Set(&levelObj))
+		*lvls = append(*lvls, &levelObj)
 	})
 	return multierr.Combine(errs...)
 }
@@ -3,11 +3,15 @@ package fetcher
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -15,6 +19,7 @@ import (
 )
 
 func TestFetchCharacterJob(t *testing.T) {
+	maelstrom := models.Maelstrom
 	testdata := map[string]models.Character{
 		testHTMLEmiHawke: models.Character{
 			ID:        7248246,
@@ -23,7 +28,22 @@ func TestFetchCharacterJob(t *testing.T) {
 			Race:      models.AuRa,
 			Clan:      models.AuRaRaen,
 			Gender:    "♀",
+			Guardian:  models.Oschon,
+			CityState: models.Gridania,
+			World:     models.Ultros,
 			Title:     &models.CharacterTitle{Title: "Khloe's Friend"},
+			GC:        &maelstrom,
+			GCRank:    9,
+		},
+	}
+	expectLevels := map[string]map[models.Job]int{
+		testHTMLEmiHawke: {
+			models.PLD: 62, models.WAR: 60, models.DRK: 33,
+			models.WHM: 51, models.SCH: 70, models.AST: 50,
+			models.MNK: 68, models.DRG: 60, models.NIN: 61, models.SAM: 53,
+			models.BRD: 38, models.BLM: 70, models.SMN: 70, models.RDM: 52,
+			models.ARM: 14, models.LTW: 50, models.WVR: 16, models.ALC: 17, models.CUL: 12,
+			models.MIN: 60, models.BOT: 13, models.FSH: 60,
 		},
 	}
 	for html, expect := range testdata {
@@ -48,13 +68,22 @@ func TestFetchCharacterJob(t *testing.T) {
 
 			var calls []*gomock.Call
 			{
-				calls = append(calls, ds.CharacterTombstoneStore.EXPECT().Check(expect.ID).Return(false, nil))
+				calls = append(calls, ds.CharacterTombstoneStore.EXPECT().Check(gomock.Any(), expect.ID).Return(false, nil))
 
 				if expect.Title != nil {
-					calls = append(calls, ds.CharacterTitleStore.EXPECT().GetOrCreate(expect.Title.Title).Return(expect.Title, nil))
+					calls = append(calls, ds.CharacterTitleStore.EXPECT().GetOrCreate(gomock.Any(), expect.Title.Title).Return(expect.Title, nil))
 				}
 
-				calls = append(calls, ds.CharacterStore.EXPECT().Save(&expect).Return(nil))
+				calls = append(calls, ds.CharacterStore.EXPECT().Save(gomock.Any(), &expect).Return(nil))
+				calls = append(calls, ds.LevelStore.EXPECT().SetAll(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, lvls []*models.Level) {
+					levels := make(map[models.Job]int, len(lvls))
+					for _, lvl := range lvls {
+						assert.Equal(t, expect.ID, lvl.CharacterID)
+						levels[lvl.Job] = lvl.Level
+					}
+					assert.Equal(t, expectLevels[html], levels)
+				}).Return(nil))
+				calls = append(calls, ds.ParseFailureStore.EXPECT().Delete(gomock.Any(), expect.ID).Return(nil))
 			}
 			gomock.InOrder(calls...)
 
@@ -66,6 +95,59 @@ func TestFetchCharacterJob(t *testing.T) {
 	}
 }
 
+func TestFetchCharacterJobMemory(t *testing.T) {
+	testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		io.WriteString(rw, testHTMLEmiHawke)
+	}))
+	realLodestoneBaseURL := LodestoneBaseURL
+	LodestoneBaseURL = testsrv.URL
+	defer func() {
+		testsrv.Close()
+		LodestoneBaseURL = realLodestoneBaseURL
+	}()
+
+	ds := models.NewMemoryDataStore()
+
+	ctx := context.Background()
+	ctx = models.WithDataStore(ctx, ds)
+
+	id := int64(7248246)
+	job := FetchCharacterJob{ID: id}
+	jobs, err := job.Run(ctx)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 0)
+
+	ch, err := ds.Characters().Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Emi", ch.FirstName)
+	assert.Equal(t, "Hawke", ch.LastName)
+	assert.Equal(t, models.AuRa, ch.Race)
+	assert.Equal(t, models.AuRaRaen, ch.Clan)
+	assert.Equal(t, "♀", ch.Gender)
+	assert.Equal(t, models.Oschon, ch.Guardian)
+	assert.Equal(t, models.Gridania, ch.CityState)
+	assert.Equal(t, models.Ultros, ch.World)
+	if assert.NotNil(t, ch.GC) {
+		assert.Equal(t, models.Maelstrom, *ch.GC)
+	}
+	assert.Equal(t, 9, ch.GCRank)
+
+	title, err := ds.CharacterTitles().GetOrCreate(ctx, "Khloe's Friend")
+	require.NoError(t, err)
+	assert.Equal(t, int64(title.ID), ch.TitleID.Int64)
+
+	for job, level := range map[models.Job]int{
+		models.PLD: 62,
+		models.SCH: 70,
+		models.MCH: 0,
+		models.FSH: 60,
+	} {
+		lvl, err := ds.Levels().Get(ctx, id, job)
+		require.NoError(t, err)
+		assert.Equal(t, level, lvl.Level, "%s", job)
+	}
+}
+
 func TestFetchCharacterJobHasTombstone(t *testing.T) {
 	testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		require.FailNow(t, "THOU SHALT NOT MAKE REQUESTS")
@@ -86,7 +168,7 @@ func TestFetchCharacterJobHasTombstone(t *testing.T) {
 	ctx = models.WithDataStore(ctx, ds)
 
 	id := int64(1234)
-	ds.CharacterTombstoneStore.EXPECT().Check(id).Return(true, nil)
+	ds.CharacterTombstoneStore.EXPECT().Check(gomock.Any(), id).Return(true, nil)
 
 	job := FetchCharacterJob{ID: id}
 	jobs, err := job.Run(ctx)
@@ -115,8 +197,45 @@ func TestFetchCharacterJobNotFound(t *testing.T) {
 
 	id := int64(1234)
 	gomock.InOrder(
-		ds.CharacterTombstoneStore.EXPECT().Check(id).Return(false, nil),
-		ds.CharacterTombstoneStore.EXPECT().Create(id).Return(nil),
+		ds.CharacterTombstoneStore.EXPECT().Check(gomock.Any(), id).Return(false, nil),
+		ds.CharacterTombstoneStore.EXPECT().Create(gomock.Any(), id).Return(nil),
+	)
+
+	job := FetchCharacterJob{ID: id}
+	jobs, err := job.Run(ctx)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 0)
+}
+
+func TestFetchCharacterJobUnchanged(t *testing.T) {
+	testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		io.WriteString(rw, testHTMLEmiHawke)
+	}))
+	realLodestoneBaseURL := LodestoneBaseURL
+	LodestoneBaseURL = testsrv.URL
+	defer func() {
+		testsrv.Close()
+		LodestoneBaseURL = realLodestoneBaseURL
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ds := models.NewMockDataStore(ctrl)
+
+	// Pretend we've already processed this exact page.
+	id := int64(7248246)
+	fs := afero.NewMemMapFs()
+	require.NoError(t, cacheRecord(fs, "char_7248246", cacheHash([]byte(testHTMLEmiHawke))))
+
+	ctx := context.Background()
+	ctx = models.WithDataStore(ctx, ds)
+	ctx = WithCacheFS(ctx, fs)
+
+	// The page shouldn't be parsed or saved, only touched.
+	gomock.InOrder(
+		ds.CharacterTombstoneStore.EXPECT().Check(gomock.Any(), id).Return(false, nil),
+		ds.CharacterStore.EXPECT().Touch(gomock.Any(), id).Return(nil),
 	)
 
 	job := FetchCharacterJob{ID: id}
@@ -125,6 +244,97 @@ func TestFetchCharacterJobNotFound(t *testing.T) {
 	assert.Len(t, jobs, 0)
 }
 
+func TestFetchCharacterJobParseFailure(t *testing.T) {
+	html := strings.Replace(testHTMLEmiHawke, `<p class="frame__chara__world">Ultros</p>`, `<p class="frame__chara__world">Nowhere</p>`, 1)
+	html = strings.Replace(html, "Oschon, the Wanderer", "Nobody", 1)
+	testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		io.WriteString(rw, html)
+	}))
+	realLodestoneBaseURL := LodestoneBaseURL
+	LodestoneBaseURL = testsrv.URL
+	defer func() {
+		testsrv.Close()
+		LodestoneBaseURL = realLodestoneBaseURL
+	}()
+
+	ds := models.NewMemoryDataStore()
+	fs := afero.NewMemMapFs()
+
+	ctx := context.Background()
+	ctx = models.WithDataStore(ctx, ds)
+	ctx = WithCacheFS(ctx, fs)
+
+	// The job should succeed, so it isn't retried, but the page should be quarantined.
+	id := int64(7248246)
+	jobs, err := FetchCharacterJob{ID: id}.Run(ctx)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 0)
+
+	_, err = ds.Characters().Get(ctx, id)
+	assert.True(t, gorm.IsRecordNotFoundError(err))
+
+	f, err := ds.ParseFailures().Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, ParserVersion, f.ParserVersion)
+	assert.Equal(t, models.ErrorList{"unknown world: 'Nowhere'", "unknown guardian: 'Nobody'"}, f.Errors)
+	assert.Equal(t, html, f.HTML)
+
+	// It shouldn't be recorded in the cache, so it's parsed again the next time it's fetched.
+	latest, err := cacheLatest(fs, "char_7248246")
+	require.NoError(t, err)
+	assert.Equal(t, "", latest)
+}
+
+func TestReparseFailure(t *testing.T) {
+	ds := models.NewMemoryDataStore()
+	ctx := models.WithDataStore(context.Background(), ds)
+
+	// A page the current parser can handle should be saved, and its failure cleared.
+	t.Run("Fixed", func(t *testing.T) {
+		id := int64(7248246)
+		require.NoError(t, ds.ParseFailures().Save(ctx, &models.ParseFailure{
+			CharacterID:   id,
+			ParserVersion: ParserVersion - 1,
+			Errors:        models.ErrorList{"unknown world: 'Ultros'"},
+			HTML:          testHTMLEmiHawke,
+		}))
+		ok, err := ReparseFailure(ctx, &models.ParseFailure{CharacterID: id, HTML: testHTMLEmiHawke})
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ch, err := ds.Characters().Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Emi", ch.FirstName)
+		assert.Equal(t, models.Ultros, ch.World)
+		lvl, err := ds.Levels().Get(ctx, id, models.SCH)
+		require.NoError(t, err)
+		assert.Equal(t, 70, lvl.Level)
+
+		_, err = ds.ParseFailures().Get(ctx, id)
+		assert.True(t, gorm.IsRecordNotFoundError(err))
+	})
+
+	// One it still can't should be updated with the current errors and version.
+	t.Run("Still Broken", func(t *testing.T) {
+		id := int64(1234)
+		require.NoError(t, ds.ParseFailures().Save(ctx, &models.ParseFailure{
+			CharacterID:   id,
+			ParserVersion: ParserVersion - 1,
+			Errors:        models.ErrorList{"something else"},
+			HTML:          "<html></html>",
+		}))
+		ok, err := ReparseFailure(ctx, &models.ParseFailure{CharacterID: id, HTML: "<html></html>"})
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		f, err := ds.ParseFailures().Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, ParserVersion, f.ParserVersion)
+		assert.Contains(t, f.Errors, `malformed name: "" (1 components)`)
+		assert.Equal(t, "<html></html>", f.HTML)
+	})
+}
+
 const testHTMLEmiHawke = `<!DOCTYPE html>
 <html lang="en-us" class="en-us" xmlns:og="http://ogp.me/ns#" xmlns:fb="http://www.facebook.com/2008/fbml">
 <head><meta charset="utf-8">
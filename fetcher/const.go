@@ -1,8 +1,16 @@
 package fetcher
 
-// UserAgent is the user agent we send with requests. We mimic a mobile browser, because the
-// Lodestone detects that and sends a mobile page, which is much smaller than the desktop one.
+// UserAgent is the user agent we send with requests. By default, we mimic a mobile browser, because
+// the Lodestone detects that and sends a mobile page, which is much smaller than the desktop one.
 const UserAgent = "Mozilla/5.0 (iPhone; CPU iPhone OS 9_1 like Mac OS X) AppleWebKit/601.1.46 (KHTML, like Gecko) Version/9.0 Mobile/13B143 Safari/601.1"
 
-// LodestoneBaseURL is the base URL for requests to the Lodestone.
-var LodestoneBaseURL = "https://na.finalfantasyxiv.com/lodestone/"
+// DesktopUserAgent is the user agent we send with requests for the desktop page, see PageDesktop.
+const DesktopUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/64.0.3282.186 Safari/537.36"
+
+// LodestoneBaseURL is the base URL for requests to the Lodestone; {region} is replaced with the
+// Region to request from, eg. "na" or "jp".
+var LodestoneBaseURL = "https://{region}.finalfantasyxiv.com/lodestone/"
+
+// ParserVersion is the version of the character page parser, recorded with pages it couldn't
+// parse. Bump it whenever the parser is fixed, so gubal parse-failures rerun knows to retry them.
+const ParserVersion = 1
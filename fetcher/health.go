@@ -0,0 +1,217 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	nsq "github.com/nsqio/go-nsq"
+)
+
+// DefaultStallTimeout is the default for Health.StallTimeout.
+const DefaultStallTimeout = 5 * time.Minute
+
+// DefaultPingTimeout is the default for Health.PingTimeout.
+const DefaultPingTimeout = time.Second
+
+// A Pinger is a database that can be pinged, eg. a *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// A ConsumerStater is an NSQ consumer that can report its connection state, eg. an *nsq.Consumer.
+type ConsumerStater interface {
+	Stats() *nsq.ConsumerStats
+}
+
+// Health tracks a fetcher's jobs and connections, and serves health checks on them:
+//
+//   - /healthz fails if the fetcher is wedged: jobs are in flight, but none has finished in
+//     StallTimeout. An orchestrator should restart it.
+//   - /readyz fails if the database doesn't respond to a ping, or a consumer isn't connected to
+//     any nsqd. An orchestrator should wait for it to recover, as a restart wouldn't help.
+//     A ping that times out waiting for a connection, eg. because SQLite's only one is in use by
+//     a job, just reports the database as busy; it doesn't fail the check.
+//
+// Both respond with a HealthStatus. A Health must not be copied after first use.
+type Health struct {
+	// StallTimeout is how long jobs may be in flight without any finishing; DefaultStallTimeout if 0.
+	StallTimeout time.Duration
+
+	// DB is pinged by readiness checks; nil if there's no database, eg. in a dry run.
+	DB Pinger
+
+	// PingTimeout is how long a ping may take before the database is reported as busy;
+	// DefaultPingTimeout if 0.
+	PingTimeout time.Duration
+
+	mu           sync.Mutex
+	consumers    map[string]ConsumerStater
+	inFlight     int
+	lastFinished time.Time // when a job last finished, or the first job started after an idle period
+	lastSuccess  time.Time
+	lastFailure  time.Time
+	now          func() time.Time
+}
+
+// HealthStatus is a snapshot of a fetcher's health.
+type HealthStatus struct {
+	OK          bool           `json:"ok"`
+	Errors      []string       `json:"errors,omitempty"`
+	Database    string         `json:"database,omitempty"` // only checked by /readyz
+	Consumers   map[string]int `json:"consumers"`
+	InFlight    int            `json:"in_flight"`
+	LastSuccess *time.Time     `json:"last_success,omitempty"`
+	LastFailure *time.Time     `json:"last_failure,omitempty"`
+}
+
+// AddConsumer registers a consumer to be checked for connections, under its topic.
+func (h *Health) AddConsumer(topic string, c ConsumerStater) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.consumers == nil {
+		h.consumers = make(map[string]ConsumerStater)
+	}
+	h.consumers[topic] = c
+}
+
+// StartJob records that a job has started; call the returned function with its result when it's done.
+func (h *Health) StartJob() func(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.inFlight == 0 {
+		// The stall timer only runs while there's work; an idle fetcher isn't a wedged one.
+		h.lastFinished = h.clock()
+	}
+	h.inFlight++
+	return func(err error) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.inFlight--
+		h.lastFinished = h.clock()
+		if err != nil {
+			h.lastFailure = h.lastFinished
+		} else {
+			h.lastSuccess = h.lastFinished
+		}
+	}
+}
+
+// Live returns the fetcher's status, OK unless it's wedged.
+func (h *Health) Live(ctx context.Context) HealthStatus {
+	st, stalled := h.status(ctx, false)
+	st.OK = !stalled
+	if stalled {
+		st.Errors = append(st.Errors, "no jobs have finished in "+h.stallTimeout().String())
+	}
+	return st
+}
+
+// Ready returns the fetcher's status, OK if the database and every consumer is connected.
+func (h *Health) Ready(ctx context.Context) HealthStatus {
+	st, _ := h.status(ctx, true)
+	st.OK = len(st.Errors) == 0
+	return st
+}
+
+// ServeHTTP serves /healthz and /readyz.
+func (h *Health) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	var st HealthStatus
+	switch req.URL.Path {
+	case "/healthz":
+		st = h.Live(req.Context())
+	case "/readyz":
+		st = h.Ready(req.Context())
+	default:
+		http.NotFound(rw, req)
+		return
+	}
+	status := http.StatusOK
+	if !st.OK {
+		status = http.StatusServiceUnavailable
+	}
+	rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+	rw.WriteHeader(status)
+	_ = json.NewEncoder(rw).Encode(st)
+}
+
+// status collects the fetcher's status, and whether it's stalled. If ping is true, the database is
+// pinged, and any unavailable dependencies are listed in the status' Errors.
+func (h *Health) status(ctx context.Context, ping bool) (HealthStatus, bool) {
+	h.mu.Lock()
+	st := HealthStatus{
+		Consumers: make(map[string]int, len(h.consumers)),
+		InFlight:  h.inFlight,
+	}
+	if !h.lastSuccess.IsZero() {
+		t := h.lastSuccess
+		st.LastSuccess = &t
+	}
+	if !h.lastFailure.IsZero() {
+		t := h.lastFailure
+		st.LastFailure = &t
+	}
+	stalled := h.inFlight > 0 && h.clock().Sub(h.lastFinished) > h.stallTimeout()
+	consumers := make(map[string]ConsumerStater, len(h.consumers))
+	for topic, c := range h.consumers {
+		consumers[topic] = c
+	}
+	h.mu.Unlock()
+
+	// Don't hold the lock while talking to anything, or a slow database would hold up every job.
+	topics := make([]string, 0, len(consumers))
+	for topic, c := range consumers {
+		st.Consumers[topic] = c.Stats().Connections
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	for _, topic := range topics {
+		if st.Consumers[topic] == 0 && ping {
+			st.Errors = append(st.Errors, "consumer not connected: "+topic)
+		}
+	}
+	switch {
+	case !ping:
+	case h.DB == nil:
+		st.Database = "none"
+	default:
+		pingCtx, cancel := context.WithTimeout(ctx, h.pingTimeout())
+		err := h.DB.PingContext(pingCtx)
+		timedOut := pingCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil
+		cancel()
+		switch {
+		case err == nil:
+			st.Database = "ok"
+		case timedOut:
+			st.Database = "busy"
+		default:
+			st.Database = "unavailable"
+			st.Errors = append(st.Errors, "database: "+err.Error())
+		}
+	}
+	return st, stalled
+}
+
+func (h *Health) pingTimeout() time.Duration {
+	if h.PingTimeout == 0 {
+		return DefaultPingTimeout
+	}
+	return h.PingTimeout
+}
+
+func (h *Health) stallTimeout() time.Duration {
+	if h.StallTimeout == 0 {
+		return DefaultStallTimeout
+	}
+	return h.StallTimeout
+}
+
+func (h *Health) clock() time.Time {
+	if h.now != nil {
+		return h.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,143 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	nsq "github.com/nsqio/go-nsq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPinger struct {
+	err   error
+	block bool // wait for the context to be done, like a pool with no free connections
+}
+
+func (p *testPinger) PingContext(ctx context.Context) error {
+	if p.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return p.err
+}
+
+type testConsumer struct{ conns int }
+
+func (c *testConsumer) Stats() *nsq.ConsumerStats { return &nsq.ConsumerStats{Connections: c.conns} }
+
+func TestHealthLive(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := &Health{StallTimeout: time.Minute, now: func() time.Time { return now }}
+
+	st := h.Live(context.Background())
+	assert.True(t, st.OK)
+	assert.Equal(t, 0, st.InFlight)
+	assert.Nil(t, st.LastSuccess)
+	assert.Empty(t, st.Database)
+
+	t.Run("Idle", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		assert.True(t, h.Live(context.Background()).OK)
+	})
+
+	t.Run("Busy", func(t *testing.T) {
+		done1 := h.StartJob()
+		done2 := h.StartJob()
+		now = now.Add(50 * time.Second)
+		done1(nil)
+		now = now.Add(50 * time.Second)
+		st := h.Live(context.Background())
+		assert.True(t, st.OK)
+		assert.Equal(t, 1, st.InFlight)
+		if assert.NotNil(t, st.LastSuccess) {
+			assert.Equal(t, now.Add(-50*time.Second), *st.LastSuccess)
+		}
+
+		now = now.Add(11 * time.Second)
+		st = h.Live(context.Background())
+		assert.False(t, st.OK)
+		assert.Equal(t, []string{"no jobs have finished in 1m0s"}, st.Errors)
+
+		done2(errors.New("oh no"))
+		st = h.Live(context.Background())
+		assert.True(t, st.OK)
+		assert.Equal(t, 0, st.InFlight)
+		if assert.NotNil(t, st.LastFailure) {
+			assert.Equal(t, now, *st.LastFailure)
+		}
+	})
+}
+
+func TestHealthReady(t *testing.T) {
+	db := &testPinger{}
+	cons := &testConsumer{}
+	h := &Health{DB: db}
+	h.AddConsumer(FetchTopic, cons)
+
+	st := h.Ready(context.Background())
+	assert.False(t, st.OK)
+	assert.Equal(t, "ok", st.Database)
+	assert.Equal(t, map[string]int{FetchTopic: 0}, st.Consumers)
+	assert.Equal(t, []string{"consumer not connected: " + FetchTopic}, st.Errors)
+
+	cons.conns = 1
+	st = h.Ready(context.Background())
+	assert.True(t, st.OK)
+	assert.Empty(t, st.Errors)
+
+	db.err = errors.New("connection refused")
+	st = h.Ready(context.Background())
+	assert.False(t, st.OK)
+	assert.Equal(t, "unavailable", st.Database)
+	assert.Equal(t, []string{"database: connection refused"}, st.Errors)
+
+	// A dead database doesn't make the fetcher wedged; restarting it won't help.
+	assert.True(t, h.Live(context.Background()).OK)
+
+	t.Run("Busy", func(t *testing.T) {
+		db := &testPinger{block: true}
+		h := &Health{DB: db, PingTimeout: 10 * time.Millisecond}
+		h.AddConsumer(FetchTopic, cons)
+		st := h.Ready(context.Background())
+		assert.True(t, st.OK)
+		assert.Equal(t, "busy", st.Database)
+		assert.Empty(t, st.Errors)
+	})
+
+	t.Run("No Database", func(t *testing.T) {
+		h := &Health{}
+		st := h.Ready(context.Background())
+		assert.True(t, st.OK)
+		assert.Equal(t, "none", st.Database)
+	})
+}
+
+func TestHealthHTTP(t *testing.T) {
+	db := &testPinger{err: errors.New("connection refused")}
+	h := &Health{DB: db}
+
+	for path, code := range map[string]int{
+		"/healthz": http.StatusOK,
+		"/readyz":  http.StatusServiceUnavailable,
+		"/nope":    http.StatusNotFound,
+	} {
+		t.Run(path, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, httptest.NewRequest("GET", path, nil))
+			assert.Equal(t, code, rw.Code)
+			if code == http.StatusNotFound {
+				return
+			}
+			assert.Equal(t, "application/json; charset=utf-8", rw.Header().Get("Content-Type"))
+			var st HealthStatus
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &st))
+			assert.Equal(t, code == http.StatusOK, st.OK)
+		})
+	}
+}
@@ -2,8 +2,17 @@ package fetcher
 
 import (
 	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/liclac/gubal/lib"
 )
 
+// tracer creates spans for jobs and the work they do.
+var tracer = otel.Tracer("github.com/liclac/gubal/fetcher")
+
 var jobIndex = make(map[string]func() Job)
 
 // A Job is a job enqueued to the Fetcher topic via a FetchMessage.
@@ -18,3 +27,12 @@ type Job interface {
 func registerJob(fn func() Job) {
 	jobIndex[fn().Type()] = fn
 }
+
+// RunJob runs a job in a span.
+func RunJob(ctx context.Context, job Job) ([]Job, error) {
+	ctx, span := tracer.Start(ctx, "Job.Run", trace.WithAttributes(attribute.String("job.type", job.Type())))
+	jobs, err := job.Run(ctx)
+	span.SetAttributes(attribute.Int("job.follow_ups", len(jobs)))
+	lib.EndSpan(span, err)
+	return jobs, err
+}
@@ -0,0 +1,97 @@
+package fetcher
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/liclac/gubal/models"
+)
+
+var (
+	testSpansOnce     sync.Once
+	testSpansRecorder *tracetest.SpanRecorder
+)
+
+// testSpans installs a global tracer provider recording every span, and returns a context with a
+// new root span in it, and a function returning the spans ended under it so far.
+func testSpans(t *testing.T) (context.Context, func() []sdktrace.ReadOnlySpan) {
+	testSpansOnce.Do(func() {
+		testSpansRecorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(testSpansRecorder)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+	ctx, span := otel.Tracer("test").Start(context.Background(), t.Name())
+	t.Cleanup(func() { span.End() })
+	traceID := span.SpanContext().TraceID()
+	return ctx, func() []sdktrace.ReadOnlySpan {
+		var spans []sdktrace.ReadOnlySpan
+		for _, s := range testSpansRecorder.Ended() {
+			if s.SpanContext().TraceID() == traceID {
+				spans = append(spans, s)
+			}
+		}
+		return spans
+	}
+}
+
+func TestRunJobSpans(t *testing.T) {
+	testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		io.WriteString(rw, testHTMLEmiHawke)
+	}))
+	realLodestoneBaseURL := LodestoneBaseURL
+	LodestoneBaseURL = testsrv.URL
+	defer func() {
+		testsrv.Close()
+		LodestoneBaseURL = realLodestoneBaseURL
+	}()
+	u, err := url.Parse(testsrv.URL)
+	require.NoError(t, err)
+
+	ctx, spans := testSpans(t)
+	ctx = models.WithDataStore(ctx, models.NewTracedDataStore(models.NewMemoryDataStore()))
+	jobs, err := RunJob(ctx, FetchCharacterJob{ID: 7248246})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 0)
+
+	// Every span should be a child of the job's, which is the last to end.
+	recorded := spans()
+	require.NotEmpty(t, recorded)
+	job := recorded[len(recorded)-1]
+	assert.Equal(t, "Job.Run", job.Name())
+	assert.Equal(t, trace.SpanContextFromContext(ctx).SpanID(), job.Parent().SpanID())
+
+	byName := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range recorded[:len(recorded)-1] {
+		byName[s.Name()] = s
+	}
+	for _, name := range []string{
+		"CharacterTombstoneStore.Check",
+		"GET " + u.Host,
+		"parse name",
+		"parse title",
+		"parse world",
+		"parse blocks",
+		"parse jobs",
+		"CharacterStore.Save",
+		"CharacterTitleStore.GetOrCreate",
+		"LevelStore.SetAll",
+		"ParseFailureStore.Delete",
+	} {
+		if assert.Contains(t, byName, name) {
+			assert.Equal(t, job.SpanContext().SpanID(), byName[name].Parent().SpanID(), name)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	_ "embed" // for defaultLayoutsYAML
+	"io"
+	"io/ioutil"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v2"
+)
+
+const ctxKeyLayouts ctxKey = "layouts"
+
+//go:embed layouts.yaml
+var defaultLayoutsYAML []byte
+
+// DefaultLayouts are the layouts built into the binary, from layouts.yaml.
+var DefaultLayouts = mustLoadLayouts(bytes.NewReader(defaultLayoutsYAML))
+
+// A Layout describes where on a character page the parser finds everything. The Lodestone changes
+// its markup every now and then, usually after a patch; describing it here rather than in code
+// lets a new layout be added without a rebuild, and lets pages in old and new ones coexist.
+type Layout struct {
+	// Version identifies the layout, eg. in logs and traces.
+	Version int `yaml:"version"`
+
+	// Detect is a selector that only matches pages in this layout. If blank, any page matches.
+	Detect string `yaml:"detect"`
+
+	Selectors LayoutSelectors `yaml:"selectors"`
+
+	// Blocks are the names of profile blocks in each language, as they're localized.
+	Blocks map[Language]LayoutBlocks `yaml:"blocks"`
+}
+
+// LayoutSelectors are the CSS selectors for the parts of a character page the parser reads.
+type LayoutSelectors struct {
+	Name  string `yaml:"name"`
+	Title string `yaml:"title"`
+	World string `yaml:"world"`
+
+	// Profile blocks, each with a name (eg. "Nameday") and a profile (eg. "Oschon, the Wanderer").
+	Block        string `yaml:"block"`
+	BlockName    string `yaml:"block_name"`
+	BlockProfile string `yaml:"block_profile"`
+
+	// Jobs, each with a level and name; relative to Job. If JobLevel is blank, the level is Job's
+	// own text; if JobNameAttr is set, the name is read from that attribute of JobName instead of
+	// its text, eg. a tooltip, and anything after a slash in it is ignored.
+	Job         string `yaml:"job"`
+	JobLevel    string `yaml:"job_level"`
+	JobName     string `yaml:"job_name"`
+	JobNameAttr string `yaml:"job_name_attr"`
+}
+
+// LayoutBlocks are the names of the profile blocks the parser understands.
+type LayoutBlocks struct {
+	RaceClanGender string `yaml:"race_clan_gender"`
+	Nameday        string `yaml:"nameday"`
+	CityState      string `yaml:"city_state"`
+	GrandCompany   string `yaml:"grand_company"`
+}
+
+// Validate returns an error for every required selector or block name that's blank, or unknown
+// language.
+func (l Layout) Validate() error {
+	type field struct{ name, value string }
+	var errs []error
+	check := func(fields ...field) {
+		for _, f := range fields {
+			if f.value == "" {
+				errs = append(errs, errors.Errorf("layout %d: %s is blank", l.Version, f.name))
+			}
+		}
+	}
+	check(
+		field{"selectors.name", l.Selectors.Name},
+		field{"selectors.title", l.Selectors.Title},
+		field{"selectors.world", l.Selectors.World},
+		field{"selectors.block", l.Selectors.Block},
+		field{"selectors.block_name", l.Selectors.BlockName},
+		field{"selectors.block_profile", l.Selectors.BlockProfile},
+		field{"selectors.job", l.Selectors.Job},
+		field{"selectors.job_name", l.Selectors.JobName},
+	)
+	if len(l.Blocks) == 0 {
+		errs = append(errs, errors.Errorf("layout %d: no blocks", l.Version))
+	}
+	for _, lang := range Languages {
+		if b, ok := l.Blocks[lang]; ok {
+			check(
+				field{"blocks." + string(lang) + ".race_clan_gender", b.RaceClanGender},
+				field{"blocks." + string(lang) + ".nameday", b.Nameday},
+				field{"blocks." + string(lang) + ".city_state", b.CityState},
+				field{"blocks." + string(lang) + ".grand_company", b.GrandCompany},
+			)
+		}
+	}
+	for lang := range l.Blocks {
+		if !lang.Valid() {
+			errs = append(errs, errors.Errorf("layout %d: unknown language: %s", l.Version, lang))
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+// Layouts is a list of layouts, in the order they're tried in.
+type Layouts []*Layout
+
+// LoadLayouts reads layouts from YAML, in the format of layouts.yaml.
+func LoadLayouts(r io.Reader) (Layouts, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Layouts Layouts `yaml:"layouts"`
+	}
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, err
+	}
+	if len(file.Layouts) == 0 {
+		return nil, errors.New("no layouts defined")
+	}
+	var errs []error
+	for _, l := range file.Layouts {
+		errs = append(errs, l.Validate())
+	}
+	if err := multierr.Combine(errs...); err != nil {
+		return nil, err
+	}
+	return file.Layouts, nil
+}
+
+func mustLoadLayouts(r io.Reader) Layouts {
+	ls, err := LoadLayouts(r)
+	if err != nil {
+		panic(err)
+	}
+	return ls
+}
+
+// Detect returns the first layout that matches the page, or nil if none do.
+func (ls Layouts) Detect(doc *goquery.Document) *Layout {
+	for _, l := range ls {
+		if l.Detect == "" || doc.Find(l.Detect).Length() > 0 {
+			return l
+		}
+	}
+	return nil
+}
+
+// WithLayouts associates layouts for parsing pages with the given context.
+func WithLayouts(ctx context.Context, ls Layouts) context.Context {
+	return context.WithValue(ctx, ctxKeyLayouts, ls)
+}
+
+// GetLayouts returns the context's associated layouts, or DefaultLayouts if there are none.
+func GetLayouts(ctx context.Context) Layouts {
+	if ls, ok := ctx.Value(ctxKeyLayouts).(Layouts); ok {
+		return ls
+	}
+	return DefaultLayouts
+}
@@ -0,0 +1,152 @@
+package fetcher
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liclac/gubal/models"
+)
+
+func TestDefaultLayouts(t *testing.T) {
+	require.Len(t, DefaultLayouts, 2)
+	desktop, mobile := DefaultLayouts[0], DefaultLayouts[1]
+	assert.Equal(t, 2, desktop.Version)
+	assert.Equal(t, ".character__level__list img[data-tooltip]", desktop.Detect)
+	assert.Equal(t, "data-tooltip", desktop.Selectors.JobNameAttr)
+	assert.Equal(t, 1, mobile.Version)
+	assert.Equal(t, "", mobile.Detect)
+	assert.Equal(t, ".frame__chara__name", mobile.Selectors.Name)
+	assert.Equal(t, "ul.character__job li", mobile.Selectors.Job)
+	for _, l := range DefaultLayouts {
+		for _, lang := range Languages {
+			assert.Contains(t, l.Blocks, lang)
+		}
+		assert.Equal(t, "Race/Clan/Gender", l.Blocks[LanguageEN].RaceClanGender)
+		assert.NoError(t, l.Validate())
+	}
+
+	assert.Equal(t, DefaultLayouts, GetLayouts(context.Background()))
+}
+
+func TestLoadLayouts(t *testing.T) {
+	t.Run("Blank", func(t *testing.T) {
+		_, err := LoadLayouts(strings.NewReader(`
+layouts:
+  - version: 2
+    selectors:
+      name: .name
+`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "layout 2: selectors.title is blank")
+		assert.Contains(t, err.Error(), "layout 2: no blocks")
+		assert.NotContains(t, err.Error(), "selectors.name")
+	})
+	t.Run("Blocks", func(t *testing.T) {
+		_, err := LoadLayouts(strings.NewReader(`
+layouts:
+  - version: 2
+    selectors: {name: a, title: a, world: a, block: a, block_name: a, block_profile: a, job: a, job_level: a, job_name: a}
+    blocks:
+      ja: {race_clan_gender: a, nameday: a, city_state: a}
+      xx: {race_clan_gender: a, nameday: a, city_state: a, grand_company: a}
+`))
+		assert.EqualError(t, err, "layout 2: blocks.ja.grand_company is blank; layout 2: unknown language: xx")
+	})
+	t.Run("Unknown Field", func(t *testing.T) {
+		_, err := LoadLayouts(strings.NewReader(`
+layouts:
+  - version: 2
+    selector: {}
+`))
+		assert.Error(t, err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := LoadLayouts(strings.NewReader(`layouts: []`))
+		assert.EqualError(t, err, "no layouts defined")
+	})
+}
+
+// testLayoutsRenamed has a layout for a version of testHTMLEmiHawke where everything's been
+// renamed, ahead of the default mobile one.
+func testLayoutsRenamed() Layouts {
+	mobile := DefaultLayouts[len(DefaultLayouts)-1]
+	renamed := *mobile
+	renamed.Version = 3
+	renamed.Detect = ".chara__name"
+	renamed.Selectors.Name = ".chara__name"
+	renamed.Selectors.World = ".chara__world"
+	renamed.Selectors.Job = "ul.chara__jobs li"
+	en := renamed.Blocks[LanguageEN]
+	en.CityState = "Home City"
+	renamed.Blocks = map[Language]LayoutBlocks{LanguageEN: en}
+	return Layouts{&renamed, mobile}
+}
+
+func TestLayoutsDetect(t *testing.T) {
+	ls := testLayoutsRenamed()
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(testHTMLEmiHawke))
+	require.NoError(t, err)
+	assert.Equal(t, 1, ls.Detect(doc).Version)
+	assert.Nil(t, ls[:1].Detect(doc))
+
+	doc, err = goquery.NewDocumentFromReader(strings.NewReader(`<p class="chara__name">Emi Hawke</p>`))
+	require.NoError(t, err)
+	assert.Equal(t, 3, ls.Detect(doc).Version)
+
+	doc, err = goquery.NewDocumentFromReader(strings.NewReader(testHTMLEmiHawkeDesktop))
+	require.NoError(t, err)
+	assert.Equal(t, 2, DefaultLayouts.Detect(doc).Version)
+	assert.Equal(t, 1, ls.Detect(doc).Version)
+}
+
+func TestFetchCharacterJobLayout(t *testing.T) {
+	html := strings.NewReplacer(
+		`class="frame__chara__name"`, `class="chara__name"`,
+		`class="frame__chara__world"`, `class="chara__world"`,
+		`<ul class="character__job clearfix">`, `<ul class="chara__jobs clearfix">`,
+		`>City-state<`, `>Home City<`,
+	).Replace(testHTMLEmiHawke)
+	testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		io.WriteString(rw, html)
+	}))
+	realLodestoneBaseURL := LodestoneBaseURL
+	LodestoneBaseURL = testsrv.URL
+	defer func() {
+		testsrv.Close()
+		LodestoneBaseURL = realLodestoneBaseURL
+	}()
+
+	ds := models.NewMemoryDataStore()
+	ctx := models.WithDataStore(context.Background(), ds)
+
+	// The default layout can't make heads or tails of it...
+	id := int64(7248246)
+	_, err := FetchCharacterJob{ID: id}.Run(ctx)
+	require.NoError(t, err)
+	f, err := ds.ParseFailures().Get(ctx, id)
+	require.NoError(t, err)
+	assert.Contains(t, f.Errors, `malformed name: "" (1 components)`)
+
+	// ...but one that describes it can.
+	ctx = WithLayouts(ctx, testLayoutsRenamed())
+	_, err = FetchCharacterJob{ID: id}.Run(ctx)
+	require.NoError(t, err)
+
+	ch, err := ds.Characters().Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Emi", ch.FirstName)
+	assert.Equal(t, models.Ultros, ch.World)
+	assert.Equal(t, models.Gridania, ch.CityState)
+	lvl, err := ds.Levels().Get(ctx, id, models.SCH)
+	require.NoError(t, err)
+	assert.Equal(t, 70, lvl.Level)
+}
@@ -0,0 +1,110 @@
+package fetcher
+
+import (
+	"strings"
+
+	"github.com/liclac/gubal/models"
+)
+
+// A Language is a language the Lodestone's pages can be in.
+type Language string
+
+// Language constants.
+const (
+	LanguageEN Language = "en"
+	LanguageJA Language = "ja"
+	LanguageFR Language = "fr"
+	LanguageDE Language = "de"
+)
+
+// Languages lists every known Language.
+var Languages = []Language{
+	LanguageEN,
+	LanguageJA,
+	LanguageFR,
+	LanguageDE,
+}
+
+// Valid returns whether this is a known Language.
+func (l Language) Valid() bool {
+	for _, known := range Languages {
+		if l == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Locale returns the strings pages in the language use.
+func (l Language) Locale() *Locale {
+	return locales[l]
+}
+
+var locales = map[Language]*Locale{
+	LanguageEN: localeEN,
+	LanguageJA: localeJA,
+	LanguageFR: localeFR,
+	LanguageDE: localeDE,
+}
+
+// A Locale holds the strings a language's pages use for everything the parser reads, other than
+// the names of profile blocks, which are part of the Layout. World names aren't localized.
+type Locale struct {
+	// RaceClans maps a race and clan, as concatenated on the page (eg. "Au RaRaen"), to the clan.
+	// German and French pages may use a different name for each gender; both should be listed.
+	RaceClans map[string]models.CharacterClan
+
+	// Guardians maps the start of a guardian's name and epithet (eg. "Oschon, the Wanderer").
+	Guardians map[string]models.CharacterGuardian
+
+	CityStates     map[string]models.CityState
+	GrandCompanies map[string]models.GrandCompany
+
+	// GCRanks lists each grand company's ranks, lowest first; unlisted ranks are parsed as 0.
+	GCRanks map[models.GrandCompany][]string
+
+	// Jobs maps jobs and the classes they're based on to the job; eg. "Paladin" and "Gladiator".
+	Jobs map[string]models.Job
+}
+
+// clanRaces maps each clan to its race.
+var clanRaces = map[models.CharacterClan]models.CharacterRace{
+	models.HyurMidlander:      models.Hyur,
+	models.HyurHighlander:     models.Hyur,
+	models.ElezenWildwood:     models.Elezen,
+	models.ElezenDuskwight:    models.Elezen,
+	models.LalafellPlainsfolk: models.Lalafell,
+	models.LalafellDunesfolk:  models.Lalafell,
+	models.MiqoteSunSeeker:    models.Miqote,
+	models.MiqoteMoonKeeper:   models.Miqote,
+	models.RoegadynSeaWolf:    models.Roegadyn,
+	models.RoegadynHellsguard: models.Roegadyn,
+	models.AuRaRaen:           models.AuRa,
+	models.AuRaXaela:          models.AuRa,
+}
+
+// RaceClan looks up a race and clan, as concatenated on the page.
+func (l *Locale) RaceClan(s string) (models.CharacterRace, models.CharacterClan, bool) {
+	clan, ok := l.RaceClans[s]
+	return clanRaces[clan], clan, ok
+}
+
+// Guardian looks up a guardian from the start of its name and epithet.
+func (l *Locale) Guardian(s string) (models.CharacterGuardian, bool) {
+	for prefix, g := range l.Guardians {
+		if strings.HasPrefix(s, prefix) {
+			return g, true
+		}
+	}
+	return "", false
+}
+
+// GCRank looks up a rank in the given grand company; 0 if it's unknown.
+func (l *Locale) GCRank(gc models.GrandCompany, s string) int {
+	for i, name := range l.GCRanks[gc] {
+		if s == name {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,108 @@
+package fetcher
+
+import (
+	"github.com/liclac/gubal/models"
+)
+
+// localeDE is used by the DE Lodestone. Grand company ranks aren't listed yet, so they're parsed
+// as 0; masculine and feminine forms of clans and classes are both listed.
+var localeDE = &Locale{
+	RaceClans: map[string]models.CharacterClan{
+		"HyuranWiesländer":      models.HyurMidlander,
+		"HyuranWiesländerin":    models.HyurMidlander,
+		"HyuranHochländer":      models.HyurHighlander,
+		"HyuranHochländerin":    models.HyurHighlander,
+		"ElezenErlschatten":     models.ElezenWildwood,
+		"ElezenDunkelalb":       models.ElezenDuskwight,
+		"ElezenDunkelalbin":     models.ElezenDuskwight,
+		"LalafellHalmling":      models.LalafellPlainsfolk,
+		"LalafellSandling":      models.LalafellDunesfolk,
+		"Miqo'teGoldtatze":      models.MiqoteSunSeeker,
+		"Miqo'teMondstreuner":   models.MiqoteMoonKeeper,
+		"Miqo'teMondstreunerin": models.MiqoteMoonKeeper,
+		"RoegadynSeewolf":       models.RoegadynSeaWolf,
+		"RoegadynSeewölfin":     models.RoegadynSeaWolf,
+		"RoegadynLohengarde":    models.RoegadynHellsguard,
+		"Au RaRaen":             models.AuRaRaen,
+		"Au RaXaela":            models.AuRaXaela,
+	},
+	Guardians: guardiansLatin,
+	CityStates: map[string]models.CityState{
+		"Gridania":      models.Gridania,
+		"Ul'dah":        models.Uldah,
+		"Limsa Lominsa": models.Limsa,
+	},
+	GrandCompanies: map[string]models.GrandCompany{
+		"Mahlstrom":                    models.Maelstrom,
+		"Bruderschaft der Morgenviper": models.Adders,
+		"Legion der Unsterblichen":     models.Flames,
+	},
+	Jobs: map[string]models.Job{
+		"Paladin":         models.PLD,
+		"Paladinin":       models.PLD,
+		"Gladiator":       models.PLD,
+		"Gladiatorin":     models.PLD,
+		"Krieger":         models.WAR,
+		"Kriegerin":       models.WAR,
+		"Marodeur":        models.WAR,
+		"Marodeurin":      models.WAR,
+		"Dunkelritter":    models.DRK,
+		"Dunkelritterin":  models.DRK,
+		"Weißmagier":      models.WHM,
+		"Weißmagierin":    models.WHM,
+		"Druide":          models.WHM,
+		"Druidin":         models.WHM,
+		"Gelehrter":       models.SCH,
+		"Gelehrte":        models.SCH,
+		"Astrologe":       models.AST,
+		"Astrologin":      models.AST,
+		"Mönch":           models.MNK,
+		"Mönchin":         models.MNK,
+		"Faustkämpfer":    models.MNK,
+		"Faustkämpferin":  models.MNK,
+		"Dragoon":         models.DRG,
+		"Pikenier":        models.DRG,
+		"Pikenierin":      models.DRG,
+		"Ninja":           models.NIN,
+		"Schurke":         models.NIN,
+		"Schurkin":        models.NIN,
+		"Samurai":         models.SAM,
+		"Barde":           models.BRD,
+		"Bardin":          models.BRD,
+		"Waldläufer":      models.BRD,
+		"Waldläuferin":    models.BRD,
+		"Maschinist":      models.MCH,
+		"Maschinistin":    models.MCH,
+		"Schwarzmagier":   models.BLM,
+		"Schwarzmagierin": models.BLM,
+		"Thaumaturg":      models.BLM,
+		"Thaumaturgin":    models.BLM,
+		"Beschwörer":      models.SMN,
+		"Beschwörerin":    models.SMN,
+		"Hermetiker":      models.SMN,
+		"Hermetikerin":    models.SMN,
+		"Rotmagier":       models.RDM,
+		"Rotmagierin":     models.RDM,
+		"Zimmerer":        models.CRP,
+		"Zimmerin":        models.CRP,
+		"Grobschmied":     models.BSM,
+		"Grobschmiedin":   models.BSM,
+		"Plattner":        models.ARM,
+		"Plattnerin":      models.ARM,
+		"Goldschmied":     models.GSM,
+		"Goldschmiedin":   models.GSM,
+		"Gerber":          models.LTW,
+		"Gerberin":        models.LTW,
+		"Weber":           models.WVR,
+		"Weberin":         models.WVR,
+		"Alchemist":       models.ALC,
+		"Alchemistin":     models.ALC,
+		"Gourmet":         models.CUL,
+		"Minenarbeiter":   models.MIN,
+		"Minenarbeiterin": models.MIN,
+		"Gärtner":         models.BOT,
+		"Gärtnerin":       models.BOT,
+		"Fischer":         models.FSH,
+		"Fischerin":       models.FSH,
+	},
+}
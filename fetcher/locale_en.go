@@ -0,0 +1,110 @@
+package fetcher
+
+import (
+	"github.com/liclac/gubal/models"
+)
+
+// localeEN is used by the NA and EU Lodestones.
+var localeEN = &Locale{
+	RaceClans: map[string]models.CharacterClan{
+		"HyurMidlander":             models.HyurMidlander,
+		"HyurHighlander":            models.HyurHighlander,
+		"ElezenWildwood":            models.ElezenWildwood,
+		"ElezenDuskwight":           models.ElezenDuskwight,
+		"LalafellPlainsfolk":        models.LalafellPlainsfolk,
+		"LalafellDunesfolk":         models.LalafellDunesfolk,
+		"Miqo'teSeeker of the Sun":  models.MiqoteSunSeeker,
+		"Miqo'teKeeper of the Moon": models.MiqoteMoonKeeper,
+		"RoegadynSea Wolf":          models.RoegadynSeaWolf,
+		"RoegadynHellsguard":        models.RoegadynHellsguard,
+		"Au RaRaen":                 models.AuRaRaen,
+		"Au RaXaela":                models.AuRaXaela,
+	},
+	Guardians: guardiansLatin,
+	CityStates: map[string]models.CityState{
+		"Gridania":      models.Gridania,
+		"Ul'dah":        models.Uldah,
+		"Limsa Lominsa": models.Limsa,
+	},
+	GrandCompanies: map[string]models.GrandCompany{
+		"Maelstrom":               models.Maelstrom,
+		"Order of the Twin Adder": models.Adders,
+		"Immortal Flames":         models.Flames,
+	},
+	GCRanks: map[models.GrandCompany][]string{
+		models.Maelstrom: {
+			"Storm Private Third Class", "Storm Private Second Class", "Storm Private First Class",
+			"Storm Corporal",
+			"Storm Sergeant Third Class", "Storm Sergeant Second Class", "Storm Sergeant First Class",
+			"Chief Storm Sergeant",
+			"Second Storm Lieutenant", "First Storm Lieutenant",
+		},
+		models.Adders: {
+			"Serpent Private Third Class", "Serpent Private Second Class", "Serpent Private First Class",
+			"Serpent Corporal",
+			"Serpent Sergeant Third Class", "Serpent Sergeant Second Class", "Serpent Sergeant First Class",
+			"Chief Serpent Sergeant",
+			"Second Serpent Lieutenant", "First Serpent Lieutenant",
+		},
+		models.Flames: {
+			"Flame Private Third Class", "Flame Private Second Class", "Flame Private First Class",
+			"Flame Corporal",
+			"Flame Sergeant Third Class", "Flame Sergeant Second Class", "Flame Sergeant First Class",
+			"Chief Flame Sergeant",
+			"Second Flame Lieutenant", "First Flame Lieutenant",
+		},
+	},
+	Jobs: map[string]models.Job{
+		"Paladin":       models.PLD,
+		"Gladiator":     models.PLD,
+		"Warrior":       models.WAR,
+		"Marauder":      models.WAR,
+		"Dark Knight":   models.DRK,
+		"White Mage":    models.WHM,
+		"Conjurer":      models.WHM,
+		"Scholar":       models.SCH,
+		"Astrologian":   models.AST,
+		"Monk":          models.MNK,
+		"Pugilist":      models.MNK,
+		"Dragoon":       models.DRG,
+		"Lancer":        models.DRG,
+		"Ninja":         models.NIN,
+		"Rogue":         models.NIN,
+		"Samurai":       models.SAM,
+		"Bard":          models.BRD,
+		"Archer":        models.BRD,
+		"Machinist":     models.MCH,
+		"Black Mage":    models.BLM,
+		"Thaumaturge":   models.BLM,
+		"Summoner":      models.SMN,
+		"Arcanist":      models.SMN,
+		"Red Mage":      models.RDM,
+		"Carpenter":     models.CRP,
+		"Blacksmith":    models.BSM,
+		"Armorer":       models.ARM,
+		"Goldsmith":     models.GSM,
+		"Leatherworker": models.LTW,
+		"Weaver":        models.WVR,
+		"Alchemist":     models.ALC,
+		"Culinarian":    models.CUL,
+		"Miner":         models.MIN,
+		"Botanist":      models.BOT,
+		"Fisher":        models.FSH,
+	},
+}
+
+// guardiansLatin are the guardians' names in English, French and German, which are the same.
+var guardiansLatin = map[string]models.CharacterGuardian{
+	"Halone":    models.Halone,
+	"Menphina":  models.Menphina,
+	"Thaliak":   models.Thaliak,
+	"Nymeia":    models.Nymeia,
+	"Llymlaen":  models.Llymlaen,
+	"Oschon":    models.Oschon,
+	"Byregot":   models.Byregot,
+	"Rhalgr":    models.Rhalgr,
+	"Azeyma":    models.Azeyma,
+	"Nald'thal": models.Naldthal,
+	"Nophica":   models.Nophica,
+	"Althyk":    models.Althyk,
+}
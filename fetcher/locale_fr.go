@@ -0,0 +1,95 @@
+package fetcher
+
+import (
+	"github.com/liclac/gubal/models"
+)
+
+// localeFR is used by the FR Lodestone. Grand company ranks aren't listed yet, so they're parsed
+// as 0; masculine and feminine forms of clans and classes are both listed.
+var localeFR = &Locale{
+	RaceClans: map[string]models.CharacterClan{
+		"HyurHyurois":                models.HyurMidlander,
+		"HyurHyuroise":               models.HyurMidlander,
+		"HyurHyurgoth":               models.HyurHighlander,
+		"HyurHyurgothe":              models.HyurHighlander,
+		"ÉlézenSylvestre":            models.ElezenWildwood,
+		"ÉlézenCrépusculaire":        models.ElezenDuskwight,
+		"LalafellPeuple des Plaines": models.LalafellPlainsfolk,
+		"LalafellPeuple des Dunes":   models.LalafellDunesfolk,
+		"Miqo'teTribu du Soleil":     models.MiqoteSunSeeker,
+		"Miqo'teTribu de la Lune":    models.MiqoteMoonKeeper,
+		"RoegadynClan de la Mer":     models.RoegadynSeaWolf,
+		"RoegadynClan du Feu":        models.RoegadynHellsguard,
+		"Ao RaRaen":                  models.AuRaRaen,
+		"Ao RaXaela":                 models.AuRaXaela,
+	},
+	Guardians: guardiansLatin,
+	CityStates: map[string]models.CityState{
+		"Gridania":      models.Gridania,
+		"Ul'dah":        models.Uldah,
+		"Limsa Lominsa": models.Limsa,
+	},
+	GrandCompanies: map[string]models.GrandCompany{
+		"Le Maelstrom":             models.Maelstrom,
+		"L'ordre des Deux Vipères": models.Adders,
+		"Les Immortels":            models.Flames,
+	},
+	Jobs: map[string]models.Job{
+		"Paladin":           models.PLD,
+		"Gladiateur":        models.PLD,
+		"Gladiatrice":       models.PLD,
+		"Guerrier":          models.WAR,
+		"Guerrière":         models.WAR,
+		"Maraudeur":         models.WAR,
+		"Maraudeuse":        models.WAR,
+		"Chevalier noir":    models.DRK,
+		"Chevalière noire":  models.DRK,
+		"Mage blanc":        models.WHM,
+		"Mage blanche":      models.WHM,
+		"Élémentaliste":     models.WHM,
+		"Érudit":            models.SCH,
+		"Érudite":           models.SCH,
+		"Astromancien":      models.AST,
+		"Astromancienne":    models.AST,
+		"Moine":             models.MNK,
+		"Pugiliste":         models.MNK,
+		"Chevalier dragon":  models.DRG,
+		"Chevalière dragon": models.DRG,
+		"Maître d'hast":     models.DRG,
+		"Maîtresse d'hast":  models.DRG,
+		"Ninja":             models.NIN,
+		"Surineur":          models.NIN,
+		"Surineuse":         models.NIN,
+		"Samouraï":          models.SAM,
+		"Barde":             models.BRD,
+		"Archer":            models.BRD,
+		"Archère":           models.BRD,
+		"Machiniste":        models.MCH,
+		"Mage noir":         models.BLM,
+		"Mage noire":        models.BLM,
+		"Occultiste":        models.BLM,
+		"Invocateur":        models.SMN,
+		"Invocatrice":       models.SMN,
+		"Arcaniste":         models.SMN,
+		"Mage rouge":        models.RDM,
+		"Menuisier":         models.CRP,
+		"Menuisière":        models.CRP,
+		"Forgeron":          models.BSM,
+		"Forgeronne":        models.BSM,
+		"Armurier":          models.ARM,
+		"Armurière":         models.ARM,
+		"Orfèvre":           models.GSM,
+		"Tanneur":           models.LTW,
+		"Tanneuse":          models.LTW,
+		"Couturier":         models.WVR,
+		"Couturière":        models.WVR,
+		"Alchimiste":        models.ALC,
+		"Cuisinier":         models.CUL,
+		"Cuisinière":        models.CUL,
+		"Mineur":            models.MIN,
+		"Mineuse":           models.MIN,
+		"Botaniste":         models.BOT,
+		"Pêcheur":           models.FSH,
+		"Pêcheuse":          models.FSH,
+	},
+}
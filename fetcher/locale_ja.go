@@ -0,0 +1,95 @@
+package fetcher
+
+import (
+	"github.com/liclac/gubal/models"
+)
+
+// localeJA is used by the JP Lodestone.
+var localeJA = &Locale{
+	RaceClans: map[string]models.CharacterClan{
+		"ヒューランミッドランダー":  models.HyurMidlander,
+		"ヒューランハイランダー":   models.HyurHighlander,
+		"エレゼンフォレスター":    models.ElezenWildwood,
+		"エレゼンシェーダー":     models.ElezenDuskwight,
+		"ララフェルプレーンフォーク": models.LalafellPlainsfolk,
+		"ララフェルデューンフォーク": models.LalafellDunesfolk,
+		"ミコッテサンシーカー":    models.MiqoteSunSeeker,
+		"ミコッテムーンキーパー":   models.MiqoteMoonKeeper,
+		"ルガディンゼーヴォルフ":   models.RoegadynSeaWolf,
+		"ルガディンローエンガルデ":  models.RoegadynHellsguard,
+		"アウラレン":         models.AuRaRaen,
+		"アウラゼラ":         models.AuRaXaela,
+	},
+	Guardians: map[string]models.CharacterGuardian{
+		"ハルオーネ": models.Halone,
+		"メネフィナ": models.Menphina,
+		"サリャク":  models.Thaliak,
+		"ニメーヤ":  models.Nymeia,
+		"リムレーン": models.Llymlaen,
+		"オシュオン": models.Oschon,
+		"ビエルゴ":  models.Byregot,
+		"ラールガー": models.Rhalgr,
+		"アーゼマ":  models.Azeyma,
+		"ナルザル":  models.Naldthal,
+		"ノフィカ":  models.Nophica,
+		"アルジク":  models.Althyk,
+	},
+	CityStates: map[string]models.CityState{
+		"グリダニア":    models.Gridania,
+		"ウルダハ":     models.Uldah,
+		"リムサ・ロミンサ": models.Limsa,
+	},
+	GrandCompanies: map[string]models.GrandCompany{
+		"黒渦団": models.Maelstrom,
+		"双蛇党": models.Adders,
+		"不滅隊": models.Flames,
+	},
+	GCRanks: map[models.GrandCompany][]string{
+		models.Maelstrom: {
+			"二等甲兵", "一等甲兵", "上等甲兵", "甲兵長", "三等甲曹", "二等甲曹", "一等甲曹", "甲曹長", "少甲尉", "中甲尉",
+		},
+		models.Adders: {
+			"二等双蛇兵", "一等双蛇兵", "上等双蛇兵", "双蛇兵長", "三等双蛇曹", "二等双蛇曹", "一等双蛇曹", "双蛇曹長", "少双蛇尉", "中双蛇尉",
+		},
+		models.Flames: {
+			"二等闘兵", "一等闘兵", "上等闘兵", "闘兵長", "三等闘曹", "二等闘曹", "一等闘曹", "闘曹長", "少闘尉", "中闘尉",
+		},
+	},
+	Jobs: map[string]models.Job{
+		"ナイト":  models.PLD,
+		"剣術士":  models.PLD,
+		"戦士":   models.WAR,
+		"斧術士":  models.WAR,
+		"暗黒騎士": models.DRK,
+		"白魔道士": models.WHM,
+		"幻術士":  models.WHM,
+		"学者":   models.SCH,
+		"占星術師": models.AST,
+		"モンク":  models.MNK,
+		"格闘士":  models.MNK,
+		"竜騎士":  models.DRG,
+		"槍術士":  models.DRG,
+		"忍者":   models.NIN,
+		"双剣士":  models.NIN,
+		"侍":    models.SAM,
+		"吟遊詩人": models.BRD,
+		"弓術士":  models.BRD,
+		"機工士":  models.MCH,
+		"黒魔道士": models.BLM,
+		"呪術士":  models.BLM,
+		"召喚士":  models.SMN,
+		"巴術士":  models.SMN,
+		"赤魔道士": models.RDM,
+		"木工師":  models.CRP,
+		"鍛冶師":  models.BSM,
+		"甲冑師":  models.ARM,
+		"彫金師":  models.GSM,
+		"革細工師": models.LTW,
+		"裁縫師":  models.WVR,
+		"錬金術師": models.ALC,
+		"調理師":  models.CUL,
+		"採掘師":  models.MIN,
+		"園芸師":  models.BOT,
+		"漁師":   models.FSH,
+	},
+}
@@ -1,18 +1,48 @@
 package fetcher
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // FetchTopic is the NSQ topic to which to publish FetchMessage messages.
 const FetchTopic = "fetch"
 
+// FetchPriorityTopic is a topic for jobs someone is waiting on, eg. a refresh requested through the
+// API. NSQ has no priorities, but fetchers consume it alongside FetchTopic, and it's normally empty,
+// so jobs on it don't wait behind a crawl's backlog.
+const FetchPriorityTopic = "fetch-priority"
+
 // FetchMessage is an envelope message published to FetchTopic.
 // This is a magical struct that when JSON serialized will take the form:
-// `{"t": "character", "d": {"id": "12345"}}`
-type FetchMessage struct{ Job }
+// `{"t": "character", "d": {"id": "12345"}}`, plus `"tc": {"traceparent": "..."}` if it carries a
+// trace context (see NewFetchMessage).
+type FetchMessage struct {
+	Job
+	Trace map[string]string
+}
+
+// NewFetchMessage wraps a job in a FetchMessage, carrying the trace context of ctx along, so the
+// job's spans can link back to whatever enqueued it.
+func NewFetchMessage(ctx context.Context, job Job) FetchMessage {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return FetchMessage{Job: job}
+	}
+	return FetchMessage{Job: job, Trace: carrier}
+}
+
+// SpanContext returns the trace context carried by the message; it's invalid if there isn't one.
+func (msg FetchMessage) SpanContext() trace.SpanContext {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(msg.Trace))
+	return trace.SpanContextFromContext(ctx)
+}
 
 // MarshalJSON marshals the message to JSON.
 func (msg FetchMessage) MarshalJSON() ([]byte, error) {
@@ -20,23 +50,27 @@ func (msg FetchMessage) MarshalJSON() ([]byte, error) {
 		return nil, errors.New("can't marshal an empty FetchMessage")
 	}
 	return json.Marshal(struct {
-		Type string      `json:"t"`
-		Data interface{} `json:"d"`
+		Type  string            `json:"t"`
+		Data  interface{}       `json:"d"`
+		Trace map[string]string `json:"tc,omitempty"`
 	}{
 		msg.Job.Type(),
 		msg.Job,
+		msg.Trace,
 	})
 }
 
 // UnmarshalJSON unmarshals JSON data.
 func (msg *FetchMessage) UnmarshalJSON(data []byte) error {
 	var d struct {
-		Type string          `json:"t"`
-		Data json.RawMessage `json:"d"`
+		Type  string            `json:"t"`
+		Data  json.RawMessage   `json:"d"`
+		Trace map[string]string `json:"tc"`
 	}
 	if err := json.Unmarshal(data, &d); err != nil {
 		return err
 	}
+	msg.Trace = d.Trace
 	msg.Job = jobIndex[d.Type]()
 	return json.Unmarshal(d.Data, msg.Job)
 }
@@ -0,0 +1,42 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestFetchMessage(t *testing.T) {
+	data, err := json.Marshal(NewFetchMessage(context.Background(), FetchCharacterJob{ID: 12345}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"t":"character","d":{"id":12345,"force":false}}`, string(data))
+
+	var msg FetchMessage
+	require.NoError(t, json.Unmarshal(data, &msg))
+	assert.Equal(t, &FetchCharacterJob{ID: 12345}, msg.Job)
+	assert.Nil(t, msg.Trace)
+	assert.False(t, msg.SpanContext().IsValid())
+
+	_, err = json.Marshal(FetchMessage{})
+	assert.EqualError(t, err, "json: error calling MarshalJSON for type *fetcher.FetchMessage: can't marshal an empty FetchMessage")
+}
+
+func TestFetchMessageTrace(t *testing.T) {
+	ctx, _ := testSpans(t)
+	parent := trace.SpanContextFromContext(ctx)
+
+	data, err := json.Marshal(NewFetchMessage(ctx, FetchCharacterJob{ID: 12345}))
+	require.NoError(t, err)
+
+	var msg FetchMessage
+	require.NoError(t, json.Unmarshal(data, &msg))
+	assert.Contains(t, msg.Trace, "traceparent")
+	sc := msg.SpanContext()
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, parent.TraceID(), sc.TraceID())
+	assert.Equal(t, parent.SpanID(), sc.SpanID())
+}
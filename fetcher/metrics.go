@@ -0,0 +1,113 @@
+package fetcher
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheus metrics for the fetcher. They're registered with the default registry, and served by
+// gubal fetcher --metrics-listen.
+var (
+	metricJobsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gubal",
+		Subsystem: "fetcher",
+		Name:      "jobs_processed_total",
+		Help:      "Jobs processed, successfully or not, by type.",
+	}, []string{"type"})
+	metricJobsFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gubal",
+		Subsystem: "fetcher",
+		Name:      "jobs_failed_total",
+		Help:      "Jobs that failed, by type.",
+	}, []string{"type"})
+	metricJobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gubal",
+		Subsystem: "fetcher",
+		Name:      "job_duration_seconds",
+		Help:      "How long jobs took to run, by type.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+	}, []string{"type"})
+
+	metricLodestoneResponses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gubal",
+		Subsystem: "fetcher",
+		Name:      "lodestone_responses_total",
+		Help:      "Responses from the Lodestone, by status code; \"error\" if there was none.",
+	}, []string{"code"})
+	metricLodestoneDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "gubal",
+		Subsystem: "fetcher",
+		Name:      "lodestone_request_duration_seconds",
+		Help:      "How long requests to the Lodestone took, until the response headers arrived.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+	})
+
+	metricCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gubal",
+		Subsystem: "fetcher",
+		Name:      "cache_lookups_total",
+		Help:      "Successful responses checked against the cache, by whether they were already in it.",
+	}, []string{"result"})
+
+	metricParseErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gubal",
+		Subsystem: "fetcher",
+		Name:      "parse_errors_total",
+		Help:      "Pages that failed to parse, by the step that failed.",
+	}, []string{"step"})
+
+	metricTombstonesCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gubal",
+		Subsystem: "fetcher",
+		Name:      "tombstones_created_total",
+		Help:      "Tombstones created for characters that no longer exist.",
+	})
+
+	metricTxDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gubal",
+		Subsystem: "fetcher",
+		Name:      "db_transaction_duration_seconds",
+		Help:      "How long jobs' database transactions were open, by whether they were committed.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"result"})
+)
+
+// ObserveJob records that a job was run, how long it took, and whether it failed.
+func ObserveJob(job Job, d time.Duration, err error) {
+	metricJobsProcessed.WithLabelValues(job.Type()).Inc()
+	metricJobDuration.WithLabelValues(job.Type()).Observe(d.Seconds())
+	if err != nil {
+		metricJobsFailed.WithLabelValues(job.Type()).Inc()
+	}
+}
+
+// ObserveTx records how long a job's transaction was open, and whether it was committed.
+func ObserveTx(d time.Duration, committed bool) {
+	result := "rollback"
+	if committed {
+		result = "commit"
+	}
+	metricTxDuration.WithLabelValues(result).Observe(d.Seconds())
+}
+
+// observeResponse records the outcome of a request to the Lodestone; statusCode is 0 if it failed.
+func observeResponse(statusCode int, d time.Duration) {
+	code := "error"
+	if statusCode != 0 {
+		code = strconv.Itoa(statusCode)
+	}
+	metricLodestoneResponses.WithLabelValues(code).Inc()
+	metricLodestoneDuration.Observe(d.Seconds())
+}
+
+// observeCacheLookup records whether a response was already cached.
+func observeCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	metricCacheLookups.WithLabelValues(result).Inc()
+}
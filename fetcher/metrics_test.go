@@ -0,0 +1,115 @@
+package fetcher
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liclac/gubal/models"
+)
+
+// The metrics are global, so these tests look at how much they change rather than their values.
+
+func TestMetricsRequests(t *testing.T) {
+	status := http.StatusOK
+	testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(status)
+		io.WriteString(rw, "hi")
+	}))
+	defer testsrv.Close()
+
+	fs := afero.NewMemMapFs()
+	do := func() {
+		req, err := http.NewRequest("GET", testsrv.URL, nil)
+		require.NoError(t, err)
+		resp, err := doRequestWithCache(fs, req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	ok := testutil.ToFloat64(metricLodestoneResponses.WithLabelValues("200"))
+	notFound := testutil.ToFloat64(metricLodestoneResponses.WithLabelValues("404"))
+	hits := testutil.ToFloat64(metricCacheLookups.WithLabelValues("hit"))
+	misses := testutil.ToFloat64(metricCacheLookups.WithLabelValues("miss"))
+
+	do()
+	do()
+	status = http.StatusNotFound
+	do()
+
+	assert.Equal(t, ok+2, testutil.ToFloat64(metricLodestoneResponses.WithLabelValues("200")))
+	assert.Equal(t, notFound+1, testutil.ToFloat64(metricLodestoneResponses.WithLabelValues("404")))
+	assert.Equal(t, hits+1, testutil.ToFloat64(metricCacheLookups.WithLabelValues("hit")))
+	assert.Equal(t, misses+1, testutil.ToFloat64(metricCacheLookups.WithLabelValues("miss")))
+}
+
+func TestMetricsFetchCharacterJob(t *testing.T) {
+	status := http.StatusNotFound
+	testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(status)
+		io.WriteString(rw, "<html></html>")
+	}))
+	realLodestoneBaseURL := LodestoneBaseURL
+	LodestoneBaseURL = testsrv.URL
+	defer func() {
+		testsrv.Close()
+		LodestoneBaseURL = realLodestoneBaseURL
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ds := models.NewMockDataStore(ctrl)
+	ctx := models.WithDataStore(context.Background(), ds)
+
+	t.Run("Tombstone", func(t *testing.T) {
+		tombstones := testutil.ToFloat64(metricTombstonesCreated)
+		gomock.InOrder(
+			ds.CharacterTombstoneStore.EXPECT().Check(gomock.Any(), int64(1234)).Return(false, nil),
+			ds.CharacterTombstoneStore.EXPECT().Create(gomock.Any(), int64(1234)).Return(nil),
+		)
+		_, err := FetchCharacterJob{ID: 1234}.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, tombstones+1, testutil.ToFloat64(metricTombstonesCreated))
+	})
+
+	t.Run("Parse Error", func(t *testing.T) {
+		status = http.StatusOK
+		nameErrors := testutil.ToFloat64(metricParseErrors.WithLabelValues("name"))
+		gomock.InOrder(
+			ds.CharacterTombstoneStore.EXPECT().Check(gomock.Any(), int64(1234)).Return(false, nil),
+			ds.ParseFailureStore.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil),
+		)
+		_, err := FetchCharacterJob{ID: 1234}.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, nameErrors+1, testutil.ToFloat64(metricParseErrors.WithLabelValues("name")))
+	})
+}
+
+func TestObserveJob(t *testing.T) {
+	job := FetchCharacterJob{ID: 1234}
+	processed := testutil.ToFloat64(metricJobsProcessed.WithLabelValues("character"))
+	failed := testutil.ToFloat64(metricJobsFailed.WithLabelValues("character"))
+
+	ObserveJob(job, time.Second, nil)
+	ObserveJob(job, time.Second, errors.New("oh no"))
+
+	assert.Equal(t, processed+2, testutil.ToFloat64(metricJobsProcessed.WithLabelValues("character")))
+	assert.Equal(t, failed+1, testutil.ToFloat64(metricJobsFailed.WithLabelValues("character")))
+}
+
+func TestObserveTx(t *testing.T) {
+	ObserveTx(time.Second, true)
+	ObserveTx(time.Second, false)
+	assert.Equal(t, 2, testutil.CollectAndCount(metricTxDuration, "gubal_fetcher_db_transaction_duration_seconds"))
+}
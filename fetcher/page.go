@@ -0,0 +1,38 @@
+package fetcher
+
+// A Page is one of the versions of a character page the Lodestone serves, depending on the
+// browser's user agent. The mobile page is much smaller, but the desktop one has more detail.
+type Page string
+
+// Page constants.
+const (
+	PageMobile  Page = "mobile"
+	PageDesktop Page = "desktop"
+)
+
+// DefaultPages are the pages a job tries if it doesn't specify any.
+var DefaultPages = []Page{PageMobile}
+
+// Pages lists every known Page.
+var Pages = []Page{
+	PageMobile,
+	PageDesktop,
+}
+
+// Valid returns whether this is a known Page.
+func (p Page) Valid() bool {
+	for _, known := range Pages {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// UserAgent returns the user agent to request the page with.
+func (p Page) UserAgent() string {
+	if p == PageDesktop {
+		return DesktopUserAgent
+	}
+	return UserAgent
+}
@@ -0,0 +1,244 @@
+package fetcher
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liclac/gubal/models"
+)
+
+func TestPage(t *testing.T) {
+	assert.True(t, PageMobile.Valid())
+	assert.True(t, PageDesktop.Valid())
+	assert.False(t, Page("tablet").Valid())
+	assert.Equal(t, UserAgent, PageMobile.UserAgent())
+	assert.Equal(t, DesktopUserAgent, PageDesktop.UserAgent())
+}
+
+func TestParseDesktop(t *testing.T) {
+	ctx := context.Background()
+	j := FetchCharacterJob{ID: 7248246}
+	mobileChar, mobileLevels, err := j.parse(ctx, []byte(testHTMLEmiHawke))
+	require.NoError(t, err)
+	desktopChar, desktopLevels, err := j.parse(ctx, []byte(testHTMLEmiHawkeDesktop))
+	require.NoError(t, err)
+
+	assert.Equal(t, mobileChar, desktopChar)
+	levelsOf := func(lvls []*models.Level) map[models.Job]int {
+		m := make(map[models.Job]int, len(lvls))
+		for _, lvl := range lvls {
+			m[lvl.Job] = lvl.Level
+		}
+		return m
+	}
+	assert.Len(t, desktopLevels, 22)
+	assert.Equal(t, levelsOf(mobileLevels), levelsOf(desktopLevels))
+}
+
+func TestFetchCharacterJobPages(t *testing.T) {
+	broken := strings.Replace(testHTMLEmiHawkeDesktop, `<p class="frame__chara__world">Ultros</p>`, `<p class="frame__chara__world">Nowhere</p>`, 1)
+	testdata := map[string]struct {
+		Pages       []Page
+		Desktop     string
+		Requested   []Page
+		Quarantined string
+		CacheKey    string
+	}{
+		"Default":          {nil, testHTMLEmiHawkeDesktop, []Page{PageMobile}, "", "char_7248246"},
+		"Desktop":          {[]Page{PageDesktop}, testHTMLEmiHawkeDesktop, []Page{PageDesktop}, "", "char_desktop_7248246"},
+		"Desktop Broken":   {[]Page{PageDesktop}, broken, []Page{PageDesktop}, broken, ""},
+		"Fallback":         {[]Page{PageDesktop, PageMobile}, broken, []Page{PageDesktop, PageMobile}, "", "char_7248246"},
+		"Fallback Unused":  {[]Page{PageDesktop, PageMobile}, testHTMLEmiHawkeDesktop, []Page{PageDesktop}, "", "char_desktop_7248246"},
+		"Fallback Desktop": {[]Page{PageMobile, PageDesktop}, testHTMLEmiHawkeDesktop, []Page{PageMobile}, "", "char_7248246"},
+	}
+	for name, data := range testdata {
+		t.Run(name, func(t *testing.T) {
+			var requested []Page
+			testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.Header.Get("User-Agent") == DesktopUserAgent {
+					requested = append(requested, PageDesktop)
+					io.WriteString(rw, data.Desktop)
+				} else {
+					requested = append(requested, PageMobile)
+					io.WriteString(rw, testHTMLEmiHawke)
+				}
+			}))
+			realLodestoneBaseURL := LodestoneBaseURL
+			LodestoneBaseURL = testsrv.URL
+			defer func() {
+				testsrv.Close()
+				LodestoneBaseURL = realLodestoneBaseURL
+			}()
+
+			ds := models.NewMemoryDataStore()
+			fs := afero.NewMemMapFs()
+			ctx := WithCacheFS(models.WithDataStore(context.Background(), ds), fs)
+
+			id := int64(7248246)
+			_, err := FetchCharacterJob{ID: id, Pages: data.Pages}.Run(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, data.Requested, requested)
+
+			f, err := ds.ParseFailures().Get(ctx, id)
+			if data.Quarantined != "" {
+				require.NoError(t, err)
+				assert.Equal(t, data.Quarantined, f.HTML)
+				assert.Contains(t, f.Errors, `unknown world: 'Nowhere'`)
+				return
+			}
+			assert.Error(t, err)
+
+			ch, err := ds.Characters().Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, models.Ultros, ch.World)
+			lvl, err := ds.Levels().Get(ctx, id, models.SCH)
+			require.NoError(t, err)
+			assert.Equal(t, 70, lvl.Level)
+
+			latest, err := cacheLatest(fs, data.CacheKey)
+			require.NoError(t, err)
+			assert.NotEqual(t, "", latest)
+		})
+	}
+
+	t.Run("Unknown", func(t *testing.T) {
+		ctx := models.WithDataStore(context.Background(), models.NewMemoryDataStore())
+		_, err := FetchCharacterJob{ID: 7248246, Pages: []Page{"tablet"}}.Run(ctx)
+		assert.EqualError(t, err, "unknown page: tablet")
+	})
+}
+
+// testHTMLEmiHawkeDesktop is an abridged version of the desktop page for testHTMLEmiHawke.
+const testHTMLEmiHawkeDesktop = `<!DOCTYPE html>
+<html lang="en-us" class="en-us">
+<head><meta charset="utf-8">
+<title>Emi Hawke | FINAL FANTASY XIV, The Lodestone</title>
+</head>
+<body>
+<div class="frame__chara" id="anchor__character">
+	<a href="/lodestone/character/7248246/" class="frame__chara__link">
+		<div class="frame__chara__box">
+			<p class="frame__chara__title">Khloe&#39;s Friend</p>
+			<p class="frame__chara__name">Emi Hawke</p>
+			<p class="frame__chara__world">Ultros</p>
+		</div>
+	</a>
+</div>
+<div class="character__content selected">
+	<div class="character__profile clearfix">
+		<div class="character__profile__data">
+			<div class="character-block">
+				<i class="character-block__icon"></i>
+				<div class="character-block__box">
+					<p class="character-block__title">Race/Clan/Gender</p>
+					<p class="character-block__name">Au Ra<br />Raen / ♀</p>
+				</div>
+			</div>
+			<div class="character-block">
+				<img src="https://img.finalfantasyxiv.com/lds/h/6/oschon.png" width="32" height="32" alt="" class="character-block__icon">
+				<div class="character-block__box">
+					<p class="character-block__title">Nameday</p>
+					<p class="character-block__birth">5th Sun of the 4th Astral Moon</p>
+					<p class="character-block__title">Guardian</p>
+					<p class="character-block__name">Oschon, the Wanderer</p>
+				</div>
+			</div>
+			<div class="character-block">
+				<img src="https://img.finalfantasyxiv.com/lds/h/D/gridania.png" width="32" height="32" alt="" class="character-block__icon">
+				<div class="character-block__box">
+					<p class="character-block__title">City-state</p>
+					<p class="character-block__name">Gridania</p>
+				</div>
+			</div>
+			<div class="character-block">
+				<img src="https://img.finalfantasyxiv.com/lds/h/8/maelstrom.png" width="32" height="32" alt="" class="character-block__icon">
+				<div class="character-block__box">
+					<p class="character-block__title">Grand Company</p>
+					<p class="character-block__name">Maelstrom / Second Storm Lieutenant</p>
+				</div>
+			</div>
+		</div>
+	</div>
+	<div class="character__profile__detail">
+		<table class="character__param__list">
+			<tr><th><span>Strength</span></th><td>218</td></tr>
+			<tr><th><span>Dexterity</span></th><td>243</td></tr>
+			<tr><th><span>Vitality</span></th><td>1823</td></tr>
+			<tr><th><span>Intelligence</span></th><td>2412</td></tr>
+			<tr><th><span>Mind</span></th><td>1304</td></tr>
+		</table>
+		<div class="character__level clearfix">
+			<div class="character__level__list">
+				<h3 class="heading__item">Tank</h3>
+				<ul>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Paladin / Gladiator"></span>62</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Warrior / Marauder"></span>60</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Dark Knight"></span>33</li>
+				</ul>
+			</div>
+			<div class="character__level__list">
+				<h3 class="heading__item">Healer</h3>
+				<ul>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="White Mage / Conjurer"></span>51</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Scholar"></span>70</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Astrologian"></span>50</li>
+				</ul>
+			</div>
+			<div class="character__level__list">
+				<h3 class="heading__item">Melee DPS</h3>
+				<ul>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Monk / Pugilist"></span>68</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Dragoon / Lancer"></span>60</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Ninja / Rogue"></span>61</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Samurai"></span>53</li>
+				</ul>
+			</div>
+			<div class="character__level__list">
+				<h3 class="heading__item">Physical Ranged DPS</h3>
+				<ul>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Bard / Archer"></span>38</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Machinist"></span>-</li>
+				</ul>
+			</div>
+			<div class="character__level__list">
+				<h3 class="heading__item">Magical Ranged DPS</h3>
+				<ul>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Black Mage / Thaumaturge"></span>70</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Summoner / Arcanist"></span>70</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Red Mage"></span>52</li>
+				</ul>
+			</div>
+			<div class="character__level__list">
+				<h3 class="heading__item">Disciples of the Hand</h3>
+				<ul>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Carpenter"></span>-</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Blacksmith"></span>-</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Armorer"></span>14</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Goldsmith"></span>-</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Leatherworker"></span>50</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Weaver"></span>16</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Alchemist"></span>17</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Culinarian"></span>12</li>
+				</ul>
+			</div>
+			<div class="character__level__list">
+				<h3 class="heading__item">Disciples of the Land</h3>
+				<ul>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Miner"></span>60</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Botanist"></span>13</li>
+					<li><span><img src="https://img.finalfantasyxiv.com/lds/h/x/job.png" width="24" height="24" alt="" data-tooltip="Fisher"></span>60</li>
+				</ul>
+			</div>
+		</div>
+	</div>
+</div>
+</body>
+</html>`
@@ -0,0 +1,67 @@
+package fetcher
+
+import (
+	"strings"
+)
+
+// A Region is one of the Lodestone's regional sites. Characters are the same on all of them, but
+// pages are in the region's language, which is what the parser has to understand.
+type Region string
+
+// Region constants.
+const (
+	RegionNA Region = "na"
+	RegionEU Region = "eu"
+	RegionJP Region = "jp"
+	RegionFR Region = "fr"
+	RegionDE Region = "de"
+)
+
+// DefaultRegion is the region a job is fetched from if it doesn't specify one.
+const DefaultRegion = RegionNA
+
+// Regions lists every known Region.
+var Regions = []Region{
+	RegionNA,
+	RegionEU,
+	RegionJP,
+	RegionFR,
+	RegionDE,
+}
+
+// Valid returns whether this is a known Region.
+func (r Region) Valid() bool {
+	for _, known := range Regions {
+		if r == known {
+			return true
+		}
+	}
+	return false
+}
+
+// OrDefault returns the region, or DefaultRegion if it's blank.
+func (r Region) OrDefault() Region {
+	if r == "" {
+		return DefaultRegion
+	}
+	return r
+}
+
+// Language returns the language the region's pages are in.
+func (r Region) Language() Language {
+	switch r.OrDefault() {
+	case RegionJP:
+		return LanguageJA
+	case RegionFR:
+		return LanguageFR
+	case RegionDE:
+		return LanguageDE
+	default:
+		return LanguageEN
+	}
+}
+
+// BaseURL returns the base URL for requests to the region's Lodestone.
+func (r Region) BaseURL() string {
+	return strings.Replace(LodestoneBaseURL, "{region}", string(r.OrDefault()), 1)
+}
@@ -0,0 +1,182 @@
+package fetcher
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liclac/gubal/models"
+)
+
+func TestRegion(t *testing.T) {
+	testdata := map[Region]struct {
+		URL  string
+		Lang Language
+	}{
+		"":        {"https://na.finalfantasyxiv.com/lodestone/", LanguageEN},
+		RegionNA:  {"https://na.finalfantasyxiv.com/lodestone/", LanguageEN},
+		RegionEU:  {"https://eu.finalfantasyxiv.com/lodestone/", LanguageEN},
+		RegionJP:  {"https://jp.finalfantasyxiv.com/lodestone/", LanguageJA},
+		RegionFR:  {"https://fr.finalfantasyxiv.com/lodestone/", LanguageFR},
+		RegionDE:  {"https://de.finalfantasyxiv.com/lodestone/", LanguageDE},
+		"kr":      {"https://kr.finalfantasyxiv.com/lodestone/", LanguageEN},
+		"invalid": {"https://invalid.finalfantasyxiv.com/lodestone/", LanguageEN},
+	}
+	for r, data := range testdata {
+		t.Run(string(r), func(t *testing.T) {
+			assert.Equal(t, data.URL, r.BaseURL())
+			assert.Equal(t, data.Lang, r.Language())
+			assert.NotNil(t, r.Language().Locale())
+		})
+	}
+	assert.True(t, Region("").OrDefault().Valid())
+	assert.False(t, Region("kr").Valid())
+}
+
+func TestLocales(t *testing.T) {
+	for _, lang := range Languages {
+		t.Run(string(lang), func(t *testing.T) {
+			loc := lang.Locale()
+			require.NotNil(t, loc)
+
+			clans := make(map[models.CharacterClan]bool)
+			for _, clan := range loc.RaceClans {
+				clans[clan] = true
+			}
+			for _, clan := range models.CharacterClans {
+				assert.True(t, clans[clan], "missing clan: %s", clan)
+			}
+
+			guardians := make(map[models.CharacterGuardian]bool)
+			for _, g := range loc.Guardians {
+				guardians[g] = true
+			}
+			for _, g := range models.CharacterGuardians {
+				assert.True(t, guardians[g], "missing guardian: %s", g)
+			}
+
+			cityStates := make(map[models.CityState]bool)
+			for _, cs := range loc.CityStates {
+				cityStates[cs] = true
+			}
+			for _, cs := range models.CityStates {
+				assert.True(t, cityStates[cs], "missing city state: %s", cs)
+			}
+
+			gcs := make(map[models.GrandCompany]bool)
+			for _, gc := range loc.GrandCompanies {
+				gcs[gc] = true
+			}
+			for _, gc := range models.GrandCompanies {
+				assert.True(t, gcs[gc], "missing grand company: %s", gc)
+				if ranks, ok := loc.GCRanks[gc]; ok {
+					assert.Len(t, ranks, 10, "%s", gc)
+				}
+			}
+
+			jobs := make(map[models.Job]bool)
+			for _, job := range loc.Jobs {
+				jobs[job] = true
+			}
+			for _, job := range models.Jobs {
+				assert.True(t, jobs[job], "missing job: %s", job)
+			}
+		})
+	}
+}
+
+func TestFetchCharacterJobRegion(t *testing.T) {
+	html := strings.NewReplacer(
+		`>Race/Clan/Gender<`, `>種族/部族/性別<`,
+		`>Au Ra<br />Raen / ♀<`, `>アウラ<br />レン / ♀<`,
+		`>Nameday<`, `>誕生日<`,
+		`>Oschon, the Wanderer<`, `>オシュオン (放浪神)<`,
+		`>City-state<`, `>開始都市<`,
+		`>Gridania<`, `>グリダニア<`,
+		`>Grand Company<`, `>グランドカンパニー<`,
+		`>Maelstrom / Second Storm Lieutenant<`, `>黒渦団 / 少甲尉<`,
+		`>Paladin<`, `>ナイト<`,
+		`>Warrior<`, `>戦士<`,
+		`>Dark Knight<`, `>暗黒騎士<`,
+		`>White Mage<`, `>白魔道士<`,
+		`>Scholar<`, `>学者<`,
+		`>Astrologian<`, `>占星術師<`,
+		`>Monk<`, `>モンク<`,
+		`>Dragoon<`, `>竜騎士<`,
+		`>Ninja<`, `>忍者<`,
+		`>Samurai<`, `>侍<`,
+		`>Bard<`, `>吟遊詩人<`,
+		`>Machinist<`, `>機工士<`,
+		`>Black Mage<`, `>黒魔道士<`,
+		`>Summoner<`, `>召喚士<`,
+		`>Red Mage<`, `>赤魔道士<`,
+		`>Carpenter<`, `>木工師<`,
+		`>Blacksmith<`, `>鍛冶師<`,
+		`>Armorer<`, `>甲冑師<`,
+		`>Goldsmith<`, `>彫金師<`,
+		`>Leatherworker<`, `>革細工師<`,
+		`>Weaver<`, `>裁縫師<`,
+		`>Alchemist<`, `>錬金術師<`,
+		`>Culinarian<`, `>調理師<`,
+		`>Miner<`, `>採掘師<`,
+		`>Botanist<`, `>園芸師<`,
+		`>Fisher<`, `>漁師<`,
+	).Replace(testHTMLEmiHawke)
+	testsrv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !assert.Equal(t, "/jp/character/7248246/", req.URL.Path) {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(rw, html)
+	}))
+	realLodestoneBaseURL := LodestoneBaseURL
+	LodestoneBaseURL = testsrv.URL + "/{region}"
+	defer func() {
+		testsrv.Close()
+		LodestoneBaseURL = realLodestoneBaseURL
+	}()
+
+	ds := models.NewMemoryDataStore()
+	ctx := models.WithDataStore(context.Background(), ds)
+
+	id := int64(7248246)
+	jobs, err := FetchCharacterJob{ID: id, Region: RegionJP}.Run(ctx)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 0)
+
+	if f, err := ds.ParseFailures().Get(ctx, id); err == nil {
+		t.Fatalf("page was quarantined: %v", f.Errors)
+	}
+
+	ch, err := ds.Characters().Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Emi", ch.FirstName)
+	assert.Equal(t, models.AuRa, ch.Race)
+	assert.Equal(t, models.AuRaRaen, ch.Clan)
+	assert.Equal(t, models.Oschon, ch.Guardian)
+	assert.Equal(t, models.Gridania, ch.CityState)
+	assert.Equal(t, models.Ultros, ch.World)
+	if assert.NotNil(t, ch.GC) {
+		assert.Equal(t, models.Maelstrom, *ch.GC)
+	}
+	assert.Equal(t, 9, ch.GCRank)
+	for job, level := range map[models.Job]int{
+		models.PLD: 62,
+		models.SCH: 70,
+		models.FSH: 60,
+	} {
+		lvl, err := ds.Levels().Get(ctx, id, job)
+		require.NoError(t, err)
+		assert.Equal(t, level, lvl.Level, "%s", job)
+	}
+
+	// An unknown region shouldn't even be requested.
+	_, err = FetchCharacterJob{ID: id, Region: "kr"}.Run(ctx)
+	assert.EqualError(t, err, "unknown region: kr")
+}
@@ -1,13 +1,16 @@
 package fetcher
 
 import (
-	"bufio"
 	"bytes"
 	"context"
+	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/spf13/afero"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/liclac/gubal/lib"
@@ -32,36 +35,76 @@ func trim(s string) string {
 	return strings.TrimSpace(s)
 }
 
-func doRequestWithCache(fs afero.Fs, key string, req *http.Request) (*http.Response, error) {
-	if fs == nil {
-		return http.DefaultClient.Do(req)
+// getPage requests a page from a region's Lodestone, as the given version of it, and returns its
+// status code and body.
+func getPage(ctx context.Context, fs afero.Fs, region Region, page Page, path string) (int, []byte, error) {
+	req, err := http.NewRequest("GET", region.BaseURL()+path, nil)
+	if err != nil {
+		return 0, nil, err
 	}
-
-	ctx := req.Context()
-	filename := key + ".http"
-	if f, err := fs.Open(filename); err == nil {
-		lib.GetLogger(ctx).Debug("Reusing a cached response", zap.String("filename", filename))
-		return http.ReadResponse(bufio.NewReader(f), req)
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", page.UserAgent())
+	resp, err := doRequestWithCache(fs, req)
+	if err != nil {
+		return 0, nil, err
 	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := resp.Body.Close(); err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
 
+// doRequestWithCache performs a request, and stores successful responses in the cache.
+// The cache is only consulted to see if we've seen a response before, it never replaces a request.
+func doRequestWithCache(fs afero.Fs, req *http.Request) (_ *http.Response, rerr error) {
+	ctx, span := tracer.Start(req.Context(), req.Method+" "+req.URL.Host,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", req.URL.String()),
+		),
+	)
+	defer func() { lib.EndSpan(span, rerr) }()
+	req = req.WithContext(ctx)
+
+	start := time.Now()
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
+		observeResponse(0, time.Since(start))
 		return nil, err
 	}
+	observeResponse(resp.StatusCode, time.Since(start))
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if fs == nil {
+		return resp, nil
+	}
+
 	switch resp.StatusCode {
 	case http.StatusOK:
-		lib.GetLogger(ctx).Debug("Writing response to cache", zap.String("filename", filename))
-
-		var buf bytes.Buffer
-		if err := resp.Write(&buf); err != nil {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
 			return nil, err
 		}
-		if err := afero.WriteFile(fs, filename, buf.Bytes(), 0644); err != nil {
+		if err := resp.Body.Close(); err != nil {
 			return nil, err
 		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
 
-		// Because resp.Write consumes the body and I'm a lazy arsehole.
-		resp, err = http.ReadResponse(bufio.NewReader(&buf), req)
+		hash, existed, err := cacheWriteObject(fs, body)
+		if err != nil {
+			return nil, err
+		}
+		observeCacheLookup(existed)
+		span.SetAttributes(attribute.Bool("cache.hit", existed))
+		if existed {
+			lib.GetLogger(ctx).Debug("Response is already cached", zap.String("hash", hash))
+		} else {
+			lib.GetLogger(ctx).Debug("Writing response to cache", zap.String("hash", hash))
+		}
 	default:
 		lib.GetLogger(ctx).Debug("Not caching unsuccessful response",
 			zap.Stringer("url", req.URL),
@@ -0,0 +1,12 @@
+package lib
+
+// A Publisher publishes messages to NSQ, eg. an *nsq.Producer.
+type Publisher interface {
+	Publish(topic string, body []byte) error
+}
+
+// PublisherFunc is a Publisher that calls a function.
+type PublisherFunc func(topic string, body []byte) error
+
+// Publish calls fn(topic, body).
+func (fn PublisherFunc) Publish(topic string, body []byte) error { return fn(topic, body) }
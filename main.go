@@ -4,8 +4,10 @@ import (
 	"github.com/liclac/gubal/cmd"
 
 	_ "github.com/jinzhu/gorm/dialects/postgres"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
 	_ "github.com/joho/godotenv/autoload"
 	_ "github.com/mattes/migrate/database/postgres"
+	_ "github.com/mattes/migrate/database/sqlite3"
 	_ "github.com/mattes/migrate/source/file"
 )
 
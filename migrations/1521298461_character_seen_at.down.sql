@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE characters DROP COLUMN seen_at;
+
+COMMIT;
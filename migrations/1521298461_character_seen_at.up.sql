@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE characters ADD COLUMN seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+COMMIT;
@@ -0,0 +1,36 @@
+BEGIN;
+
+-- Precomputed counts for the stats endpoints, rebuilt by `gubal stats refresh`. Breakdowns are
+-- stored per world, so they can be summed up for any data center; top lists can't be, so they're
+-- stored per scope: every world, every data center, and everything ('', '').
+
+CREATE TABLE stats_character_counts (
+    world   world          NOT NULL,
+    race    character_race NOT NULL,
+    clan    character_clan NOT NULL,
+    gender  CHAR(1)        NOT NULL,
+    gc      grand_company,
+    gc_rank INT            NOT NULL,
+    count   BIGINT         NOT NULL
+);
+
+CREATE TABLE stats_level_counts (
+    world world  NOT NULL,
+    job   job    NOT NULL,
+    min   INT    NOT NULL,
+    count BIGINT NOT NULL,
+
+    PRIMARY KEY (world, job, min)
+);
+
+CREATE TABLE stats_top_counts (
+    world       TEXT   NOT NULL,
+    data_center TEXT   NOT NULL,
+    stat        TEXT   NOT NULL,
+    value       TEXT   NOT NULL,
+    count       BIGINT NOT NULL,
+
+    PRIMARY KEY (world, data_center, stat, value)
+);
+
+COMMIT;
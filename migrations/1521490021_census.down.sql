@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE census_snapshot_counts;
+DROP TABLE census_snapshots;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE census_snapshots (
+    date       DATE        PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE census_snapshot_counts (
+    date   DATE   NOT NULL REFERENCES census_snapshots (date) ON DELETE CASCADE,
+    metric TEXT   NOT NULL,
+    world  world  NOT NULL,
+    value  TEXT   NOT NULL,
+    min    INT    NOT NULL,
+    count  BIGINT NOT NULL,
+
+    PRIMARY KEY (date, metric, world, value, min)
+);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE parse_failures (
+    character_id   BIGINT      PRIMARY KEY,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    parser_version INT         NOT NULL,
+    errors         TEXT        NOT NULL,
+    html           TEXT        NOT NULL
+);
+
+COMMIT;
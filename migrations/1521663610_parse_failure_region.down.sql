@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE parse_failures DROP COLUMN region;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE parse_failures ADD COLUMN region TEXT NOT NULL DEFAULT 'na';
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE stats_refreshes;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- When the precomputed stats were last rebuilt; until they have been, they're counted up live.
+CREATE TABLE stats_refreshes (
+    refreshed_at TIMESTAMPTZ NOT NULL
+);
+
+COMMIT;
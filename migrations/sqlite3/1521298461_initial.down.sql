@@ -0,0 +1,7 @@
+DROP TABLE levels;
+
+DROP TABLE character_tombstones;
+
+DROP TABLE characters;
+
+DROP TABLE character_titles;
@@ -0,0 +1,179 @@
+-- SQLite has no ENUM types, so enum columns are TEXT with CHECK constraints instead. This is
+-- equivalent to every Postgres migration up to this version.
+
+CREATE TABLE character_titles (
+    id         INTEGER      PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    title VARCHAR(255) NOT NULL UNIQUE
+);
+
+CREATE TABLE characters (
+    id         BIGINT    PRIMARY KEY,
+    created_at DATETIME  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    seen_at    DATETIME  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    first_name VARCHAR(25) NOT NULL,
+    last_name  VARCHAR(25) NOT NULL,
+    title_id   INT         REFERENCES character_titles (id),
+    gender     CHAR(1)     NOT NULL DEFAULT ' ',
+    race       TEXT        NOT NULL CHECK (race IN (
+        'Hyur',
+        'Elezen',
+        'Lalafell',
+        'Miqote',
+        'Roegadyn',
+        'AuRa'
+    )),
+    clan       TEXT        NOT NULL CHECK (clan IN (
+        'Midlander',
+        'Highlander',
+        'Wildwood',
+        'Duskwight',
+        'Plainsfolk',
+        'Dunesfolk',
+        'SunSeeker',
+        'MoonKeeper',
+        'SeaWolf',
+        'Hellsguard',
+        'Raen',
+        'Xaela'
+    )),
+    guardian   TEXT        NOT NULL CHECK (guardian IN (
+        'Halone',
+        'Menphina',
+        'Thaliak',
+        'Nymeia',
+        'Llymlaen',
+        'Oschon',
+        'Byregot',
+        'Rhalgr',
+        'Azeyma',
+        'Naldthal',
+        'Nophica',
+        'Althyk'
+    )),
+    city_state TEXT        NOT NULL CHECK (city_state IN (
+        'Gridania',
+        'Uldah',
+        'Limsa'
+    )),
+    world      TEXT        NOT NULL CHECK (world IN (
+        'Aegis',
+        'Atomos',
+        'Carbuncle',
+        'Garuda',
+        'Gungnir',
+        'Kujata',
+        'Ramuh',
+        'Tonberry',
+        'Typhon',
+        'Unicorn',
+        'Alexander',
+        'Bahamut',
+        'Durandal',
+        'Fenrir',
+        'Ifrit',
+        'Ridill',
+        'Tiamat',
+        'Ultima',
+        'Valefor',
+        'Yojimbo',
+        'Zeromus',
+        'Anima',
+        'Asura',
+        'Belias',
+        'Chocobo',
+        'Hades',
+        'Ixion',
+        'Mandragora',
+        'Masamune',
+        'Pandaemonium',
+        'Shinryu',
+        'Titan',
+        'Adamantoise',
+        'Balmung',
+        'Cactuar',
+        'Coeurl',
+        'Faerie',
+        'Gilgamesh',
+        'Goblin',
+        'Jenova',
+        'Mateus',
+        'Midgardsormr',
+        'Sargatanas',
+        'Siren',
+        'Zalera',
+        'Behemoth',
+        'Brynhildr',
+        'Diabolos',
+        'Excalibur',
+        'Exodus',
+        'Famfrit',
+        'Hyperion',
+        'Lamia',
+        'Leviathan',
+        'Malboro',
+        'Ultros',
+        'Cerberus',
+        'Lich',
+        'Louisoix',
+        'Moogle',
+        'Odin',
+        'Omega',
+        'Phoenix',
+        'Ragnarok',
+        'Shiva',
+        'Zodiark'
+    )),
+    gc         TEXT        CHECK (gc IN (
+        'Maelstrom',
+        'Adders',
+        'Flames'
+    )),
+    gc_rank    INT         NOT NULL DEFAULT 0
+);
+
+CREATE TABLE character_tombstones (
+    id          BIGINT    PRIMARY KEY,
+    created_at  DATETIME  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE levels (
+    character_id BIGINT    NOT NULL REFERENCES characters (id) DEFERRABLE INITIALLY DEFERRED,
+    job          TEXT      NOT NULL CHECK (job IN (
+        'PLD',
+        'WAR',
+        'DRK',
+        'WHM',
+        'SCH',
+        'AST',
+        'MNK',
+        'DRG',
+        'NIN',
+        'SAM',
+        'BRD',
+        'MCH',
+        'BLM',
+        'SMN',
+        'RDM',
+        'CRP',
+        'BSM',
+        'ARM',
+        'GSM',
+        'LTW',
+        'WVR',
+        'ALC',
+        'CUL',
+        'MIN',
+        'BOT',
+        'FSH'
+    )),
+    created_at   DATETIME  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   DATETIME  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    level        INT       NOT NULL,
+
+    PRIMARY KEY (character_id, job)
+);
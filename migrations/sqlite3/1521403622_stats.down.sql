@@ -0,0 +1,5 @@
+DROP TABLE stats_top_counts;
+
+DROP TABLE stats_level_counts;
+
+DROP TABLE stats_character_counts;
@@ -0,0 +1,30 @@
+-- See ../1521403622_stats.up.sql. Enums are already checked in the tables these are built from.
+
+CREATE TABLE stats_character_counts (
+    world   TEXT    NOT NULL,
+    race    TEXT    NOT NULL,
+    clan    TEXT    NOT NULL,
+    gender  CHAR(1) NOT NULL,
+    gc      TEXT,
+    gc_rank INT     NOT NULL,
+    count   BIGINT  NOT NULL
+);
+
+CREATE TABLE stats_level_counts (
+    world TEXT   NOT NULL,
+    job   TEXT   NOT NULL,
+    min   INT    NOT NULL,
+    count BIGINT NOT NULL,
+
+    PRIMARY KEY (world, job, min)
+);
+
+CREATE TABLE stats_top_counts (
+    world       TEXT   NOT NULL,
+    data_center TEXT   NOT NULL,
+    stat        TEXT   NOT NULL,
+    value       TEXT   NOT NULL,
+    count       BIGINT NOT NULL,
+
+    PRIMARY KEY (world, data_center, stat, value)
+);
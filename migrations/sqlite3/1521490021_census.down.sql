@@ -0,0 +1,3 @@
+DROP TABLE census_snapshot_counts;
+
+DROP TABLE census_snapshots;
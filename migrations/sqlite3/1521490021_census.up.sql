@@ -0,0 +1,15 @@
+CREATE TABLE census_snapshots (
+    date       DATE     PRIMARY KEY,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE census_snapshot_counts (
+    date   DATE   NOT NULL REFERENCES census_snapshots (date) ON DELETE CASCADE,
+    metric TEXT   NOT NULL,
+    world  TEXT   NOT NULL,
+    value  TEXT   NOT NULL,
+    min    INT    NOT NULL,
+    count  BIGINT NOT NULL,
+
+    PRIMARY KEY (date, metric, world, value, min)
+);
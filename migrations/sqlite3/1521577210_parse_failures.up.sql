@@ -0,0 +1,8 @@
+CREATE TABLE parse_failures (
+    character_id   BIGINT   PRIMARY KEY,
+    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    parser_version INT      NOT NULL,
+    errors         TEXT     NOT NULL,
+    html           TEXT     NOT NULL
+);
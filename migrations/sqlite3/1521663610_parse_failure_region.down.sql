@@ -0,0 +1,13 @@
+-- SQLite can't drop columns before 3.35, so copy everything else into a new table instead.
+CREATE TABLE parse_failures_new (
+    character_id   BIGINT   PRIMARY KEY,
+    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    parser_version INT      NOT NULL,
+    errors         TEXT     NOT NULL,
+    html           TEXT     NOT NULL
+);
+INSERT INTO parse_failures_new (character_id, created_at, updated_at, parser_version, errors, html)
+    SELECT character_id, created_at, updated_at, parser_version, errors, html FROM parse_failures;
+DROP TABLE parse_failures;
+ALTER TABLE parse_failures_new RENAME TO parse_failures;
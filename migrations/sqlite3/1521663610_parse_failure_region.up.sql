@@ -0,0 +1 @@
+ALTER TABLE parse_failures ADD COLUMN region TEXT NOT NULL DEFAULT 'na';
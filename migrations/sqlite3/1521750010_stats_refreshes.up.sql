@@ -0,0 +1,5 @@
+-- See ../1521750010_stats_refreshes.up.sql.
+
+CREATE TABLE stats_refreshes (
+    refreshed_at DATETIME NOT NULL
+);
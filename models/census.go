@@ -0,0 +1,178 @@
+package models
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -package=models -source=census.go -destination=census.mock.go
+
+// CensusMetric is a constant type for a metric recorded in census snapshots.
+type CensusMetric string
+
+// CensusMetric constants.
+const (
+	// CensusPopulation is the number of characters on each world; the value is the world.
+	CensusPopulation CensusMetric = "population"
+	// CensusRace is the number of characters of each race.
+	CensusRace CensusMetric = "race"
+	// CensusGC is the number of characters in each grand company; "" is those who aren't in one.
+	CensusGC CensusMetric = "gc"
+	// CensusLevel is the number of levels in each job and level bucket; see LevelBucket().
+	CensusLevel CensusMetric = "level"
+)
+
+// CensusMetrics lists every known CensusMetric.
+var CensusMetrics = []CensusMetric{
+	CensusPopulation,
+	CensusRace,
+	CensusGC,
+	CensusLevel,
+}
+
+// Valid returns whether this is a known CensusMetric.
+func (v CensusMetric) Valid() bool {
+	for _, known := range CensusMetrics {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
+
+// A CensusPoint is a count from a census snapshot. For CensusLevel, Value is the job, and Min and
+// Max are the level bucket; they're zero for all other metrics.
+type CensusPoint struct {
+	Date  time.Time `json:"date"`
+	Value string    `json:"value"`
+	Min   int       `json:"min,omitempty"`
+	Max   int       `json:"max,omitempty"`
+	Count int64     `json:"count"`
+}
+
+// A CensusSnapshot records that population metrics were snapshotted on a date. PK is Date.
+type CensusSnapshot struct {
+	Date      time.Time `json:"date" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// censusDate truncates a time to the (UTC) date a snapshot of it is stored under.
+func censusDate(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// A CensusStore stores dated snapshots of population metrics, so they can be charted over time.
+type CensusStore interface {
+	// Snapshot records the current population metrics under the given date. There's one snapshot
+	// per date; taking another one on the same day replaces it.
+	Snapshot(ctx context.Context, date time.Time) error
+
+	// List lists every snapshot, oldest first.
+	List(ctx context.Context) ([]*CensusSnapshot, error)
+
+	// Trend returns a metric from every snapshot between from and to (inclusive, zero for no
+	// bound), ordered by date, then value. Points for every world matching the filter are summed.
+	Trend(ctx context.Context, metric CensusMetric, f StatsFilter, from, to time.Time) ([]CensusPoint, error)
+}
+
+type censusStore struct {
+	DB *gorm.DB
+}
+
+// NewCensusStore creates a new CensusStore.
+func NewCensusStore(db *gorm.DB) CensusStore {
+	return &censusStore{db}
+}
+
+// censusMetricSQL are the queries that count up each metric by world, for Snapshot().
+var censusMetricSQL = map[CensusMetric]string{
+	CensusPopulation: `SELECT world, CAST(world AS TEXT) AS value, 0 AS min, COUNT(*) AS count
+		FROM characters GROUP BY world`,
+	CensusRace: `SELECT world, CAST(race AS TEXT) AS value, 0 AS min, COUNT(*) AS count
+		FROM characters GROUP BY world, race`,
+	CensusGC: `SELECT world, COALESCE(CAST(gc AS TEXT), '') AS value, 0 AS min, COUNT(*) AS count
+		FROM characters GROUP BY world, gc`,
+	CensusLevel: `SELECT characters.world AS world, CAST(levels.job AS TEXT) AS value, ` + levelBucketSQL + ` AS min, COUNT(*) AS count
+		FROM levels JOIN characters ON characters.id = levels.character_id
+		WHERE levels.level >= ` + strconv.Itoa(LevelMilestones[0]) + `
+		GROUP BY characters.world, levels.job, ` + levelBucketSQL,
+}
+
+func (s *censusStore) Snapshot(ctx context.Context, date time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	date = censusDate(date)
+	if err := s.DB.Exec(`DELETE FROM census_snapshots WHERE date = ?`, date).Error; err != nil {
+		return err
+	}
+	if err := s.DB.Create(&CensusSnapshot{Date: date}).Error; err != nil {
+		return err
+	}
+	for _, metric := range CensusMetrics {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// The date comes from census_snapshots, rather than a parameter, so it has the right type.
+		if err := s.DB.Exec(`INSERT INTO census_snapshot_counts (date, metric, world, value, min, count)
+			SELECT census_snapshots.date, ?, counts.world, counts.value, counts.min, counts.count
+			FROM census_snapshots, (`+censusMetricSQL[metric]+`) AS counts
+			WHERE census_snapshots.date = ?`,
+			metric, date).Error; err != nil {
+			return errors.Wrapf(err, "couldn't snapshot %s", metric)
+		}
+	}
+	return nil
+}
+
+func (s *censusStore) List(ctx context.Context) ([]*CensusSnapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	snaps := []*CensusSnapshot{}
+	if err := s.DB.Order("date").Find(&snaps).Error; err != nil {
+		return nil, err
+	}
+	for _, snap := range snaps {
+		snap.Date = censusDate(snap.Date)
+	}
+	return snaps, nil
+}
+
+func (s *censusStore) Trend(ctx context.Context, metric CensusMetric, f StatsFilter, from, to time.Time) ([]CensusPoint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	db := s.DB.Table("census_snapshot_counts").Where("metric = ?", metric)
+	if f.World != "" {
+		db = db.Where("world = ?", f.World)
+	}
+	if f.DataCenter != "" {
+		db = db.Where("world IN (?)", f.DataCenter.Worlds())
+	}
+	if !from.IsZero() {
+		db = db.Where("date >= ?", censusDate(from))
+	}
+	if !to.IsZero() {
+		db = db.Where("date <= ?", censusDate(to))
+	}
+	points := []CensusPoint{}
+	if err := db.Select("date, value, min, SUM(count) AS count").
+		Group("date, value, min").
+		Order("date, value, min").
+		Scan(&points).Error; err != nil {
+		return nil, err
+	}
+	for i := range points {
+		points[i].Date = censusDate(points[i].Date)
+		if metric == CensusLevel {
+			_, points[i].Max = LevelBucket(points[i].Min)
+		}
+	}
+	return points, nil
+}
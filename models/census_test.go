@@ -0,0 +1,110 @@
+package models
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCensusStore(t *testing.T) {
+	tx := testDB(t).Begin()
+	defer tx.Rollback()
+
+	testCensus(t, NewDataStore(tx))
+}
+
+// testCensus tests a CensusStore against any DataStore.
+func testCensus(t *testing.T, ds DataStore) {
+	ctx := context.Background()
+	census := ds.Census()
+	day1 := time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2018, 3, 2, 0, 0, 0, 0, time.UTC)
+	day3 := time.Date(2018, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	maelstrom := Maelstrom
+	emi := newTestCharacter(1, "Emi", "Hawke")
+	emi.GC = &maelstrom
+	require.NoError(t, ds.Characters().Save(ctx, emi))
+	require.NoError(t, ds.Levels().Set(ctx, &Level{CharacterID: 1, Job: SCH, Level: 62}))
+	require.NoError(t, census.Snapshot(ctx, day1.Add(13*time.Hour)))
+
+	zed := newTestCharacter(2, "Zed", "Adams")
+	zed.World, zed.Race, zed.Clan = Odin, Hyur, HyurMidlander
+	require.NoError(t, ds.Characters().Save(ctx, zed))
+	require.NoError(t, ds.Levels().Set(ctx, &Level{CharacterID: 1, Job: SCH, Level: 70}))
+	require.NoError(t, ds.Levels().Set(ctx, &Level{CharacterID: 2, Job: SCH, Level: 70}))
+	require.NoError(t, census.Snapshot(ctx, day2))
+
+	// Taking another snapshot on the same day replaces it.
+	require.NoError(t, ds.Characters().Save(ctx, newTestCharacter(3, "Anna", "Adams")))
+	require.NoError(t, census.Snapshot(ctx, day3))
+	require.NoError(t, census.Snapshot(ctx, day3))
+
+	t.Run("List", func(t *testing.T) {
+		snaps, err := census.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, snaps, 3)
+		for i, date := range []time.Time{day1, day2, day3} {
+			assert.Equal(t, date, snaps[i].Date)
+		}
+	})
+
+	t.Run("Population", func(t *testing.T) {
+		points, err := census.Trend(ctx, CensusPopulation, StatsFilter{}, time.Time{}, time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, []CensusPoint{
+			{Date: day1, Value: "Ultros", Count: 1},
+			{Date: day2, Value: "Odin", Count: 1},
+			{Date: day2, Value: "Ultros", Count: 1},
+			{Date: day3, Value: "Odin", Count: 1},
+			{Date: day3, Value: "Ultros", Count: 2},
+		}, points)
+	})
+
+	t.Run("Race", func(t *testing.T) {
+		points, err := census.Trend(ctx, CensusRace, StatsFilter{}, day2, day2)
+		require.NoError(t, err)
+		assert.Equal(t, []CensusPoint{
+			{Date: day2, Value: "AuRa", Count: 1},
+			{Date: day2, Value: "Hyur", Count: 1},
+		}, points)
+	})
+
+	t.Run("GC", func(t *testing.T) {
+		points, err := census.Trend(ctx, CensusGC, StatsFilter{DataCenter: Primal}, day2, time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, []CensusPoint{
+			{Date: day2, Value: "Maelstrom", Count: 1},
+			{Date: day3, Value: "", Count: 1},
+			{Date: day3, Value: "Maelstrom", Count: 1},
+		}, points)
+	})
+
+	t.Run("Level", func(t *testing.T) {
+		points, err := census.Trend(ctx, CensusLevel, StatsFilter{}, time.Time{}, day2)
+		require.NoError(t, err)
+		assert.Equal(t, []CensusPoint{
+			{Date: day1, Value: "SCH", Min: 61, Max: 69, Count: 1},
+			{Date: day2, Value: "SCH", Min: 70, Max: 70, Count: 2},
+		}, points)
+
+		points, err = census.Trend(ctx, CensusLevel, StatsFilter{World: Odin}, time.Time{}, day2)
+		require.NoError(t, err)
+		assert.Equal(t, []CensusPoint{
+			{Date: day2, Value: "SCH", Min: 70, Max: 70, Count: 1},
+		}, points)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		assert.Equal(t, context.Canceled, census.Snapshot(ctx, day1))
+		_, err := census.List(ctx)
+		assert.Equal(t, context.Canceled, err)
+		_, err = census.Trend(ctx, CensusRace, StatsFilter{}, time.Time{}, time.Time{})
+		assert.Equal(t, context.Canceled, err)
+	})
+}
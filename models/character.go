@@ -1,6 +1,9 @@
 package models
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"gopkg.in/guregu/null.v3"
@@ -18,6 +21,7 @@ type Character struct {
 	ID        int64     `json:"id" gorm:"primary_key"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	SeenAt    time.Time `json:"seen_at"`
 
 	FirstName string            `json:"first_name"`
 	LastName  string            `json:"last_name"`
@@ -38,10 +42,26 @@ type Character struct {
 // A CharacterStore is a data access layer for Characters.
 type CharacterStore interface {
 	// Returns the named character, or an error if it doesn't exist.
-	Get(cID int64) (*Character, error)
+	Get(ctx context.Context, cID int64) (*Character, error)
 
-	// Inserts or updates the character's record.
-	Save(ch *Character) error
+	// Inserts or updates the character's record, marking it as seen.
+	Save(ctx context.Context, ch *Character) error
+
+	// SaveAll is like Save for several characters at once, but in as few statements as possible.
+	// If the same character is given more than once, the last one wins.
+	SaveAll(ctx context.Context, chs []*Character) error
+
+	// Marks the character as seen without otherwise updating it; gorm.ErrRecordNotFound if it
+	// doesn't exist.
+	Touch(ctx context.Context, cID int64) error
+
+	// Returns a page of characters matching the query. Pass q.CursorFor() the last one to get the
+	// next page; a page shorter than the limit is the last one.
+	Search(ctx context.Context, q CharacterQuery) ([]*Character, error)
+
+	// SearchTitles is like Search, but runs the query once for each of the given titles, rather
+	// than q.Title, in a single statement. Returns each title's page by its ID.
+	SearchTitles(ctx context.Context, q CharacterQuery, titleIDs []int) (map[int][]*Character, error)
 }
 
 type characterStore struct {
@@ -53,7 +73,10 @@ func NewCharacterStore(db *gorm.DB) CharacterStore {
 	return &characterStore{db}
 }
 
-func (s *characterStore) Get(cID int64) (*Character, error) {
+func (s *characterStore) Get(ctx context.Context, cID int64) (*Character, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	var ch Character
 	if err := s.DB.First(&ch, Character{ID: cID}).Error; err != nil {
 		return nil, err
@@ -61,6 +84,178 @@ func (s *characterStore) Get(cID int64) (*Character, error) {
 	return &ch, nil
 }
 
-func (s *characterStore) Save(ch *Character) error {
+func (s *characterStore) Save(ctx context.Context, ch *Character) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	ch.SeenAt = time.Now()
 	return s.DB.Set("gorm:insert_option", `ON CONFLICT (id) DO UPDATE SET `+characterConflictAssignments).Create(ch).Error
 }
+
+func (s *characterStore) SaveAll(ctx context.Context, chs []*Character) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now()
+	idx := make(map[int64]int, len(chs))
+	rows := make([][]interface{}, 0, len(chs))
+	for _, ch := range chs {
+		// Do what GORM does for Save: fill in blank timestamps, and the title's ID.
+		if ch.CreatedAt.IsZero() {
+			ch.CreatedAt = now
+		}
+		if ch.UpdatedAt.IsZero() {
+			ch.UpdatedAt = now
+		}
+		ch.SeenAt = now
+		if ch.Title != nil {
+			ch.TitleID = null.IntFrom(int64(ch.Title.ID))
+		}
+		row := []interface{}{
+			ch.ID, ch.CreatedAt, ch.UpdatedAt, ch.SeenAt,
+			ch.FirstName, ch.LastName, ch.Race, ch.Clan, ch.Gender, ch.Guardian, ch.CityState, ch.World,
+			ch.TitleID, ch.GC, ch.GCRank,
+		}
+		if i, ok := idx[ch.ID]; ok {
+			rows[i] = row
+			continue
+		}
+		idx[ch.ID] = len(rows)
+		rows = append(rows, row)
+	}
+	return upsertAll(s.DB, "characters", []string{
+		"id", "created_at", "updated_at", "seen_at",
+		"first_name", "last_name", "race", "clan", "gender", "guardian", "city_state", "world",
+		"title_id", "gc", "gc_rank",
+	}, "id", characterConflictAssignments, rows)
+}
+
+func (s *characterStore) Touch(ctx context.Context, cID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	res := s.DB.Model(&Character{ID: cID}).UpdateColumn("seen_at", gorm.Expr("CURRENT_TIMESTAMP"))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (s *characterStore) Search(ctx context.Context, q CharacterQuery) ([]*Character, error) {
+	q, err := q.normalize()
+	if err != nil {
+		return nil, err
+	}
+	cur, err := q.cursor()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	db := searchWhere(s.DB, q, cur)
+
+	// Sort by the sort column, breaking ties by ID; the cursor is the last row's values for both.
+	if q.Sort != SortByID {
+		db = db.Order(fmt.Sprintf("%s %s", q.Sort, q.sortDir()))
+	}
+	db = db.Order("id " + q.sortDir()).Limit(q.Limit)
+
+	var chars []*Character
+	return chars, db.Find(&chars).Error
+}
+
+func (s *characterStore) SearchTitles(ctx context.Context, q CharacterQuery, titleIDs []int) (map[int][]*Character, error) {
+	q.Title = ""
+	q, err := q.normalize()
+	if err != nil {
+		return nil, err
+	}
+	cur, err := q.cursor()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	pages := make(map[int][]*Character, len(titleIDs))
+	if len(titleIDs) == 0 {
+		return pages, nil
+	}
+
+	// Number each title's characters in the order Search would return them in, and keep the first
+	// page's worth of each; the sort column has been validated by normalize().
+	order := fmt.Sprintf("id %s", q.sortDir())
+	if q.Sort != SortByID {
+		order = fmt.Sprintf("%s %s, %s", q.Sort, q.sortDir(), order)
+	}
+	ranked := searchWhere(s.DB.Table("characters"), q, cur).
+		Where("title_id IN (?)", titleIDs).
+		Select(fmt.Sprintf("*, ROW_NUMBER() OVER (PARTITION BY title_id ORDER BY %s) AS search_row", order)).
+		QueryExpr()
+	var chars []*Character
+	if err := s.DB.Raw(`SELECT * FROM (?) AS ranked WHERE search_row <= ? ORDER BY title_id, search_row`, ranked, q.Limit).
+		Scan(&chars).Error; err != nil {
+		return nil, err
+	}
+	for _, ch := range chars {
+		id := int(ch.TitleID.Int64)
+		pages[id] = append(pages[id], ch)
+	}
+	return pages, nil
+}
+
+// searchWhere applies a normalized query's filters, and its cursor, if any, to db.
+func searchWhere(db *gorm.DB, q CharacterQuery, cur *characterCursor) *gorm.DB {
+	if q.Name != "" {
+		db = db.Where(`LOWER(first_name || ' ' || last_name) LIKE ? ESCAPE '\'`, escapeLike(strings.ToLower(q.Name))+"%")
+	}
+	for col, v := range map[string]string{
+		"world":      string(q.World),
+		"race":       string(q.Race),
+		"clan":       string(q.Clan),
+		"gender":     q.Gender,
+		"guardian":   string(q.Guardian),
+		"city_state": string(q.CityState),
+		"gc":         string(q.GC),
+	} {
+		if v != "" {
+			db = db.Where(col+" = ?", v)
+		}
+	}
+	if q.GCRank != 0 {
+		db = db.Where("gc_rank = ?", q.GCRank)
+	}
+	if q.Title != "" {
+		db = db.Where("title_id IN (SELECT id FROM character_titles WHERE title = ?)", q.Title)
+	}
+	for _, r := range q.Levels {
+		cond := "EXISTS (SELECT 1 FROM levels WHERE levels.character_id = characters.id AND levels.job = ? AND levels.level >= ?"
+		args := []interface{}{r.Job, r.Min}
+		if r.Max != nil {
+			cond += " AND levels.level <= ?"
+			args = append(args, *r.Max)
+		}
+		db = db.Where(cond+")", args...)
+	}
+
+	// The cursor is the last row's values for the sort column and ID; the sort column has been
+	// validated by normalize(), so it's safe to interpolate.
+	op := ">"
+	if q.Desc {
+		op = "<"
+	}
+	if cur != nil {
+		if q.Sort == SortByID {
+			db = db.Where(fmt.Sprintf("id %s ?", op), cur.ID)
+		} else {
+			db = db.Where(fmt.Sprintf("(%s, id) %s (?, ?)", q.Sort, op), cur.Value, cur.ID)
+		}
+	}
+	return db
+}
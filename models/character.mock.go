@@ -5,6 +5,7 @@
 package models
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -33,26 +34,76 @@ func (m *MockCharacterStore) EXPECT() *MockCharacterStoreMockRecorder {
 }
 
 // Get mocks base method
-func (m *MockCharacterStore) Get(cID int64) (*Character, error) {
-	ret := m.ctrl.Call(m, "Get", cID)
+func (m *MockCharacterStore) Get(ctx context.Context, cID int64) (*Character, error) {
+	ret := m.ctrl.Call(m, "Get", ctx, cID)
 	ret0, _ := ret[0].(*Character)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get
-func (mr *MockCharacterStoreMockRecorder) Get(cID interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCharacterStore)(nil).Get), cID)
+func (mr *MockCharacterStoreMockRecorder) Get(ctx, cID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCharacterStore)(nil).Get), ctx, cID)
 }
 
 // Save mocks base method
-func (m *MockCharacterStore) Save(ch *Character) error {
-	ret := m.ctrl.Call(m, "Save", ch)
+func (m *MockCharacterStore) Save(ctx context.Context, ch *Character) error {
+	ret := m.ctrl.Call(m, "Save", ctx, ch)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Save indicates an expected call of Save
-func (mr *MockCharacterStoreMockRecorder) Save(ch interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCharacterStore)(nil).Save), ch)
+func (mr *MockCharacterStoreMockRecorder) Save(ctx, ch interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCharacterStore)(nil).Save), ctx, ch)
+}
+
+// SaveAll mocks base method
+func (m *MockCharacterStore) SaveAll(ctx context.Context, chs []*Character) error {
+	ret := m.ctrl.Call(m, "SaveAll", ctx, chs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAll indicates an expected call of SaveAll
+func (mr *MockCharacterStoreMockRecorder) SaveAll(ctx, chs interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAll", reflect.TypeOf((*MockCharacterStore)(nil).SaveAll), ctx, chs)
+}
+
+// Touch mocks base method
+func (m *MockCharacterStore) Touch(ctx context.Context, cID int64) error {
+	ret := m.ctrl.Call(m, "Touch", ctx, cID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch
+func (mr *MockCharacterStoreMockRecorder) Touch(ctx, cID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockCharacterStore)(nil).Touch), ctx, cID)
+}
+
+// Search mocks base method
+func (m *MockCharacterStore) Search(ctx context.Context, q CharacterQuery) ([]*Character, error) {
+	ret := m.ctrl.Call(m, "Search", ctx, q)
+	ret0, _ := ret[0].([]*Character)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search
+func (mr *MockCharacterStoreMockRecorder) Search(ctx, q interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockCharacterStore)(nil).Search), ctx, q)
+}
+
+// SearchTitles mocks base method
+func (m *MockCharacterStore) SearchTitles(ctx context.Context, q CharacterQuery, titleIDs []int) (map[int][]*Character, error) {
+	ret := m.ctrl.Call(m, "SearchTitles", ctx, q, titleIDs)
+	ret0, _ := ret[0].(map[int][]*Character)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTitles indicates an expected call of SearchTitles
+func (mr *MockCharacterStoreMockRecorder) SearchTitles(ctx, q, titleIDs interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTitles", reflect.TypeOf((*MockCharacterStore)(nil).SearchTitles), ctx, q, titleIDs)
 }
@@ -50,3 +50,75 @@ const (
 	Nophica  CharacterGuardian = "Nophica"
 	Althyk   CharacterGuardian = "Althyk"
 )
+
+// CharacterRaces lists every known CharacterRace.
+var CharacterRaces = []CharacterRace{
+	Hyur,
+	Elezen,
+	Lalafell,
+	Miqote,
+	Roegadyn,
+	AuRa,
+}
+
+// Valid returns whether this is a known CharacterRace.
+func (v CharacterRace) Valid() bool {
+	for _, known := range CharacterRaces {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
+
+// CharacterClans lists every known CharacterClan.
+var CharacterClans = []CharacterClan{
+	HyurMidlander,
+	HyurHighlander,
+	ElezenWildwood,
+	ElezenDuskwight,
+	LalafellPlainsfolk,
+	LalafellDunesfolk,
+	MiqoteSunSeeker,
+	MiqoteMoonKeeper,
+	RoegadynSeaWolf,
+	RoegadynHellsguard,
+	AuRaRaen,
+	AuRaXaela,
+}
+
+// Valid returns whether this is a known CharacterClan.
+func (v CharacterClan) Valid() bool {
+	for _, known := range CharacterClans {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
+
+// CharacterGuardians lists every known CharacterGuardian.
+var CharacterGuardians = []CharacterGuardian{
+	Halone,
+	Menphina,
+	Thaliak,
+	Nymeia,
+	Llymlaen,
+	Oschon,
+	Byregot,
+	Rhalgr,
+	Azeyma,
+	Naldthal,
+	Nophica,
+	Althyk,
+}
+
+// Valid returns whether this is a known CharacterGuardian.
+func (v CharacterGuardian) Valid() bool {
+	for _, known := range CharacterGuardians {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultSearchLimit is the number of results returned by a search that doesn't specify a limit.
+const DefaultSearchLimit = 100
+
+// CharacterSort is a column that searches can be sorted by. Ties are broken by ID.
+type CharacterSort string
+
+// CharacterSort constants.
+const (
+	SortByID        CharacterSort = "id"
+	SortByFirstName CharacterSort = "first_name"
+	SortByLastName  CharacterSort = "last_name"
+	SortByUpdatedAt CharacterSort = "updated_at"
+	SortBySeenAt    CharacterSort = "seen_at"
+)
+
+// A LevelRange matches characters with a level in a job between Min and Max, inclusive.
+// A nil Max means there's no upper bound.
+type LevelRange struct {
+	Job Job
+	Min int
+	Max *int
+}
+
+// ParseLevelRange parses a LevelRange from a string like "PLD:50-60", "PLD:50-" or "PLD:70".
+func ParseLevelRange(s string) (LevelRange, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return LevelRange{}, errors.Errorf("malformed level range: \"%s\"", s)
+	}
+	r := LevelRange{Job: Job(strings.ToUpper(parts[0]))}
+	if !r.Job.Valid() {
+		return LevelRange{}, errors.Errorf("unknown job: '%s'", parts[0])
+	}
+
+	bounds := strings.SplitN(parts[1], "-", 2)
+	min, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return LevelRange{}, errors.Errorf("malformed level range: \"%s\"", s)
+	}
+	max := min
+	r.Min, r.Max = min, &max
+	if len(bounds) == 2 {
+		r.Max = nil
+		if bounds[1] != "" {
+			if max, err = strconv.Atoi(bounds[1]); err != nil {
+				return LevelRange{}, errors.Errorf("malformed level range: \"%s\"", s)
+			}
+			r.Max = &max
+		}
+	}
+	return r, r.Validate()
+}
+
+// Validate returns an error if the range is invalid, eg. has a negative bound, or Min > Max.
+func (r LevelRange) Validate() error {
+	switch {
+	case !r.Job.Valid():
+		return errors.Errorf("unknown job: '%s'", r.Job)
+	case r.Min < 0 || (r.Max != nil && *r.Max < 0):
+		return errors.Errorf("negative level in range: %s", r)
+	case r.Max != nil && r.Min > *r.Max:
+		return errors.Errorf("level range ends before it starts: %s", r)
+	}
+	return nil
+}
+
+// String formats the range the way ParseLevelRange parses it, eg. "PLD:50-60" or "PLD:50-".
+func (r LevelRange) String() string {
+	if r.Max == nil {
+		return fmt.Sprintf("%s:%d-", r.Job, r.Min)
+	}
+	return fmt.Sprintf("%s:%d-%d", r.Job, r.Min, *r.Max)
+}
+
+// Matches returns whether a level falls within the range.
+func (r LevelRange) Matches(lvl int) bool {
+	return lvl >= r.Min && (r.Max == nil || lvl <= *r.Max)
+}
+
+// A CharacterQuery is a search for characters; zero values match everything.
+type CharacterQuery struct {
+	Name      string // Prefix of "First Last", case insensitive.
+	World     World
+	Race      CharacterRace
+	Clan      CharacterClan
+	Gender    string
+	Guardian  CharacterGuardian
+	CityState CityState
+	GC        GrandCompany
+	GCRank    int
+	Title     string
+	Levels    []LevelRange
+
+	Sort  CharacterSort // Defaults to SortByID.
+	Desc  bool
+	After string // Cursor returned by CursorFor(), for keyset pagination.
+	Limit int    // Defaults to DefaultSearchLimit.
+}
+
+// characterCursor is the decoded form of a CharacterQuery's After.
+type characterCursor struct {
+	Value interface{} `json:"v,omitempty"`
+	ID    int64       `json:"id"`
+}
+
+// Validate returns an error if the query is invalid, eg. filters on an unknown world.
+func (q CharacterQuery) Validate() error {
+	if _, err := q.normalize(); err != nil {
+		return err
+	}
+	_, err := q.cursor()
+	return err
+}
+
+// normalize fills in defaults, and validates the query.
+func (q CharacterQuery) normalize() (CharacterQuery, error) {
+	if q.Sort == "" {
+		q.Sort = SortByID
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultSearchLimit
+	}
+	switch q.Sort {
+	case SortByID, SortByFirstName, SortByLastName, SortByUpdatedAt, SortBySeenAt:
+	default:
+		return q, errors.Errorf("can't sort by: %s", q.Sort)
+	}
+
+	switch {
+	case q.World != "" && !q.World.Valid():
+		return q, errors.Errorf("unknown world: '%s'", q.World)
+	case q.Race != "" && !q.Race.Valid():
+		return q, errors.Errorf("unknown race: '%s'", q.Race)
+	case q.Clan != "" && !q.Clan.Valid():
+		return q, errors.Errorf("unknown clan: '%s'", q.Clan)
+	case q.Guardian != "" && !q.Guardian.Valid():
+		return q, errors.Errorf("unknown guardian: '%s'", q.Guardian)
+	case q.CityState != "" && !q.CityState.Valid():
+		return q, errors.Errorf("unknown city state: '%s'", q.CityState)
+	case q.GC != "" && !q.GC.Valid():
+		return q, errors.Errorf("unknown grand company: '%s'", q.GC)
+	}
+	for _, r := range q.Levels {
+		if err := r.Validate(); err != nil {
+			return q, err
+		}
+	}
+	return q, nil
+}
+
+// sortValue returns the value of a character's sort column.
+func (q CharacterQuery) sortValue(ch *Character) interface{} {
+	switch q.Sort {
+	case SortByFirstName:
+		return ch.FirstName
+	case SortByLastName:
+		return ch.LastName
+	case SortByUpdatedAt:
+		return ch.UpdatedAt
+	case SortBySeenAt:
+		return ch.SeenAt
+	default:
+		return ch.ID
+	}
+}
+
+// sortDir returns the SQL sort direction.
+func (q CharacterQuery) sortDir() string {
+	if q.Desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// CursorFor returns a cursor for the page after the given character, for use as After.
+func (q CharacterQuery) CursorFor(ch *Character) string {
+	cur := characterCursor{ID: ch.ID}
+	if q.Sort != "" && q.Sort != SortByID {
+		cur.Value = q.sortValue(ch)
+	}
+	data, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// cursor decodes After, if set; the value is returned as the sort column's type.
+func (q CharacterQuery) cursor() (*characterCursor, error) {
+	if q.Sort == "" {
+		q.Sort = SortByID
+	}
+	if q.After == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(q.After)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed cursor")
+	}
+	var cur characterCursor
+	if err := json.Unmarshal(data, &cur); err != nil {
+		return nil, errors.Wrap(err, "malformed cursor")
+	}
+
+	switch q.Sort {
+	case SortByID:
+		cur.Value = cur.ID
+	case SortByFirstName, SortByLastName:
+		s, ok := cur.Value.(string)
+		if !ok {
+			return nil, errors.New("malformed cursor: wrong type")
+		}
+		cur.Value = s
+	case SortByUpdatedAt, SortBySeenAt:
+		s, ok := cur.Value.(string)
+		if !ok {
+			return nil, errors.New("malformed cursor: wrong type")
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, errors.Wrap(err, "malformed cursor")
+		}
+		cur.Value = t
+	}
+	return &cur, nil
+}
+
+// escapeLike escapes LIKE wildcards in s, for use with ESCAPE '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
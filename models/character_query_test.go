@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestParseLevelRange(t *testing.T) {
+	for s, expect := range map[string]LevelRange{
+		"PLD:70":    {PLD, 70, intPtr(70)},
+		"PLD:0":     {PLD, 0, intPtr(0)},
+		"pld:50-60": {PLD, 50, intPtr(60)},
+		"SCH:50-":   {SCH, 50, nil},
+	} {
+		t.Run(s, func(t *testing.T) {
+			r, err := ParseLevelRange(s)
+			require.NoError(t, err)
+			assert.Equal(t, expect, r)
+		})
+	}
+
+	for s, msg := range map[string]string{
+		"PLD":       `malformed level range: "PLD"`,
+		"XYZ:50":    `unknown job: 'XYZ'`,
+		"PLD:a-60":  `malformed level range: "PLD:a-60"`,
+		"PLD:50-ab": `malformed level range: "PLD:50-ab"`,
+		"PLD:-5":    `malformed level range: "PLD:-5"`,
+		"PLD:5--1":  `negative level in range: PLD:5--1`,
+		"PLD:60-50": `level range ends before it starts: PLD:60-50`,
+	} {
+		t.Run(s, func(t *testing.T) {
+			_, err := ParseLevelRange(s)
+			assert.EqualError(t, err, msg)
+		})
+	}
+}
+
+func TestLevelRangeMatches(t *testing.T) {
+	assert.True(t, LevelRange{PLD, 50, intPtr(60)}.Matches(50))
+	assert.True(t, LevelRange{PLD, 50, intPtr(60)}.Matches(60))
+	assert.False(t, LevelRange{PLD, 50, intPtr(60)}.Matches(61))
+	assert.True(t, LevelRange{PLD, 50, nil}.Matches(70))
+	assert.False(t, LevelRange{PLD, 50, nil}.Matches(49))
+	assert.False(t, LevelRange{PLD, 0, intPtr(0)}.Matches(1))
+}
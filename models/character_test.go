@@ -1,6 +1,8 @@
 package models
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/jinzhu/gorm"
@@ -10,7 +12,8 @@ import (
 )
 
 func TestCharacterStore(t *testing.T) {
-	tx := TestDB.Begin()
+	ctx := context.Background()
+	tx := testDB(t).Begin()
 	defer tx.Rollback()
 
 	store := NewCharacterStore(tx)
@@ -19,20 +22,32 @@ func TestCharacterStore(t *testing.T) {
 
 	// Getting a nonexistent character should error.
 	t.Run("Nonexistent", func(t *testing.T) {
-		_, err := store.Get(id)
+		_, err := store.Get(ctx, id)
 		require.EqualError(t, err, "record not found")
 		assert.True(t, gorm.IsRecordNotFoundError(err))
 	})
 
+	// A cancelled context shouldn't even try.
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := store.Get(ctx, id)
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, context.Canceled, store.Save(ctx, &Character{ID: id}))
+		assert.Equal(t, context.Canceled, store.SaveAll(ctx, []*Character{{ID: id}}))
+	})
+
+	// Touching a nonexistent character should also error.
+	t.Run("Nonexistent Touch", func(t *testing.T) {
+		err := store.Touch(ctx, id)
+		assert.True(t, gorm.IsRecordNotFoundError(err))
+	})
+
 	t.Run("Create", func(t *testing.T) {
-		require.NoError(t, store.Save(&Character{
-			ID:        id,
-			FirstName: "First",
-			LastName:  "Last",
-		}))
+		require.NoError(t, store.Save(ctx, newTestCharacter(id, "First", "Last")))
 
 		t.Run("Get", func(t *testing.T) {
-			ch, err := store.Get(id)
+			ch, err := store.Get(ctx, id)
 			require.NoError(t, err)
 			assert.Equal(t, id, ch.ID)
 			assert.Equal(t, "First", ch.FirstName)
@@ -41,16 +56,203 @@ func TestCharacterStore(t *testing.T) {
 			t.Run("Save", func(t *testing.T) {
 				ch.FirstName = "NewFirst"
 				ch.LastName = "NewLast"
-				require.NoError(t, store.Save(ch))
+				require.NoError(t, store.Save(ctx, ch))
 
 				t.Run("Get", func(t *testing.T) {
-					ch, err := store.Get(id)
+					ch, err := store.Get(ctx, id)
 					require.NoError(t, err)
 					assert.Equal(t, id, ch.ID)
 					assert.Equal(t, "NewFirst", ch.FirstName)
 					assert.Equal(t, "NewLast", ch.LastName)
 				})
 			})
+
+			t.Run("Touch", func(t *testing.T) {
+				require.NoError(t, store.Touch(ctx, id))
+
+				t.Run("Get", func(t *testing.T) {
+					ch2, err := store.Get(ctx, id)
+					require.NoError(t, err)
+					assert.False(t, ch2.SeenAt.IsZero())
+					assert.Equal(t, "NewFirst", ch2.FirstName)
+				})
+			})
+		})
+	})
+	// Saving several characters at once should work like saving them one by one, even across
+	// statements, and when one is given twice.
+	t.Run("SaveAll", func(t *testing.T) {
+		title, err := NewCharacterTitleStore(tx).GetOrCreate(ctx, "Khloe's Friend")
+		require.NoError(t, err)
+		before, err := store.Get(ctx, id)
+		require.NoError(t, err)
+
+		gc := Maelstrom
+		chs := []*Character{newTestCharacter(id, "Updated", "Last")}
+		for i := int64(1); i <= upsertBatchSize; i++ {
+			chs = append(chs, newTestCharacter(100000+i, "Many", "Last"))
+		}
+		last := newTestCharacter(100001, "Twice", "Last")
+		last.Title = title
+		last.GC = &gc
+		last.GCRank = 9
+		chs = append(chs, last)
+		require.NoError(t, store.SaveAll(ctx, chs))
+
+		ch, err := store.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Updated", ch.FirstName)
+		assert.True(t, before.CreatedAt.Equal(ch.CreatedAt))
+		assert.False(t, ch.SeenAt.Before(before.SeenAt))
+
+		ch, err = store.Get(ctx, 100001)
+		require.NoError(t, err)
+		assert.Equal(t, "Twice", ch.FirstName)
+		assert.Equal(t, int64(title.ID), ch.TitleID.Int64)
+		if assert.NotNil(t, ch.GC) {
+			assert.Equal(t, Maelstrom, *ch.GC)
+		}
+		assert.Equal(t, 9, ch.GCRank)
+
+		ch, err = store.Get(ctx, 100000+upsertBatchSize)
+		require.NoError(t, err)
+		assert.Equal(t, "Many", ch.FirstName)
+		assert.False(t, ch.TitleID.Valid)
+		assert.Nil(t, ch.GC)
+	})
+}
+
+func TestCharacterStoreSearch(t *testing.T) {
+	tx := testDB(t).Begin()
+	defer tx.Rollback()
+
+	testCharacterSearch(t, NewDataStore(tx))
+}
+
+// testCharacterSearch tests CharacterStore.Search() and SearchTitles() against any DataStore.
+func testCharacterSearch(t *testing.T, ds DataStore) {
+	ctx := context.Background()
+
+	title, err := ds.CharacterTitles().GetOrCreate(ctx, "Khloe's Friend")
+	require.NoError(t, err)
+
+	maelstrom, adders := Maelstrom, Adders
+	chars := []*Character{
+		newTestCharacter(1, "Emi", "Hawke"),
+		newTestCharacter(2, "Emily", "Adams"),
+		newTestCharacter(3, "Zed", "Adams"),
+		newTestCharacter(4, "Anna", "Zed"),
+	}
+	chars[0].GC, chars[0].GCRank, chars[0].Title = &maelstrom, 9, title
+	chars[1].GC, chars[1].GCRank = &maelstrom, 2
+	chars[2].GC, chars[2].World, chars[2].Race, chars[2].Clan = &adders, Zalera, Hyur, HyurMidlander
+	chars[3].Gender = "♂"
+	for _, ch := range chars {
+		require.NoError(t, ds.Characters().Save(ctx, ch))
+	}
+	for _, lvl := range []*Level{
+		{CharacterID: 1, Job: PLD, Level: 62},
+		{CharacterID: 1, Job: SCH, Level: 70},
+		{CharacterID: 2, Job: PLD, Level: 50},
+		{CharacterID: 3, Job: SCH, Level: 70},
+	} {
+		require.NoError(t, ds.Levels().Set(ctx, lvl))
+	}
+
+	ids := func(chars []*Character) (ids []int64) {
+		for _, ch := range chars {
+			ids = append(ids, ch.ID)
+		}
+		return ids
+	}
+	for name, tc := range map[string]struct {
+		Query  CharacterQuery
+		Expect []int64
+	}{
+		"All":            {CharacterQuery{}, []int64{1, 2, 3, 4}},
+		"Name":           {CharacterQuery{Name: "emi"}, []int64{1, 2}},
+		"Full Name":      {CharacterQuery{Name: "Emi H"}, []int64{1}},
+		"Wildcards":      {CharacterQuery{Name: "%"}, nil},
+		"World":          {CharacterQuery{World: Zalera}, []int64{3}},
+		"Race":           {CharacterQuery{Race: AuRa}, []int64{1, 2, 4}},
+		"Clan":           {CharacterQuery{Clan: HyurMidlander}, []int64{3}},
+		"Gender":         {CharacterQuery{Gender: "♂"}, []int64{4}},
+		"GC":             {CharacterQuery{GC: Maelstrom}, []int64{1, 2}},
+		"GC Rank":        {CharacterQuery{GC: Maelstrom, GCRank: 9}, []int64{1}},
+		"Title":          {CharacterQuery{Title: "Khloe's Friend"}, []int64{1}},
+		"Level":          {CharacterQuery{Levels: []LevelRange{{Job: PLD, Min: 50, Max: intPtr(60)}}}, []int64{2}},
+		"Level Open":     {CharacterQuery{Levels: []LevelRange{{Job: PLD, Min: 50}}}, []int64{1, 2}},
+		"Levels":         {CharacterQuery{Levels: []LevelRange{{Job: PLD, Min: 1}, {Job: SCH, Min: 70}}}, []int64{1}},
+		"Desc":           {CharacterQuery{Desc: true}, []int64{4, 3, 2, 1}},
+		"Last Name":      {CharacterQuery{Sort: SortByLastName}, []int64{2, 3, 1, 4}},
+		"Last Name Desc": {CharacterQuery{Sort: SortByLastName, Desc: true}, []int64{4, 1, 3, 2}},
+		"Limit":          {CharacterQuery{Limit: 2}, []int64{1, 2}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			chars, err := ds.Characters().Search(ctx, tc.Query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expect, ids(chars))
 		})
+	}
+
+	// Paging through everything two at a time should visit every character once, in order.
+	for _, q := range []CharacterQuery{
+		{Limit: 2},
+		{Limit: 2, Desc: true},
+		{Limit: 2, Sort: SortByLastName},
+		{Limit: 2, Sort: SortByFirstName, Desc: true},
+	} {
+		t.Run(fmt.Sprintf("Pages %s %v", q.Sort, q.Desc), func(t *testing.T) {
+			all, err := ds.Characters().Search(ctx, CharacterQuery{Sort: q.Sort, Desc: q.Desc})
+			require.NoError(t, err)
+
+			var paged []*Character
+			for {
+				page, err := ds.Characters().Search(ctx, q)
+				require.NoError(t, err)
+				paged = append(paged, page...)
+				if len(page) < q.Limit {
+					break
+				}
+				q.After = q.CursorFor(page[len(page)-1])
+			}
+			assert.Equal(t, ids(all), ids(paged))
+		})
+	}
+
+	t.Run("Titles", func(t *testing.T) {
+		other, err := ds.CharacterTitles().GetOrCreate(ctx, "Bunny Lord")
+		require.NoError(t, err)
+		for _, ch := range chars[2:] {
+			ch.Title = other
+			require.NoError(t, ds.Characters().Save(ctx, ch))
+		}
+
+		pages, err := ds.Characters().SearchTitles(ctx, CharacterQuery{Limit: 1, Desc: true}, []int{title.ID, other.ID, 1000})
+		require.NoError(t, err)
+		assert.Len(t, pages, 2)
+		assert.Equal(t, []int64{1}, ids(pages[title.ID]))
+		assert.Equal(t, []int64{4}, ids(pages[other.ID]))
+
+		q := CharacterQuery{Sort: SortByLastName}
+		pages, err = ds.Characters().SearchTitles(ctx, q, []int{title.ID, other.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1}, ids(pages[title.ID]))
+		assert.Equal(t, []int64{3, 4}, ids(pages[other.ID]))
+
+		q.After = q.CursorFor(chars[2])
+		pages, err = ds.Characters().SearchTitles(ctx, q, []int{title.ID, other.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1}, ids(pages[title.ID]))
+		assert.Equal(t, []int64{4}, ids(pages[other.ID]))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ds.Characters().Search(ctx, CharacterQuery{Sort: "gender"})
+		assert.EqualError(t, err, "can't sort by: gender")
+		_, err = ds.Characters().Search(ctx, CharacterQuery{World: "Nowhere"})
+		assert.EqualError(t, err, "unknown world: 'Nowhere'")
+		_, err = ds.Characters().Search(ctx, CharacterQuery{After: "!!"})
+		assert.Error(t, err)
 	})
 }
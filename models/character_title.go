@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -19,7 +20,16 @@ type CharacterTitle struct {
 // CharacterTitleStore is a data access layer for CharacterTitles.
 type CharacterTitleStore interface {
 	// GetOrCreate returns an existing CharacterTitle if there is one, or creates one.
-	GetOrCreate(title string) (*CharacterTitle, error)
+	GetOrCreate(ctx context.Context, title string) (*CharacterTitle, error)
+
+	// Get returns a CharacterTitle by ID, or an error if it doesn't exist.
+	Get(ctx context.Context, id int) (*CharacterTitle, error)
+
+	// GetMany returns the CharacterTitles with the given IDs, ordered by ID; missing ones are skipped.
+	GetMany(ctx context.Context, ids []int) ([]*CharacterTitle, error)
+
+	// List returns up to limit CharacterTitles with IDs greater than after, ordered by ID.
+	List(ctx context.Context, after, limit int) ([]*CharacterTitle, error)
 }
 
 type characterTitleStore struct {
@@ -31,8 +41,41 @@ func NewCharacterTitleStore(db *gorm.DB) CharacterTitleStore {
 	return &characterTitleStore{db}
 }
 
-func (s *characterTitleStore) GetOrCreate(titleStr string) (*CharacterTitle, error) {
+func (s *characterTitleStore) GetOrCreate(ctx context.Context, titleStr string) (*CharacterTitle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	var title CharacterTitle
 	err := s.DB.FirstOrCreate(&title, CharacterTitle{Title: titleStr}).Error
 	return &title, err
 }
+
+func (s *characterTitleStore) Get(ctx context.Context, id int) (*CharacterTitle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var title CharacterTitle
+	if err := s.DB.First(&title, CharacterTitle{ID: id}).Error; err != nil {
+		return nil, err
+	}
+	return &title, nil
+}
+
+func (s *characterTitleStore) GetMany(ctx context.Context, ids []int) ([]*CharacterTitle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var titles []*CharacterTitle
+	if len(ids) == 0 {
+		return titles, nil
+	}
+	return titles, s.DB.Where("id IN (?)", ids).Order("id").Find(&titles).Error
+}
+
+func (s *characterTitleStore) List(ctx context.Context, after, limit int) ([]*CharacterTitle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var titles []*CharacterTitle
+	return titles, s.DB.Where("id > ?", after).Order("id").Limit(limit).Find(&titles).Error
+}
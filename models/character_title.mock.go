@@ -5,6 +5,7 @@
 package models
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -33,14 +34,53 @@ func (m *MockCharacterTitleStore) EXPECT() *MockCharacterTitleStoreMockRecorder
 }
 
 // GetOrCreate mocks base method
-func (m *MockCharacterTitleStore) GetOrCreate(title string) (*CharacterTitle, error) {
-	ret := m.ctrl.Call(m, "GetOrCreate", title)
+func (m *MockCharacterTitleStore) GetOrCreate(ctx context.Context, title string) (*CharacterTitle, error) {
+	ret := m.ctrl.Call(m, "GetOrCreate", ctx, title)
 	ret0, _ := ret[0].(*CharacterTitle)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrCreate indicates an expected call of GetOrCreate
-func (mr *MockCharacterTitleStoreMockRecorder) GetOrCreate(title interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreate", reflect.TypeOf((*MockCharacterTitleStore)(nil).GetOrCreate), title)
+func (mr *MockCharacterTitleStoreMockRecorder) GetOrCreate(ctx, title interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreate", reflect.TypeOf((*MockCharacterTitleStore)(nil).GetOrCreate), ctx, title)
+}
+
+// Get mocks base method
+func (m *MockCharacterTitleStore) Get(ctx context.Context, id int) (*CharacterTitle, error) {
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*CharacterTitle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockCharacterTitleStoreMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCharacterTitleStore)(nil).Get), ctx, id)
+}
+
+// GetMany mocks base method
+func (m *MockCharacterTitleStore) GetMany(ctx context.Context, ids []int) ([]*CharacterTitle, error) {
+	ret := m.ctrl.Call(m, "GetMany", ctx, ids)
+	ret0, _ := ret[0].([]*CharacterTitle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany
+func (mr *MockCharacterTitleStoreMockRecorder) GetMany(ctx, ids interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockCharacterTitleStore)(nil).GetMany), ctx, ids)
+}
+
+// List mocks base method
+func (m *MockCharacterTitleStore) List(ctx context.Context, after int, limit int) ([]*CharacterTitle, error) {
+	ret := m.ctrl.Call(m, "List", ctx, after, limit)
+	ret0, _ := ret[0].([]*CharacterTitle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockCharacterTitleStoreMockRecorder) List(ctx, after, limit interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCharacterTitleStore)(nil).List), ctx, after, limit)
 }
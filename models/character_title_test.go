@@ -1,28 +1,57 @@
 package models
 
 import (
+	"context"
 	"testing"
 
+	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestCharacterTitleStore(t *testing.T) {
-	tx := TestDB.Begin()
+	ctx := context.Background()
+	tx := testDB(t).Begin()
 	defer tx.Rollback()
 
 	store := NewCharacterTitleStore(tx)
 	title := "Khloe's Friend"
 
-	tl, err := store.GetOrCreate(title)
+	tl, err := store.GetOrCreate(ctx, title)
 	require.NoError(t, err)
 	require.NotNil(t, tl)
 	assert.NotZero(t, tl.ID)
 	assert.Equal(t, title, tl.Title)
 
-	tl2, err := store.GetOrCreate(title)
+	tl2, err := store.GetOrCreate(ctx, title)
 	require.NoError(t, err)
 	assert.Equal(t, tl2.ID, tl.ID)
 	assert.Equal(t, tl2.CreatedAt.Unix(), tl.CreatedAt.Unix())
 	assert.Equal(t, tl2.Title, tl.Title)
+
+	tl3, err := store.Get(ctx, tl.ID)
+	require.NoError(t, err)
+	assert.Equal(t, tl.Title, tl3.Title)
+
+	_, err = store.Get(ctx, tl.ID+1000)
+	assert.True(t, gorm.IsRecordNotFoundError(err))
+
+	other, err := store.GetOrCreate(ctx, "The Final Witness")
+	require.NoError(t, err)
+
+	titles, err := store.List(ctx, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, titles, 1)
+	assert.Equal(t, tl.ID, titles[0].ID)
+
+	titles, err = store.List(ctx, tl.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, titles, 1)
+	assert.Equal(t, other.ID, titles[0].ID)
+
+	titles, err = store.GetMany(ctx, []int{other.ID, tl.ID + 1000, tl.ID})
+	require.NoError(t, err)
+	require.Len(t, titles, 2)
+	assert.Equal(t, tl.ID, titles[0].ID)
+	assert.Equal(t, other.ID, titles[1].ID)
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -18,10 +19,16 @@ type CharacterTombstone struct {
 // CharacterTombstoneStore is a data access layer for CharacterTombstones.
 type CharacterTombstoneStore interface {
 	// Creates or updates a CharacterTombstone.
-	Create(cID int64) error
+	Create(ctx context.Context, cID int64) error
 
 	// Checks if there's a CharacterTombstone for a character.
-	Check(cID int64) (bool, error)
+	Check(ctx context.Context, cID int64) (bool, error)
+
+	// Returns a character's CharacterTombstone, or an error if it doesn't have one.
+	Get(ctx context.Context, cID int64) (*CharacterTombstone, error)
+
+	// List returns up to limit CharacterTombstones with IDs greater than after, ordered by ID.
+	List(ctx context.Context, after int64, limit int) ([]*CharacterTombstone, error)
 }
 
 type characterTombstoneStore struct {
@@ -33,14 +40,39 @@ func NewCharacterTombstoneStore(db *gorm.DB) CharacterTombstoneStore {
 	return &characterTombstoneStore{db}
 }
 
-func (s *characterTombstoneStore) Create(cID int64) error {
-	return s.DB.Exec(`INSERT INTO "character_tombstones" ("id", "created_at") VALUES (?, NOW()) ON CONFLICT DO NOTHING`, cID).Error
+func (s *characterTombstoneStore) Create(ctx context.Context, cID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.DB.Exec(`INSERT INTO "character_tombstones" ("id", "created_at") VALUES (?, CURRENT_TIMESTAMP) ON CONFLICT DO NOTHING`, cID).Error
 }
 
-func (s *characterTombstoneStore) Check(cID int64) (bool, error) {
+func (s *characterTombstoneStore) Check(ctx context.Context, cID int64) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
 	var count int
 	if err := s.DB.Model(CharacterTombstone{}).Where(CharacterTombstone{ID: cID}).Count(&count).Error; err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
+
+func (s *characterTombstoneStore) Get(ctx context.Context, cID int64) (*CharacterTombstone, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var ts CharacterTombstone
+	if err := s.DB.First(&ts, CharacterTombstone{ID: cID}).Error; err != nil {
+		return nil, err
+	}
+	return &ts, nil
+}
+
+func (s *characterTombstoneStore) List(ctx context.Context, after int64, limit int) ([]*CharacterTombstone, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var tss []*CharacterTombstone
+	return tss, s.DB.Where("id > ?", after).Order("id").Limit(limit).Find(&tss).Error
+}
@@ -5,6 +5,7 @@
 package models
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -33,26 +34,52 @@ func (m *MockCharacterTombstoneStore) EXPECT() *MockCharacterTombstoneStoreMockR
 }
 
 // Create mocks base method
-func (m *MockCharacterTombstoneStore) Create(cID int64) error {
-	ret := m.ctrl.Call(m, "Create", cID)
+func (m *MockCharacterTombstoneStore) Create(ctx context.Context, cID int64) error {
+	ret := m.ctrl.Call(m, "Create", ctx, cID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create
-func (mr *MockCharacterTombstoneStoreMockRecorder) Create(cID interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCharacterTombstoneStore)(nil).Create), cID)
+func (mr *MockCharacterTombstoneStoreMockRecorder) Create(ctx, cID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCharacterTombstoneStore)(nil).Create), ctx, cID)
 }
 
 // Check mocks base method
-func (m *MockCharacterTombstoneStore) Check(cID int64) (bool, error) {
-	ret := m.ctrl.Call(m, "Check", cID)
+func (m *MockCharacterTombstoneStore) Check(ctx context.Context, cID int64) (bool, error) {
+	ret := m.ctrl.Call(m, "Check", ctx, cID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Check indicates an expected call of Check
-func (mr *MockCharacterTombstoneStoreMockRecorder) Check(cID interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockCharacterTombstoneStore)(nil).Check), cID)
+func (mr *MockCharacterTombstoneStoreMockRecorder) Check(ctx, cID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockCharacterTombstoneStore)(nil).Check), ctx, cID)
+}
+
+// Get mocks base method
+func (m *MockCharacterTombstoneStore) Get(ctx context.Context, cID int64) (*CharacterTombstone, error) {
+	ret := m.ctrl.Call(m, "Get", ctx, cID)
+	ret0, _ := ret[0].(*CharacterTombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockCharacterTombstoneStoreMockRecorder) Get(ctx, cID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCharacterTombstoneStore)(nil).Get), ctx, cID)
+}
+
+// List mocks base method
+func (m *MockCharacterTombstoneStore) List(ctx context.Context, after int64, limit int) ([]*CharacterTombstone, error) {
+	ret := m.ctrl.Call(m, "List", ctx, after, limit)
+	ret0, _ := ret[0].([]*CharacterTombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockCharacterTombstoneStoreMockRecorder) List(ctx, after, limit interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCharacterTombstoneStore)(nil).List), ctx, after, limit)
 }
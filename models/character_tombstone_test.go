@@ -1,14 +1,17 @@
 package models
 
 import (
+	"context"
 	"testing"
 
+	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestCharacterTombstoneStore(t *testing.T) {
-	tx := TestDB.Begin()
+	ctx := context.Background()
+	tx := testDB(t).Begin()
 	defer tx.Rollback()
 
 	store := NewCharacterTombstoneStore(tx)
@@ -16,32 +19,60 @@ func TestCharacterTombstoneStore(t *testing.T) {
 
 	// If the character has no tombstone, checking it should return false.
 	t.Run("None", func(t *testing.T) {
-		dead, err := store.Check(id)
+		dead, err := store.Check(ctx, id)
 		require.NoError(t, err)
 		assert.False(t, dead)
+
+		_, err = store.Get(ctx, id)
+		assert.True(t, gorm.IsRecordNotFoundError(err))
 	})
 
 	// Create a tombstone.
 	t.Run("Create", func(t *testing.T) {
-		require.NoError(t, store.Create(id))
+		require.NoError(t, store.Create(ctx, id))
 
 		// If the character has a tombstone, checking it should return true.
 		t.Run("Check", func(t *testing.T) {
-			dead, err := store.Check(id)
+			dead, err := store.Check(ctx, id)
 			require.NoError(t, err)
 			assert.True(t, dead)
 		})
 
+		// ...and it should be possible to get it.
+		t.Run("Get", func(t *testing.T) {
+			ts, err := store.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, id, ts.ID)
+			assert.False(t, ts.CreatedAt.IsZero())
+		})
+
 		// If the character has a tombstone, creating it again should do nothing.
 		t.Run("Re-create", func(t *testing.T) {
-			require.NoError(t, store.Create(id))
+			require.NoError(t, store.Create(ctx, id))
 
 			// Re-creating an existing tombstone shouldn't touch it at all.
 			t.Run("Check", func(t *testing.T) {
-				dead, err := store.Check(id)
+				dead, err := store.Check(ctx, id)
 				require.NoError(t, err)
 				assert.True(t, dead)
 			})
 		})
+
+		// Tombstones can be listed in order of ID.
+		t.Run("List", func(t *testing.T) {
+			require.NoError(t, store.Create(ctx, 1))
+			require.NoError(t, store.Create(ctx, 5678))
+
+			tss, err := store.List(ctx, 0, 2)
+			require.NoError(t, err)
+			require.Len(t, tss, 2)
+			assert.Equal(t, int64(1), tss[0].ID)
+			assert.Equal(t, id, tss[1].ID)
+
+			tss, err = store.List(ctx, id, 10)
+			require.NoError(t, err)
+			require.Len(t, tss, 1)
+			assert.Equal(t, int64(5678), tss[0].ID)
+		})
 	})
 }
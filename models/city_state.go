@@ -9,3 +9,20 @@ const (
 	Uldah    CityState = "Uldah"
 	Limsa    CityState = "Limsa"
 )
+
+// CityStates lists every known CityState.
+var CityStates = []CityState{
+	Gridania,
+	Uldah,
+	Limsa,
+}
+
+// Valid returns whether this is a known CityState.
+func (v CityState) Valid() bool {
+	for _, known := range CityStates {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
@@ -2,16 +2,24 @@ package models
 
 import (
 	"context"
+
+	"github.com/liclac/gubal/lib"
 )
 
 type ctxKey string
 
 const ctxKeyDataStore ctxKey = "data_store"
 
-// GetDataStore returns the data store associated with the context, or nil.
+// GetDataStore returns the data store associated with the context. If there is none, but there is
+// a raw DB (see lib.WithRawDB), it returns a data store bound to that instead; otherwise nil.
 func GetDataStore(ctx context.Context) DataStore {
-	ds, _ := ctx.Value(ctxKeyDataStore).(DataStore)
-	return ds
+	if ds, ok := ctx.Value(ctxKeyDataStore).(DataStore); ok {
+		return ds
+	}
+	if db := lib.GetRawDB(ctx); db != nil {
+		return NewDataStore(db)
+	}
+	return nil
 }
 
 // WithDataStore returns a context with the given data store attached.
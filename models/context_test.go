@@ -0,0 +1,29 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liclac/gubal/lib"
+)
+
+func TestGetDataStore(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		assert.Nil(t, GetDataStore(context.Background()))
+	})
+
+	t.Run("Attached", func(t *testing.T) {
+		ds := &MockDataStore{}
+		assert.Equal(t, ds, GetDataStore(WithDataStore(context.Background(), ds)))
+	})
+
+	t.Run("RawDB", func(t *testing.T) {
+		tx := testDB(t).Begin()
+		defer tx.Rollback()
+
+		ds := GetDataStore(lib.WithRawDB(context.Background(), tx))
+		assert.Equal(t, NewDataStore(tx), ds)
+	})
+}
@@ -0,0 +1,61 @@
+package models
+
+// DataCenter is a constant type for a data center, a group of worlds.
+type DataCenter string
+
+// DataCenter constants.
+const (
+	Elemental DataCenter = "Elemental"
+	Gaia      DataCenter = "Gaia"
+	Mana      DataCenter = "Mana"
+	Aether    DataCenter = "Aether"
+	Primal    DataCenter = "Primal"
+	Chaos     DataCenter = "Chaos"
+)
+
+// DataCenters lists every known DataCenter.
+var DataCenters = []DataCenter{
+	Elemental,
+	Gaia,
+	Mana,
+	Aether,
+	Primal,
+	Chaos,
+}
+
+// dataCenterWorlds lists the worlds in every DataCenter.
+var dataCenterWorlds = map[DataCenter][]World{
+	Elemental: {Aegis, Atomos, Carbuncle, Garuda, Gungnir, Kujata, Ramuh, Tonberry, Typhon, Unicorn},
+	Gaia:      {Alexander, Bahamut, Durandal, Fenrir, Ifrit, Ridill, Tiamat, Ultima, Valefor, Yojimbo, Zeromus},
+	Mana:      {Anima, Asura, Belias, Chocobo, Hades, Ixion, Mandragora, Masamune, Pandaemonium, Shinryu, Titan},
+	Aether:    {Adamantoise, Balmung, Cactuar, Coeurl, Faerie, Gilgamesh, Goblin, Jenova, Mateus, Midgardsormr, Sargatanas, Siren, Zalera},
+	Primal:    {Behemoth, Brynhildr, Diabolos, Excalibur, Exodus, Famfrit, Hyperion, Lamia, Leviathan, Malboro, Ultros},
+	Chaos:     {Cerberus, Lich, Louisoix, Moogle, Odin, Omega, Phoenix, Ragnarok, Shiva, Zodiark},
+}
+
+// Valid returns whether this is a known DataCenter.
+func (v DataCenter) Valid() bool {
+	for _, known := range DataCenters {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Worlds returns the worlds in the data center.
+func (v DataCenter) Worlds() []World {
+	return dataCenterWorlds[v]
+}
+
+// DataCenter returns the data center the world is in, or "" if it's unknown.
+func (v World) DataCenter() DataCenter {
+	for dc, worlds := range dataCenterWorlds {
+		for _, w := range worlds {
+			if v == w {
+				return dc
+			}
+		}
+	}
+	return ""
+}
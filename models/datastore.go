@@ -5,11 +5,18 @@ import (
 )
 
 // A DataStore puts all the various kinds of stores in one place.
+//
+// All store methods take a context, and refuse to run once it's been cancelled or has expired.
+// GORM can't pass contexts down to the driver, so a query that's already running will only be
+// cancelled with it if the store is bound to a transaction begun with that context (gorm.BeginTx).
 type DataStore interface {
 	Characters() CharacterStore
 	CharacterTombstones() CharacterTombstoneStore
 	CharacterTitles() CharacterTitleStore
 	Levels() LevelStore
+	Stats() StatsStore
+	Census() CensusStore
+	ParseFailures() ParseFailureStore
 }
 
 type dataStore struct {
@@ -17,15 +24,22 @@ type dataStore struct {
 	characterTombstones CharacterTombstoneStore
 	characterTitles     CharacterTitleStore
 	levels              LevelStore
+	stats               StatsStore
+	census              CensusStore
+	parseFailures       ParseFailureStore
 }
 
 // NewDataStore creates a new DataStore, full of concrete data stores wrapping the given DB.
+// Stores are cheap, so it's fine to create one per transaction, eg. one for every job.
 func NewDataStore(db *gorm.DB) DataStore {
 	return &dataStore{
 		characters:          NewCharacterStore(db),
 		characterTombstones: NewCharacterTombstoneStore(db),
 		characterTitles:     NewCharacterTitleStore(db),
 		levels:              NewLevelStore(db),
+		stats:               NewStatsStore(db),
+		census:              NewCensusStore(db),
+		parseFailures:       NewParseFailureStore(db),
 	}
 }
 
@@ -44,3 +58,15 @@ func (ds *dataStore) CharacterTitles() CharacterTitleStore {
 func (ds *dataStore) Levels() LevelStore {
 	return ds.levels
 }
+
+func (ds *dataStore) Stats() StatsStore {
+	return ds.stats
+}
+
+func (ds *dataStore) Census() CensusStore {
+	return ds.census
+}
+
+func (ds *dataStore) ParseFailures() ParseFailureStore {
+	return ds.parseFailures
+}
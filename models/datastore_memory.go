@@ -0,0 +1,907 @@
+package models
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"gopkg.in/guregu/null.v3"
+)
+
+var _ DataStore = &memoryDataStore{}
+
+// memoryData is the shared state behind all of a memoryDataStore's stores.
+type memoryData struct {
+	sync.Mutex
+
+	characters          map[int64]Character
+	characterTombstones map[int64]CharacterTombstone
+	characterTitles     map[string]CharacterTitle
+	levels              map[int64]map[Job]Level
+	censusSnapshots     map[time.Time]memoryCensusSnapshot
+	parseFailures       map[int64]ParseFailure
+}
+
+// memoryCensusSnapshot is a census snapshot, with its counts.
+type memoryCensusSnapshot struct {
+	CensusSnapshot
+	Counts []memoryCensusCount
+}
+
+// memoryCensusCount is a row in census_snapshot_counts.
+type memoryCensusCount struct {
+	Metric CensusMetric
+	World  World
+	Value  string
+	Min    int
+	Count  int64
+}
+
+type memoryDataStore struct {
+	characters          *memoryCharacterStore
+	characterTombstones *memoryCharacterTombstoneStore
+	characterTitles     *memoryCharacterTitleStore
+	levels              *memoryLevelStore
+	stats               *memoryStatsStore
+	census              *memoryCensusStore
+	parseFailures       *memoryParseFailureStore
+}
+
+// NewMemoryDataStore creates a new DataStore that keeps everything in memory, for tests and dry
+// runs. It mimics the upsert semantics of the database-backed stores, but not their constraints.
+func NewMemoryDataStore() DataStore {
+	data := &memoryData{
+		characters:          make(map[int64]Character),
+		characterTombstones: make(map[int64]CharacterTombstone),
+		characterTitles:     make(map[string]CharacterTitle),
+		levels:              make(map[int64]map[Job]Level),
+		censusSnapshots:     make(map[time.Time]memoryCensusSnapshot),
+		parseFailures:       make(map[int64]ParseFailure),
+	}
+	return &memoryDataStore{
+		characters:          &memoryCharacterStore{data},
+		characterTombstones: &memoryCharacterTombstoneStore{data},
+		characterTitles:     &memoryCharacterTitleStore{data},
+		levels:              &memoryLevelStore{data},
+		stats:               &memoryStatsStore{data},
+		census:              &memoryCensusStore{data},
+		parseFailures:       &memoryParseFailureStore{data},
+	}
+}
+
+func (ds *memoryDataStore) Characters() CharacterStore {
+	return ds.characters
+}
+
+func (ds *memoryDataStore) CharacterTombstones() CharacterTombstoneStore {
+	return ds.characterTombstones
+}
+
+func (ds *memoryDataStore) CharacterTitles() CharacterTitleStore {
+	return ds.characterTitles
+}
+
+func (ds *memoryDataStore) Levels() LevelStore {
+	return ds.levels
+}
+
+func (ds *memoryDataStore) Stats() StatsStore {
+	return ds.stats
+}
+
+func (ds *memoryDataStore) Census() CensusStore {
+	return ds.census
+}
+
+func (ds *memoryDataStore) ParseFailures() ParseFailureStore {
+	return ds.parseFailures
+}
+
+type memoryCharacterStore struct {
+	data *memoryData
+}
+
+func (s *memoryCharacterStore) Get(ctx context.Context, cID int64) (*Character, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	ch, ok := s.data.characters[cID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if ch.GC != nil {
+		gc := *ch.GC
+		ch.GC = &gc
+	}
+	return &ch, nil
+}
+
+func (s *memoryCharacterStore) Save(ctx context.Context, ch *Character) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	// Like GORM, fill in blank timestamps and the title's ID on the caller's copy.
+	now := time.Now()
+	if ch.CreatedAt.IsZero() {
+		ch.CreatedAt = now
+	}
+	if ch.UpdatedAt.IsZero() {
+		ch.UpdatedAt = now
+	}
+	ch.SeenAt = now
+	if ch.Title != nil {
+		ch.TitleID = null.IntFrom(int64(ch.Title.ID))
+	}
+
+	// Like ON CONFLICT DO UPDATE, overwrite everything but the creation time; associations aren't
+	// stored, just like they aren't loaded by Get().
+	rec := *ch
+	rec.Title = nil
+	if ch.GC != nil {
+		gc := *ch.GC
+		rec.GC = &gc
+	}
+	if existing, ok := s.data.characters[ch.ID]; ok {
+		rec.CreatedAt = existing.CreatedAt
+	}
+	s.data.characters[ch.ID] = rec
+	return nil
+}
+
+func (s *memoryCharacterStore) SaveAll(ctx context.Context, chs []*Character) error {
+	for _, ch := range chs {
+		if err := s.Save(ctx, ch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryCharacterStore) Touch(ctx context.Context, cID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	ch, ok := s.data.characters[cID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	ch.SeenAt = time.Now()
+	s.data.characters[cID] = ch
+	return nil
+}
+
+func (s *memoryCharacterStore) Search(ctx context.Context, q CharacterQuery) ([]*Character, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	q, err := q.normalize()
+	if err != nil {
+		return nil, err
+	}
+	cur, err := q.cursor()
+	if err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	var chars []*Character
+	for _, ch := range s.data.characters {
+		ch := ch
+		if s.matches(q, &ch) {
+			chars = append(chars, &ch)
+		}
+	}
+
+	// less is the order the characters should be in, ties broken by ID.
+	less := func(av interface{}, aID int64, bv interface{}, bID int64) bool {
+		if c := compareSortValues(av, bv); c != 0 {
+			return (c < 0) != q.Desc
+		}
+		return aID != bID && (aID < bID) != q.Desc
+	}
+	sort.Slice(chars, func(i, j int) bool {
+		return less(q.sortValue(chars[i]), chars[i].ID, q.sortValue(chars[j]), chars[j].ID)
+	})
+
+	// Skip everything up to and including the cursor.
+	if cur != nil {
+		i := sort.Search(len(chars), func(i int) bool {
+			return less(cur.Value, cur.ID, q.sortValue(chars[i]), chars[i].ID)
+		})
+		chars = chars[i:]
+	}
+	if len(chars) > q.Limit {
+		chars = chars[:q.Limit]
+	}
+	return chars, nil
+}
+
+func (s *memoryCharacterStore) SearchTitles(ctx context.Context, q CharacterQuery, titleIDs []int) (map[int][]*Character, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	titles := make(map[int]string, len(titleIDs))
+	for _, title := range s.data.characterTitles {
+		titles[title.ID] = title.Title
+	}
+	s.data.Unlock()
+
+	pages := make(map[int][]*Character, len(titleIDs))
+	for _, id := range titleIDs {
+		title, ok := titles[id]
+		if !ok {
+			continue
+		}
+		q.Title = title
+		chars, err := s.Search(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		if len(chars) > 0 {
+			pages[id] = chars
+		}
+	}
+	return pages, nil
+}
+
+func (s *memoryCharacterStore) matches(q CharacterQuery, ch *Character) bool {
+	name := strings.ToLower(ch.FirstName + " " + ch.LastName)
+	switch {
+	case !strings.HasPrefix(name, strings.ToLower(q.Name)):
+	case q.World != "" && ch.World != q.World:
+	case q.Race != "" && ch.Race != q.Race:
+	case q.Clan != "" && ch.Clan != q.Clan:
+	case q.Gender != "" && ch.Gender != q.Gender:
+	case q.Guardian != "" && ch.Guardian != q.Guardian:
+	case q.CityState != "" && ch.CityState != q.CityState:
+	case q.GC != "" && (ch.GC == nil || *ch.GC != q.GC):
+	case q.GCRank != 0 && ch.GCRank != q.GCRank:
+	case q.Title != "" && (!ch.TitleID.Valid || s.data.characterTitles[q.Title].ID != int(ch.TitleID.Int64)):
+	default:
+		for _, r := range q.Levels {
+			lvl, ok := s.data.levels[ch.ID][r.Job]
+			if !ok || !r.Matches(lvl.Level) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+type memoryCharacterTombstoneStore struct {
+	data *memoryData
+}
+
+func (s *memoryCharacterTombstoneStore) Create(ctx context.Context, cID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	if _, ok := s.data.characterTombstones[cID]; !ok {
+		s.data.characterTombstones[cID] = CharacterTombstone{ID: cID, CreatedAt: time.Now()}
+	}
+	return nil
+}
+
+func (s *memoryCharacterTombstoneStore) Check(ctx context.Context, cID int64) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	_, ok := s.data.characterTombstones[cID]
+	return ok, nil
+}
+
+func (s *memoryCharacterTombstoneStore) Get(ctx context.Context, cID int64) (*CharacterTombstone, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	ts, ok := s.data.characterTombstones[cID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &ts, nil
+}
+
+func (s *memoryCharacterTombstoneStore) List(ctx context.Context, after int64, limit int) ([]*CharacterTombstone, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	var tss []*CharacterTombstone
+	for _, ts := range s.data.characterTombstones {
+		if ts.ID > after {
+			ts := ts
+			tss = append(tss, &ts)
+		}
+	}
+	sort.Slice(tss, func(i, j int) bool { return tss[i].ID < tss[j].ID })
+	if len(tss) > limit {
+		tss = tss[:limit]
+	}
+	return tss, nil
+}
+
+type memoryCharacterTitleStore struct {
+	data *memoryData
+}
+
+func (s *memoryCharacterTitleStore) GetOrCreate(ctx context.Context, titleStr string) (*CharacterTitle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	title, ok := s.data.characterTitles[titleStr]
+	if !ok {
+		title = CharacterTitle{
+			ID:        len(s.data.characterTitles) + 1,
+			CreatedAt: time.Now(),
+			Title:     titleStr,
+		}
+		s.data.characterTitles[titleStr] = title
+	}
+	return &title, nil
+}
+
+func (s *memoryCharacterTitleStore) Get(ctx context.Context, id int) (*CharacterTitle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	for _, title := range s.data.characterTitles {
+		if title.ID == id {
+			return &title, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *memoryCharacterTitleStore) GetMany(ctx context.Context, ids []int) ([]*CharacterTitle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	want := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	var titles []*CharacterTitle
+	for _, title := range s.data.characterTitles {
+		if want[title.ID] {
+			title := title
+			titles = append(titles, &title)
+		}
+	}
+	sort.Slice(titles, func(i, j int) bool { return titles[i].ID < titles[j].ID })
+	return titles, nil
+}
+
+func (s *memoryCharacterTitleStore) List(ctx context.Context, after, limit int) ([]*CharacterTitle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	var titles []*CharacterTitle
+	for _, title := range s.data.characterTitles {
+		if title.ID > after {
+			title := title
+			titles = append(titles, &title)
+		}
+	}
+	sort.Slice(titles, func(i, j int) bool { return titles[i].ID < titles[j].ID })
+	if len(titles) > limit {
+		titles = titles[:limit]
+	}
+	return titles, nil
+}
+
+type memoryLevelStore struct {
+	data *memoryData
+}
+
+func (s *memoryLevelStore) Get(ctx context.Context, cID int64, job Job) (*Level, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	// Like FirstOrInit, a missing level isn't an error.
+	lvl, ok := s.data.levels[cID][job]
+	if !ok {
+		lvl = Level{CharacterID: cID, Job: job}
+	}
+	return &lvl, nil
+}
+
+func (s *memoryLevelStore) Set(ctx context.Context, lvl *Level) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	now := time.Now()
+	if lvl.CreatedAt.IsZero() {
+		lvl.CreatedAt = now
+	}
+	if lvl.UpdatedAt.IsZero() {
+		lvl.UpdatedAt = now
+	}
+
+	rec := *lvl
+	levels, ok := s.data.levels[lvl.CharacterID]
+	if !ok {
+		levels = make(map[Job]Level)
+		s.data.levels[lvl.CharacterID] = levels
+	}
+	if existing, ok := levels[lvl.Job]; ok {
+		rec.CreatedAt = existing.CreatedAt
+	}
+	levels[lvl.Job] = rec
+	return nil
+}
+
+func (s *memoryLevelStore) SetAll(ctx context.Context, lvls []*Level) error {
+	for _, lvl := range lvls {
+		if err := s.Set(ctx, lvl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryLevelStore) List(ctx context.Context, cID int64) ([]*Level, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	var lvls []*Level
+	for _, job := range Jobs {
+		if lvl, ok := s.data.levels[cID][job]; ok {
+			lvls = append(lvls, &lvl)
+		}
+	}
+	return lvls, nil
+}
+
+func (s *memoryLevelStore) ListMany(ctx context.Context, cIDs []int64) ([]*Level, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	sorted := append([]int64(nil), cIDs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var lvls []*Level
+	for i, cID := range sorted {
+		if i > 0 && sorted[i-1] == cID {
+			continue
+		}
+		for _, job := range Jobs {
+			if lvl, ok := s.data.levels[cID][job]; ok {
+				lvls = append(lvls, &lvl)
+			}
+		}
+	}
+	return lvls, nil
+}
+
+type memoryStatsStore struct {
+	data *memoryData
+}
+
+// characters returns every character matching the filter.
+func (s *memoryStatsStore) characters(f StatsFilter) []Character {
+	var chars []Character
+	for _, ch := range s.data.characters {
+		if f.matches(&ch) {
+			chars = append(chars, ch)
+		}
+	}
+	return chars
+}
+
+// countBy counts characters matching the filter by a value; characters with a value of ""
+// are skipped unless keepEmpty is set.
+func (s *memoryStatsStore) countBy(ctx context.Context, f StatsFilter, keepEmpty bool, fn func(ch *Character) string) ([]StatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	byValue := make(map[string]int64)
+	for _, ch := range s.characters(f) {
+		if v := fn(&ch); v != "" || keepEmpty {
+			byValue[v]++
+		}
+	}
+	counts := []StatsCount{}
+	for v, n := range byValue {
+		counts = append(counts, StatsCount{Value: v, Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Value < counts[j].Value })
+	return counts, nil
+}
+
+func (s *memoryStatsStore) Genders(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	return s.countBy(ctx, f, true, func(ch *Character) string { return ch.Gender })
+}
+
+func (s *memoryStatsStore) Races(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	return s.countBy(ctx, f, true, func(ch *Character) string { return string(ch.Race) })
+}
+
+func (s *memoryStatsStore) Clans(ctx context.Context, f StatsFilter) ([]ClanStatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	byKey := make(map[ClanStatsCount]int64)
+	for _, ch := range s.characters(f) {
+		byKey[ClanStatsCount{Race: ch.Race, Clan: ch.Clan, Gender: ch.Gender}]++
+	}
+	counts := []ClanStatsCount{}
+	for key, n := range byKey {
+		key.Count = n
+		counts = append(counts, key)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		a, b := counts[i], counts[j]
+		if a.Race != b.Race {
+			return a.Race < b.Race
+		}
+		if a.Clan != b.Clan {
+			return a.Clan < b.Clan
+		}
+		return a.Gender < b.Gender
+	})
+	return counts, nil
+}
+
+func (s *memoryStatsStore) Levels(ctx context.Context, f StatsFilter) ([]LevelStatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	byKey := make(map[LevelStatsCount]int64)
+	for cID, levels := range s.data.levels {
+		// Like the database, levels of characters that don't exist only count without a filter.
+		if f != (StatsFilter{}) {
+			ch, ok := s.data.characters[cID]
+			if !ok || !f.matches(&ch) {
+				continue
+			}
+		}
+		for _, lvl := range levels {
+			min, max := LevelBucket(lvl.Level)
+			if min == 0 {
+				continue
+			}
+			byKey[LevelStatsCount{Job: lvl.Job, Min: min, Max: max}]++
+		}
+	}
+	counts := []LevelStatsCount{}
+	for key, n := range byKey {
+		key.Count = n
+		counts = append(counts, key)
+	}
+	sortLevelStatsCounts(counts)
+	return counts, nil
+}
+
+func (s *memoryStatsStore) GrandCompanies(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	return s.countBy(ctx, f, true, func(ch *Character) string {
+		if ch.GC == nil {
+			return ""
+		}
+		return string(*ch.GC)
+	})
+}
+
+func (s *memoryStatsStore) GrandCompanyRanks(ctx context.Context, f StatsFilter) ([]GCRankStatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	byKey := make(map[GCRankStatsCount]int64)
+	for _, ch := range s.characters(f) {
+		if ch.GC != nil {
+			byKey[GCRankStatsCount{GC: *ch.GC, Rank: ch.GCRank}]++
+		}
+	}
+	counts := []GCRankStatsCount{}
+	for key, n := range byKey {
+		key.Count = n
+		counts = append(counts, key)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].GC != counts[j].GC {
+			return counts[i].GC < counts[j].GC
+		}
+		return counts[i].Rank < counts[j].Rank
+	})
+	return counts, nil
+}
+
+func (s *memoryStatsStore) Worlds(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	counts, err := s.countBy(ctx, f, true, func(ch *Character) string { return string(ch.World) })
+	return sortTopStatsCounts(counts, 0), err
+}
+
+func (s *memoryStatsStore) TopTitles(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	titles := make(map[int64]string)
+	s.data.Lock()
+	for _, title := range s.data.characterTitles {
+		titles[int64(title.ID)] = title.Title
+	}
+	s.data.Unlock()
+
+	counts, err := s.countBy(ctx, f, false, func(ch *Character) string {
+		if !ch.TitleID.Valid {
+			return ""
+		}
+		return titles[ch.TitleID.Int64]
+	})
+	return sortTopStatsCounts(counts, clampTopLimit(limit)), err
+}
+
+func (s *memoryStatsStore) TopFirstNames(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error) {
+	counts, err := s.countBy(ctx, f, true, func(ch *Character) string { return ch.FirstName })
+	return sortTopStatsCounts(counts, clampTopLimit(limit)), err
+}
+
+func (s *memoryStatsStore) TopLastNames(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error) {
+	counts, err := s.countBy(ctx, f, true, func(ch *Character) string { return ch.LastName })
+	return sortTopStatsCounts(counts, clampTopLimit(limit)), err
+}
+
+// Refresh does nothing; stats are always computed on the fly.
+func (s *memoryStatsStore) Refresh(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// RefreshedAt always returns a zero time, since stats are always computed on the fly.
+func (s *memoryStatsStore) RefreshedAt(ctx context.Context) (time.Time, error) {
+	return time.Time{}, ctx.Err()
+}
+
+type memoryCensusStore struct {
+	data *memoryData
+}
+
+func (s *memoryCensusStore) Snapshot(ctx context.Context, date time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	byKey := make(map[memoryCensusCount]int64)
+	for _, ch := range s.data.characters {
+		gc := ""
+		if ch.GC != nil {
+			gc = string(*ch.GC)
+		}
+		byKey[memoryCensusCount{Metric: CensusPopulation, World: ch.World, Value: string(ch.World)}]++
+		byKey[memoryCensusCount{Metric: CensusRace, World: ch.World, Value: string(ch.Race)}]++
+		byKey[memoryCensusCount{Metric: CensusGC, World: ch.World, Value: gc}]++
+		for _, lvl := range s.data.levels[ch.ID] {
+			if min, _ := LevelBucket(lvl.Level); min != 0 {
+				byKey[memoryCensusCount{Metric: CensusLevel, World: ch.World, Value: string(lvl.Job), Min: min}]++
+			}
+		}
+	}
+	date = censusDate(date)
+	snap := memoryCensusSnapshot{CensusSnapshot: CensusSnapshot{Date: date, CreatedAt: time.Now()}}
+	for key, n := range byKey {
+		key.Count = n
+		snap.Counts = append(snap.Counts, key)
+	}
+	s.data.censusSnapshots[date] = snap
+	return nil
+}
+
+func (s *memoryCensusStore) List(ctx context.Context) ([]*CensusSnapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	snaps := []*CensusSnapshot{}
+	for _, snap := range s.data.censusSnapshots {
+		snap := snap.CensusSnapshot
+		snaps = append(snaps, &snap)
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Date.Before(snaps[j].Date) })
+	return snaps, nil
+}
+
+func (s *memoryCensusStore) Trend(ctx context.Context, metric CensusMetric, f StatsFilter, from, to time.Time) ([]CensusPoint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	byKey := make(map[CensusPoint]int64)
+	for date, snap := range s.data.censusSnapshots {
+		if (!from.IsZero() && date.Before(censusDate(from))) || (!to.IsZero() && date.After(censusDate(to))) {
+			continue
+		}
+		for _, c := range snap.Counts {
+			if c.Metric != metric || !f.matches(&Character{World: c.World}) {
+				continue
+			}
+			byKey[CensusPoint{Date: date, Value: c.Value, Min: c.Min}] += c.Count
+		}
+	}
+	points := []CensusPoint{}
+	for key, n := range byKey {
+		key.Count = n
+		if metric == CensusLevel {
+			_, key.Max = LevelBucket(key.Min)
+		}
+		points = append(points, key)
+	}
+	sort.Slice(points, func(i, j int) bool {
+		a, b := points[i], points[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if a.Value != b.Value {
+			return a.Value < b.Value
+		}
+		return a.Min < b.Min
+	})
+	return points, nil
+}
+
+// compareSortValues compares two values of a sort column, as returned by sortValue().
+func compareSortValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		b := b.(int64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(a, b.(string))
+	case time.Time:
+		b := b.(time.Time)
+		switch {
+		case a.Before(b):
+			return -1
+		case a.After(b):
+			return 1
+		}
+		return 0
+	}
+	return 0
+}
+
+type memoryParseFailureStore struct {
+	data *memoryData
+}
+
+func (s *memoryParseFailureStore) Save(ctx context.Context, f *ParseFailure) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	now := time.Now()
+	if f.CreatedAt.IsZero() {
+		f.CreatedAt = now
+	}
+	f.UpdatedAt = now
+
+	rec := *f
+	rec.Errors = append(ErrorList{}, f.Errors...)
+	if existing, ok := s.data.parseFailures[f.CharacterID]; ok {
+		rec.CreatedAt = existing.CreatedAt
+	}
+	s.data.parseFailures[f.CharacterID] = rec
+	return nil
+}
+
+func (s *memoryParseFailureStore) Get(ctx context.Context, cID int64) (*ParseFailure, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	f, ok := s.data.parseFailures[cID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	f.Errors = append(ErrorList{}, f.Errors...)
+	return &f, nil
+}
+
+func (s *memoryParseFailureStore) List(ctx context.Context, after int64, limit int, beforeVersion int) ([]*ParseFailure, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	fs := []*ParseFailure{}
+	for _, f := range s.data.parseFailures {
+		if f.CharacterID <= after || (beforeVersion != 0 && f.ParserVersion >= beforeVersion) {
+			continue
+		}
+		f := f
+		f.Errors = append(ErrorList{}, f.Errors...)
+		f.HTML = ""
+		fs = append(fs, &f)
+	}
+	sort.Slice(fs, func(i, j int) bool { return fs[i].CharacterID < fs[j].CharacterID })
+	if len(fs) > limit {
+		fs = fs[:limit]
+	}
+	return fs, nil
+}
+
+func (s *memoryParseFailureStore) Delete(ctx context.Context, cID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.data.Lock()
+	defer s.data.Unlock()
+
+	delete(s.data.parseFailures, cID)
+	return nil
+}
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryDataStore(t *testing.T) {
+	ctx := context.Background()
+	ds := NewMemoryDataStore()
+	id := int64(1234)
+
+	t.Run("Characters", func(t *testing.T) {
+		store := ds.Characters()
+
+		_, err := store.Get(ctx, id)
+		assert.True(t, gorm.IsRecordNotFoundError(err))
+		assert.True(t, gorm.IsRecordNotFoundError(store.Touch(ctx, id)))
+
+		title, err := ds.CharacterTitles().GetOrCreate(ctx, "Khloe's Friend")
+		require.NoError(t, err)
+		require.NoError(t, store.Save(ctx, &Character{ID: id, FirstName: "First", LastName: "Last", Title: title}))
+
+		ch, err := store.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "First", ch.FirstName)
+		assert.Nil(t, ch.Title)
+		assert.Equal(t, int64(title.ID), ch.TitleID.Int64)
+		createdAt := ch.CreatedAt
+
+		// Saving again should update everything but the creation time.
+		require.NoError(t, store.Save(ctx, &Character{ID: id, FirstName: "NewFirst", LastName: "NewLast"}))
+		ch, err = store.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "NewFirst", ch.FirstName)
+		assert.Equal(t, "NewLast", ch.LastName)
+		assert.False(t, ch.TitleID.Valid)
+		assert.Equal(t, createdAt, ch.CreatedAt)
+
+		require.NoError(t, store.Touch(ctx, id))
+
+		require.NoError(t, store.SaveAll(ctx, []*Character{
+			{ID: id, FirstName: "Many"},
+			{ID: id + 1, FirstName: "Other"},
+		}))
+		ch, err = store.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Many", ch.FirstName)
+		assert.Equal(t, createdAt, ch.CreatedAt)
+		ch, err = store.Get(ctx, id+1)
+		require.NoError(t, err)
+		assert.Equal(t, "Other", ch.FirstName)
+	})
+
+	t.Run("CharacterTitles", func(t *testing.T) {
+		store := ds.CharacterTitles()
+
+		tl, err := store.GetOrCreate(ctx, "The Final Witness")
+		require.NoError(t, err)
+		assert.NotZero(t, tl.ID)
+
+		tl2, err := store.GetOrCreate(ctx, "The Final Witness")
+		require.NoError(t, err)
+		assert.Equal(t, tl, tl2)
+
+		tl3, err := store.GetOrCreate(ctx, "Something Else")
+		require.NoError(t, err)
+		assert.NotEqual(t, tl.ID, tl3.ID)
+
+		tl4, err := store.Get(ctx, tl3.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tl3, tl4)
+
+		titles, err := store.List(ctx, tl.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, titles, 1)
+		assert.Equal(t, tl3, titles[0])
+
+		titles, err = store.GetMany(ctx, []int{tl3.ID, tl.ID, 1000})
+		require.NoError(t, err)
+		require.Len(t, titles, 2)
+		assert.Equal(t, tl, titles[0])
+		assert.Equal(t, tl3, titles[1])
+	})
+
+	t.Run("CharacterTombstones", func(t *testing.T) {
+		store := ds.CharacterTombstones()
+
+		dead, err := store.Check(ctx, id)
+		require.NoError(t, err)
+		assert.False(t, dead)
+
+		require.NoError(t, store.Create(ctx, id))
+		require.NoError(t, store.Create(ctx, id))
+
+		dead, err = store.Check(ctx, id)
+		require.NoError(t, err)
+		assert.True(t, dead)
+
+		ts, err := store.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, id, ts.ID)
+
+		require.NoError(t, store.Create(ctx, id+1))
+		tss, err := store.List(ctx, id, 10)
+		require.NoError(t, err)
+		require.Len(t, tss, 1)
+		assert.Equal(t, id+1, tss[0].ID)
+	})
+
+	t.Run("Levels", func(t *testing.T) {
+		store := ds.Levels()
+
+		lvl, err := store.Get(ctx, id, PLD)
+		require.NoError(t, err)
+		assert.Equal(t, 0, lvl.Level)
+
+		require.NoError(t, store.Set(ctx, &Level{CharacterID: id, Job: PLD, Level: 30}))
+		require.NoError(t, store.Set(ctx, &Level{CharacterID: id, Job: PLD, Level: 31}))
+
+		lvl, err = store.Get(ctx, id, PLD)
+		require.NoError(t, err)
+		assert.Equal(t, 31, lvl.Level)
+
+		require.NoError(t, store.Set(ctx, &Level{CharacterID: id, Job: WAR, Level: 20}))
+		lvls, err := store.List(ctx, id)
+		require.NoError(t, err)
+		require.Len(t, lvls, 2)
+		assert.Equal(t, PLD, lvls[0].Job)
+		assert.Equal(t, WAR, lvls[1].Job)
+
+		require.NoError(t, store.Set(ctx, &Level{CharacterID: 1, Job: AST, Level: 50}))
+		lvls, err = store.ListMany(ctx, []int64{id, 1, id})
+		require.NoError(t, err)
+		require.Len(t, lvls, 3)
+		assert.Equal(t, int64(1), lvls[0].CharacterID)
+		assert.Equal(t, id, lvls[1].CharacterID)
+		assert.Equal(t, PLD, lvls[1].Job)
+
+		require.NoError(t, store.SetAll(ctx, []*Level{
+			{CharacterID: id, Job: PLD, Level: 40},
+			{CharacterID: id, Job: PLD, Level: 50},
+			{CharacterID: 1, Job: AST, Level: 51},
+		}))
+		lvls, err = store.ListMany(ctx, []int64{id, 1})
+		require.NoError(t, err)
+		require.Len(t, lvls, 3)
+		assert.Equal(t, 51, lvls[0].Level)
+		assert.Equal(t, 50, lvls[1].Level)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		testCharacterSearch(t, NewMemoryDataStore())
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		testStats(t, NewMemoryDataStore())
+	})
+
+	t.Run("Census", func(t *testing.T) {
+		testCensus(t, NewMemoryDataStore())
+	})
+
+	t.Run("ParseFailures", func(t *testing.T) {
+		testParseFailures(t, NewMemoryDataStore())
+	})
+}
@@ -12,6 +12,9 @@ type MockDataStore struct {
 	CharacterTombstoneStore *MockCharacterTombstoneStore
 	CharacterTitleStore     *MockCharacterTitleStore
 	LevelStore              *MockLevelStore
+	StatsStore              *MockStatsStore
+	CensusStore             *MockCensusStore
+	ParseFailureStore       *MockParseFailureStore
 }
 
 // NewMockDataStore creates a new DataStore, full of mock implementations of data stores.
@@ -21,6 +24,9 @@ func NewMockDataStore(ctrl *gomock.Controller) *MockDataStore {
 		CharacterTombstoneStore: NewMockCharacterTombstoneStore(ctrl),
 		CharacterTitleStore:     NewMockCharacterTitleStore(ctrl),
 		LevelStore:              NewMockLevelStore(ctrl),
+		StatsStore:              NewMockStatsStore(ctrl),
+		CensusStore:             NewMockCensusStore(ctrl),
+		ParseFailureStore:       NewMockParseFailureStore(ctrl),
 	}
 }
 
@@ -43,3 +49,18 @@ func (ds *MockDataStore) CharacterTitles() CharacterTitleStore {
 func (ds *MockDataStore) Levels() LevelStore {
 	return ds.LevelStore
 }
+
+// Stats implements the DataStore interface.
+func (ds *MockDataStore) Stats() StatsStore {
+	return ds.StatsStore
+}
+
+// Census implements the DataStore interface.
+func (ds *MockDataStore) Census() CensusStore {
+	return ds.CensusStore
+}
+
+// ParseFailures implements the DataStore interface.
+func (ds *MockDataStore) ParseFailures() ParseFailureStore {
+	return ds.ParseFailureStore
+}
@@ -0,0 +1,335 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/liclac/gubal/lib"
+)
+
+// tracer creates spans for store calls.
+var tracer = otel.Tracer("github.com/liclac/gubal/models")
+
+// NewTracedDataStore wraps a DataStore, so every call to one of its stores is traced as a span
+// named after the method, eg. "CharacterStore.Save", under whatever span is in its context.
+func NewTracedDataStore(ds DataStore) DataStore {
+	return tracedDataStore{ds}
+}
+
+type tracedDataStore struct{ ds DataStore }
+
+func (t tracedDataStore) Characters() CharacterStore {
+	return tracedCharacterStore{t.ds.Characters()}
+}
+
+func (t tracedDataStore) CharacterTombstones() CharacterTombstoneStore {
+	return tracedCharacterTombstoneStore{t.ds.CharacterTombstones()}
+}
+
+func (t tracedDataStore) CharacterTitles() CharacterTitleStore {
+	return tracedCharacterTitleStore{t.ds.CharacterTitles()}
+}
+
+func (t tracedDataStore) Levels() LevelStore {
+	return tracedLevelStore{t.ds.Levels()}
+}
+
+func (t tracedDataStore) Stats() StatsStore {
+	return tracedStatsStore{t.ds.Stats()}
+}
+
+func (t tracedDataStore) Census() CensusStore {
+	return tracedCensusStore{t.ds.Census()}
+}
+
+func (t tracedDataStore) ParseFailures() ParseFailureStore {
+	return tracedParseFailureStore{t.ds.ParseFailures()}
+}
+
+// startSpan starts a span for a store call.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+}
+
+type tracedCharacterStore struct{ s CharacterStore }
+
+func (t tracedCharacterStore) Get(ctx context.Context, cID int64) (*Character, error) {
+	ctx, span := startSpan(ctx, "CharacterStore.Get")
+	v, err := t.s.Get(ctx, cID)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedCharacterStore) Save(ctx context.Context, ch *Character) error {
+	ctx, span := startSpan(ctx, "CharacterStore.Save")
+	err := t.s.Save(ctx, ch)
+	lib.EndSpan(span, err)
+	return err
+}
+
+func (t tracedCharacterStore) SaveAll(ctx context.Context, chs []*Character) error {
+	ctx, span := startSpan(ctx, "CharacterStore.SaveAll")
+	err := t.s.SaveAll(ctx, chs)
+	lib.EndSpan(span, err)
+	return err
+}
+
+func (t tracedCharacterStore) Touch(ctx context.Context, cID int64) error {
+	ctx, span := startSpan(ctx, "CharacterStore.Touch")
+	err := t.s.Touch(ctx, cID)
+	lib.EndSpan(span, err)
+	return err
+}
+
+func (t tracedCharacterStore) Search(ctx context.Context, q CharacterQuery) ([]*Character, error) {
+	ctx, span := startSpan(ctx, "CharacterStore.Search")
+	v, err := t.s.Search(ctx, q)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedCharacterStore) SearchTitles(ctx context.Context, q CharacterQuery, titleIDs []int) (map[int][]*Character, error) {
+	ctx, span := startSpan(ctx, "CharacterStore.SearchTitles")
+	v, err := t.s.SearchTitles(ctx, q, titleIDs)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+type tracedCharacterTombstoneStore struct{ s CharacterTombstoneStore }
+
+func (t tracedCharacterTombstoneStore) Create(ctx context.Context, cID int64) error {
+	ctx, span := startSpan(ctx, "CharacterTombstoneStore.Create")
+	err := t.s.Create(ctx, cID)
+	lib.EndSpan(span, err)
+	return err
+}
+
+func (t tracedCharacterTombstoneStore) Check(ctx context.Context, cID int64) (bool, error) {
+	ctx, span := startSpan(ctx, "CharacterTombstoneStore.Check")
+	v, err := t.s.Check(ctx, cID)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedCharacterTombstoneStore) Get(ctx context.Context, cID int64) (*CharacterTombstone, error) {
+	ctx, span := startSpan(ctx, "CharacterTombstoneStore.Get")
+	v, err := t.s.Get(ctx, cID)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedCharacterTombstoneStore) List(ctx context.Context, after int64, limit int) ([]*CharacterTombstone, error) {
+	ctx, span := startSpan(ctx, "CharacterTombstoneStore.List")
+	v, err := t.s.List(ctx, after, limit)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+type tracedCharacterTitleStore struct{ s CharacterTitleStore }
+
+func (t tracedCharacterTitleStore) GetOrCreate(ctx context.Context, title string) (*CharacterTitle, error) {
+	ctx, span := startSpan(ctx, "CharacterTitleStore.GetOrCreate")
+	v, err := t.s.GetOrCreate(ctx, title)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedCharacterTitleStore) Get(ctx context.Context, id int) (*CharacterTitle, error) {
+	ctx, span := startSpan(ctx, "CharacterTitleStore.Get")
+	v, err := t.s.Get(ctx, id)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedCharacterTitleStore) GetMany(ctx context.Context, ids []int) ([]*CharacterTitle, error) {
+	ctx, span := startSpan(ctx, "CharacterTitleStore.GetMany")
+	v, err := t.s.GetMany(ctx, ids)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedCharacterTitleStore) List(ctx context.Context, after int, limit int) ([]*CharacterTitle, error) {
+	ctx, span := startSpan(ctx, "CharacterTitleStore.List")
+	v, err := t.s.List(ctx, after, limit)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+type tracedLevelStore struct{ s LevelStore }
+
+func (t tracedLevelStore) Get(ctx context.Context, cID int64, job Job) (*Level, error) {
+	ctx, span := startSpan(ctx, "LevelStore.Get")
+	v, err := t.s.Get(ctx, cID, job)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedLevelStore) Set(ctx context.Context, lvl *Level) error {
+	ctx, span := startSpan(ctx, "LevelStore.Set")
+	err := t.s.Set(ctx, lvl)
+	lib.EndSpan(span, err)
+	return err
+}
+
+func (t tracedLevelStore) SetAll(ctx context.Context, lvls []*Level) error {
+	ctx, span := startSpan(ctx, "LevelStore.SetAll")
+	err := t.s.SetAll(ctx, lvls)
+	lib.EndSpan(span, err)
+	return err
+}
+
+func (t tracedLevelStore) List(ctx context.Context, cID int64) ([]*Level, error) {
+	ctx, span := startSpan(ctx, "LevelStore.List")
+	v, err := t.s.List(ctx, cID)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedLevelStore) ListMany(ctx context.Context, cIDs []int64) ([]*Level, error) {
+	ctx, span := startSpan(ctx, "LevelStore.ListMany")
+	v, err := t.s.ListMany(ctx, cIDs)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+type tracedStatsStore struct{ s StatsStore }
+
+func (t tracedStatsStore) Genders(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	ctx, span := startSpan(ctx, "StatsStore.Genders")
+	v, err := t.s.Genders(ctx, f)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedStatsStore) Races(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	ctx, span := startSpan(ctx, "StatsStore.Races")
+	v, err := t.s.Races(ctx, f)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedStatsStore) Clans(ctx context.Context, f StatsFilter) ([]ClanStatsCount, error) {
+	ctx, span := startSpan(ctx, "StatsStore.Clans")
+	v, err := t.s.Clans(ctx, f)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedStatsStore) Levels(ctx context.Context, f StatsFilter) ([]LevelStatsCount, error) {
+	ctx, span := startSpan(ctx, "StatsStore.Levels")
+	v, err := t.s.Levels(ctx, f)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedStatsStore) GrandCompanies(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	ctx, span := startSpan(ctx, "StatsStore.GrandCompanies")
+	v, err := t.s.GrandCompanies(ctx, f)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedStatsStore) GrandCompanyRanks(ctx context.Context, f StatsFilter) ([]GCRankStatsCount, error) {
+	ctx, span := startSpan(ctx, "StatsStore.GrandCompanyRanks")
+	v, err := t.s.GrandCompanyRanks(ctx, f)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedStatsStore) Worlds(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	ctx, span := startSpan(ctx, "StatsStore.Worlds")
+	v, err := t.s.Worlds(ctx, f)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedStatsStore) TopTitles(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error) {
+	ctx, span := startSpan(ctx, "StatsStore.TopTitles")
+	v, err := t.s.TopTitles(ctx, f, limit)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedStatsStore) TopFirstNames(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error) {
+	ctx, span := startSpan(ctx, "StatsStore.TopFirstNames")
+	v, err := t.s.TopFirstNames(ctx, f, limit)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedStatsStore) TopLastNames(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error) {
+	ctx, span := startSpan(ctx, "StatsStore.TopLastNames")
+	v, err := t.s.TopLastNames(ctx, f, limit)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedStatsStore) Refresh(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "StatsStore.Refresh")
+	err := t.s.Refresh(ctx)
+	lib.EndSpan(span, err)
+	return err
+}
+
+func (t tracedStatsStore) RefreshedAt(ctx context.Context) (time.Time, error) {
+	ctx, span := startSpan(ctx, "StatsStore.RefreshedAt")
+	v, err := t.s.RefreshedAt(ctx)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+type tracedCensusStore struct{ s CensusStore }
+
+func (t tracedCensusStore) Snapshot(ctx context.Context, date time.Time) error {
+	ctx, span := startSpan(ctx, "CensusStore.Snapshot")
+	err := t.s.Snapshot(ctx, date)
+	lib.EndSpan(span, err)
+	return err
+}
+
+func (t tracedCensusStore) List(ctx context.Context) ([]*CensusSnapshot, error) {
+	ctx, span := startSpan(ctx, "CensusStore.List")
+	v, err := t.s.List(ctx)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedCensusStore) Trend(ctx context.Context, metric CensusMetric, f StatsFilter, from time.Time, to time.Time) ([]CensusPoint, error) {
+	ctx, span := startSpan(ctx, "CensusStore.Trend")
+	v, err := t.s.Trend(ctx, metric, f, from, to)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+type tracedParseFailureStore struct{ s ParseFailureStore }
+
+func (t tracedParseFailureStore) Save(ctx context.Context, f *ParseFailure) error {
+	ctx, span := startSpan(ctx, "ParseFailureStore.Save")
+	err := t.s.Save(ctx, f)
+	lib.EndSpan(span, err)
+	return err
+}
+
+func (t tracedParseFailureStore) Get(ctx context.Context, cID int64) (*ParseFailure, error) {
+	ctx, span := startSpan(ctx, "ParseFailureStore.Get")
+	v, err := t.s.Get(ctx, cID)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedParseFailureStore) List(ctx context.Context, after int64, limit int, beforeVersion int) ([]*ParseFailure, error) {
+	ctx, span := startSpan(ctx, "ParseFailureStore.List")
+	v, err := t.s.List(ctx, after, limit, beforeVersion)
+	lib.EndSpan(span, err)
+	return v, err
+}
+
+func (t tracedParseFailureStore) Delete(ctx context.Context, cID int64) error {
+	ctx, span := startSpan(ctx, "ParseFailureStore.Delete")
+	err := t.s.Delete(ctx, cID)
+	lib.EndSpan(span, err)
+	return err
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	testSpansOnce     sync.Once
+	testSpansRecorder *tracetest.SpanRecorder
+)
+
+// testSpans installs a global tracer provider recording every span, and returns a context with a
+// new root span in it, and a function returning the spans ended under it so far.
+func testSpans(t *testing.T) (context.Context, func() []sdktrace.ReadOnlySpan) {
+	testSpansOnce.Do(func() {
+		testSpansRecorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(testSpansRecorder)))
+	})
+	ctx, span := otel.Tracer("test").Start(context.Background(), t.Name())
+	t.Cleanup(func() { span.End() })
+	traceID := span.SpanContext().TraceID()
+	return ctx, func() []sdktrace.ReadOnlySpan {
+		var spans []sdktrace.ReadOnlySpan
+		for _, s := range testSpansRecorder.Ended() {
+			if s.SpanContext().TraceID() == traceID {
+				spans = append(spans, s)
+			}
+		}
+		return spans
+	}
+}
+
+func TestTracedDataStore(t *testing.T) {
+	ctx, spans := testSpans(t)
+	parent := trace.SpanContextFromContext(ctx)
+	ds := NewTracedDataStore(NewMemoryDataStore())
+
+	require.NoError(t, ds.Characters().Save(ctx, &Character{ID: 1, FirstName: "Emi"}))
+	require.NoError(t, ds.Levels().SetAll(ctx, []*Level{{CharacterID: 1, Job: PLD, Level: 70}}))
+	ch, err := ds.Characters().Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Emi", ch.FirstName)
+	_, err = ds.CharacterTombstones().Get(ctx, 1)
+	require.Error(t, err)
+
+	recorded := spans()
+	require.Len(t, recorded, 4)
+	for i, name := range []string{
+		"CharacterStore.Save",
+		"LevelStore.SetAll",
+		"CharacterStore.Get",
+		"CharacterTombstoneStore.Get",
+	} {
+		assert.Equal(t, name, recorded[i].Name())
+		assert.Equal(t, parent.SpanID(), recorded[i].Parent().SpanID(), name)
+		assert.Equal(t, trace.SpanKindClient, recorded[i].SpanKind(), name)
+	}
+	assert.Equal(t, codes.Unset, recorded[0].Status().Code)
+	assert.Equal(t, codes.Error, recorded[3].Status().Code)
+	assert.Equal(t, err.Error(), recorded[3].Status().Description)
+}
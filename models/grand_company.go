@@ -9,3 +9,20 @@ const (
 	Adders    GrandCompany = "Adders"
 	Flames    GrandCompany = "Flames"
 )
+
+// GrandCompanies lists every known GrandCompany.
+var GrandCompanies = []GrandCompany{
+	Maelstrom,
+	Adders,
+	Flames,
+}
+
+// Valid returns whether this is a known GrandCompany.
+func (v GrandCompany) Valid() bool {
+	for _, known := range GrandCompanies {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
@@ -32,3 +32,53 @@ const (
 	BOT Job = "BOT"
 	FSH Job = "FSH"
 )
+
+// Jobs lists every Job, in the order stores sort them in.
+var Jobs = []Job{
+	PLD,
+	WAR,
+	DRK,
+	WHM,
+	SCH,
+	AST,
+	MNK,
+	DRG,
+	NIN,
+	SAM,
+	BRD,
+	MCH,
+	BLM,
+	SMN,
+	RDM,
+	CRP,
+	BSM,
+	ARM,
+	GSM,
+	LTW,
+	WVR,
+	ALC,
+	CUL,
+	MIN,
+	BOT,
+	FSH,
+}
+
+// jobOrder maps every Job to its index in Jobs. Databases can't be relied on to sort jobs this way:
+// Postgres sorts its enum by declaration order, but SQLite sorts the names alphabetically.
+var jobOrder = func() map[Job]int {
+	order := make(map[Job]int, len(Jobs))
+	for i, job := range Jobs {
+		order[job] = i
+	}
+	return order
+}()
+
+// Valid returns whether this is a known Job.
+func (v Job) Valid() bool {
+	for _, known := range Jobs {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"context"
+	"sort"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -23,8 +25,18 @@ type Level struct {
 
 // A LevelStore is a data access layer for Levels.
 type LevelStore interface {
-	Get(cID int64, job Job) (*Level, error)
-	Set(lvl *Level) error
+	Get(ctx context.Context, cID int64, job Job) (*Level, error)
+	Set(ctx context.Context, lvl *Level) error
+
+	// SetAll is like Set for several levels at once, but in as few statements as possible. If the
+	// same job of a character is given more than once, the last one wins.
+	SetAll(ctx context.Context, lvls []*Level) error
+
+	// List returns all of a character's levels, ordered by job.
+	List(ctx context.Context, cID int64) ([]*Level, error)
+
+	// ListMany returns all levels of several characters, ordered by character ID, then job.
+	ListMany(ctx context.Context, cIDs []int64) ([]*Level, error)
 }
 
 type levelStore struct {
@@ -36,11 +48,84 @@ func NewLevelStore(db *gorm.DB) LevelStore {
 	return &levelStore{db}
 }
 
-func (s *levelStore) Get(cID int64, job Job) (*Level, error) {
+func (s *levelStore) Get(ctx context.Context, cID int64, job Job) (*Level, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	lvl := Level{CharacterID: cID, Job: job}
 	return &lvl, s.DB.FirstOrInit(&lvl, lvl).Error
 }
 
-func (s *levelStore) Set(lvl *Level) error {
+func (s *levelStore) Set(ctx context.Context, lvl *Level) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	return s.DB.Set("gorm:insert_option", `ON CONFLICT (character_id, job) DO UPDATE SET `+levelConflictAssignments).Create(lvl).Error
 }
+
+func (s *levelStore) SetAll(ctx context.Context, lvls []*Level) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	type key struct {
+		cID int64
+		job Job
+	}
+	now := time.Now()
+	idx := make(map[key]int, len(lvls))
+	rows := make([][]interface{}, 0, len(lvls))
+	for _, lvl := range lvls {
+		if lvl.CreatedAt.IsZero() {
+			lvl.CreatedAt = now
+		}
+		if lvl.UpdatedAt.IsZero() {
+			lvl.UpdatedAt = now
+		}
+		row := []interface{}{lvl.CharacterID, lvl.Job, lvl.CreatedAt, lvl.UpdatedAt, lvl.Level}
+		if i, ok := idx[key{lvl.CharacterID, lvl.Job}]; ok {
+			rows[i] = row
+			continue
+		}
+		idx[key{lvl.CharacterID, lvl.Job}] = len(rows)
+		rows = append(rows, row)
+	}
+	return upsertAll(s.DB, "levels", []string{"character_id", "job", "created_at", "updated_at", "level"},
+		"character_id, job", levelConflictAssignments, rows)
+}
+
+func (s *levelStore) List(ctx context.Context, cID int64) ([]*Level, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var lvls []*Level
+	if err := s.DB.Where(Level{CharacterID: cID}).Find(&lvls).Error; err != nil {
+		return nil, err
+	}
+	sortLevels(lvls)
+	return lvls, nil
+}
+
+func (s *levelStore) ListMany(ctx context.Context, cIDs []int64) ([]*Level, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var lvls []*Level
+	if len(cIDs) == 0 {
+		return lvls, nil
+	}
+	if err := s.DB.Where("character_id IN (?)", cIDs).Find(&lvls).Error; err != nil {
+		return nil, err
+	}
+	sortLevels(lvls)
+	return lvls, nil
+}
+
+// sortLevels sorts levels by character ID, then job, in the order of Jobs.
+func sortLevels(lvls []*Level) {
+	sort.Slice(lvls, func(i, j int) bool {
+		if a, b := lvls[i].CharacterID, lvls[j].CharacterID; a != b {
+			return a < b
+		}
+		return jobOrder[lvls[i].Job] < jobOrder[lvls[j].Job]
+	})
+}
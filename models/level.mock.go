@@ -5,6 +5,7 @@
 package models
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -33,26 +34,64 @@ func (m *MockLevelStore) EXPECT() *MockLevelStoreMockRecorder {
 }
 
 // Get mocks base method
-func (m *MockLevelStore) Get(cID int64, job Job) (*Level, error) {
-	ret := m.ctrl.Call(m, "Get", cID, job)
+func (m *MockLevelStore) Get(ctx context.Context, cID int64, job Job) (*Level, error) {
+	ret := m.ctrl.Call(m, "Get", ctx, cID, job)
 	ret0, _ := ret[0].(*Level)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get
-func (mr *MockLevelStoreMockRecorder) Get(cID, job interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLevelStore)(nil).Get), cID, job)
+func (mr *MockLevelStoreMockRecorder) Get(ctx, cID, job interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLevelStore)(nil).Get), ctx, cID, job)
 }
 
 // Set mocks base method
-func (m *MockLevelStore) Set(lvl *Level) error {
-	ret := m.ctrl.Call(m, "Set", lvl)
+func (m *MockLevelStore) Set(ctx context.Context, lvl *Level) error {
+	ret := m.ctrl.Call(m, "Set", ctx, lvl)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Set indicates an expected call of Set
-func (mr *MockLevelStoreMockRecorder) Set(lvl interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockLevelStore)(nil).Set), lvl)
+func (mr *MockLevelStoreMockRecorder) Set(ctx, lvl interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockLevelStore)(nil).Set), ctx, lvl)
+}
+
+// SetAll mocks base method
+func (m *MockLevelStore) SetAll(ctx context.Context, lvls []*Level) error {
+	ret := m.ctrl.Call(m, "SetAll", ctx, lvls)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAll indicates an expected call of SetAll
+func (mr *MockLevelStoreMockRecorder) SetAll(ctx, lvls interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAll", reflect.TypeOf((*MockLevelStore)(nil).SetAll), ctx, lvls)
+}
+
+// List mocks base method
+func (m *MockLevelStore) List(ctx context.Context, cID int64) ([]*Level, error) {
+	ret := m.ctrl.Call(m, "List", ctx, cID)
+	ret0, _ := ret[0].([]*Level)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockLevelStoreMockRecorder) List(ctx, cID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLevelStore)(nil).List), ctx, cID)
+}
+
+// ListMany mocks base method
+func (m *MockLevelStore) ListMany(ctx context.Context, cIDs []int64) ([]*Level, error) {
+	ret := m.ctrl.Call(m, "ListMany", ctx, cIDs)
+	ret0, _ := ret[0].([]*Level)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMany indicates an expected call of ListMany
+func (mr *MockLevelStoreMockRecorder) ListMany(ctx, cIDs interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMany", reflect.TypeOf((*MockLevelStore)(nil).ListMany), ctx, cIDs)
 }
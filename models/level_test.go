@@ -1,26 +1,82 @@
 package models
 
 import (
+	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestLevelStore(t *testing.T) {
-	tx := TestDB.Begin()
+	ctx := context.Background()
+	tx := testDB(t).Begin()
 	defer tx.Rollback()
 
 	// Create a test user.
 	chStore := NewCharacterStore(tx)
-	ch := &Character{ID: 12345, FirstName: "First", LastName: "Last"}
-	require.NoError(t, chStore.Save(ch))
+	ch := newTestCharacter(12345, "First", "Last")
+	require.NoError(t, chStore.Save(ctx, ch))
 
 	// Add a level.
 	store := NewLevelStore(tx)
 	lvl := &Level{CharacterID: ch.ID, Job: PLD, Level: 30}
-	require.NoError(t, store.Set(lvl))
+	require.NoError(t, store.Set(ctx, lvl))
 
 	// Update a level.
 	lvl.Level = 31
-	require.NoError(t, store.Set(lvl))
+	require.NoError(t, store.Set(ctx, lvl))
+
+	// List levels.
+	require.NoError(t, store.Set(ctx, &Level{CharacterID: ch.ID, Job: WAR, Level: 20}))
+	lvls, err := store.List(ctx, ch.ID)
+	require.NoError(t, err)
+	require.Len(t, lvls, 2)
+	assert.Equal(t, PLD, lvls[0].Job)
+	assert.Equal(t, 31, lvls[0].Level)
+	assert.Equal(t, WAR, lvls[1].Job)
+	assert.Equal(t, 20, lvls[1].Level)
+
+	// List levels for several characters.
+	ch2 := newTestCharacter(12346, "Other", "Last")
+	require.NoError(t, chStore.Save(ctx, ch2))
+	require.NoError(t, store.Set(ctx, &Level{CharacterID: ch2.ID, Job: AST, Level: 50}))
+	lvls, err = store.ListMany(ctx, []int64{ch2.ID, ch.ID, 1})
+	require.NoError(t, err)
+	require.Len(t, lvls, 3)
+	assert.Equal(t, ch.ID, lvls[0].CharacterID)
+	assert.Equal(t, PLD, lvls[0].Job)
+	assert.Equal(t, ch.ID, lvls[1].CharacterID)
+	assert.Equal(t, WAR, lvls[1].Job)
+	assert.Equal(t, ch2.ID, lvls[2].CharacterID)
+	assert.Equal(t, AST, lvls[2].Job)
+
+	// Set several levels at once, across statements, with one given twice.
+	var many []*Level
+	for i := int64(1); i <= int64(upsertBatchSize/len(Jobs)+1); i++ {
+		require.NoError(t, chStore.Save(ctx, newTestCharacter(20000+i, "Many", "Last")))
+		for _, job := range Jobs {
+			many = append(many, &Level{CharacterID: 20000 + i, Job: job, Level: 10})
+		}
+	}
+	require.Condition(t, func() bool { return len(many) > upsertBatchSize })
+	many = append(many,
+		&Level{CharacterID: ch.ID, Job: PLD, Level: 40},
+		&Level{CharacterID: ch.ID, Job: WAR, Level: 21},
+		&Level{CharacterID: ch.ID, Job: PLD, Level: 50},
+	)
+	require.NoError(t, store.SetAll(ctx, many))
+	lvls, err = store.List(ctx, ch.ID)
+	require.NoError(t, err)
+	require.Len(t, lvls, 2)
+	assert.Equal(t, 50, lvls[0].Level)
+	assert.Equal(t, 21, lvls[1].Level)
+	lvls, err = store.List(ctx, 20001)
+	require.NoError(t, err)
+	require.Len(t, lvls, len(Jobs))
+
+	// Jobs are in game order, not alphabetical, whatever the database thinks.
+	for i, lvl := range lvls {
+		assert.Equal(t, Jobs[i], lvl.Job)
+	}
 }
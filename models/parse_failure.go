@@ -0,0 +1,121 @@
+package models
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -package=models -source=parse_failure.go -destination=parse_failure.mock.go
+
+// ErrorList is a list of error messages, stored as a JSON array.
+type ErrorList []string
+
+// Value implements driver.Valuer.
+func (l ErrorList) Value() (driver.Value, error) {
+	if l == nil {
+		l = ErrorList{}
+	}
+	data, err := json.Marshal(l)
+	return string(data), err
+}
+
+// Scan implements sql.Scanner.
+func (l *ErrorList) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	case []byte:
+		return json.Unmarshal(v, l)
+	default:
+		return errors.Errorf("can't scan %T into an ErrorList", src)
+	}
+}
+
+// A ParseFailure is a character's page that the fetcher couldn't parse. It's quarantined with the
+// raw page, rather than retried forever with the same outcome, so it can be re-parsed once the
+// parser's been fixed. Region is the Lodestone region the page came from, which determines what
+// language it's in. PK is CharacterID.
+type ParseFailure struct {
+	CharacterID   int64     `json:"character_id" gorm:"primary_key"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Region        string    `json:"region"`
+	ParserVersion int       `json:"parser_version"`
+	Errors        ErrorList `json:"errors"`
+	HTML          string    `json:"html,omitempty" gorm:"column:html"`
+}
+
+// ParseFailureStore is a data access layer for ParseFailures.
+type ParseFailureStore interface {
+	// Save creates or replaces a character's ParseFailure.
+	Save(ctx context.Context, f *ParseFailure) error
+
+	// Get returns a character's ParseFailure, including its HTML.
+	Get(ctx context.Context, cID int64) (*ParseFailure, error)
+
+	// List returns up to limit ParseFailures with character IDs greater than after, ordered by ID,
+	// without their HTML. If beforeVersion isn't 0, only failures recorded by older parser
+	// versions are returned.
+	List(ctx context.Context, after int64, limit int, beforeVersion int) ([]*ParseFailure, error)
+
+	// Delete deletes a character's ParseFailure, if it has one.
+	Delete(ctx context.Context, cID int64) error
+}
+
+type parseFailureStore struct {
+	DB *gorm.DB
+}
+
+// NewParseFailureStore creates a new ParseFailureStore.
+func NewParseFailureStore(db *gorm.DB) ParseFailureStore {
+	return &parseFailureStore{db}
+}
+
+func (s *parseFailureStore) Save(ctx context.Context, f *ParseFailure) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.UpdatedAt = time.Now()
+	return s.DB.Set("gorm:insert_option", `ON CONFLICT (character_id) DO UPDATE SET
+		updated_at = EXCLUDED.updated_at,
+		region = EXCLUDED.region,
+		parser_version = EXCLUDED.parser_version,
+		errors = EXCLUDED.errors,
+		html = EXCLUDED.html`).Create(f).Error
+}
+
+func (s *parseFailureStore) Get(ctx context.Context, cID int64) (*ParseFailure, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var f ParseFailure
+	if err := s.DB.First(&f, ParseFailure{CharacterID: cID}).Error; err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (s *parseFailureStore) List(ctx context.Context, after int64, limit int, beforeVersion int) ([]*ParseFailure, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	db := s.DB.Select("character_id, created_at, updated_at, region, parser_version, errors").
+		Where("character_id > ?", after)
+	if beforeVersion != 0 {
+		db = db.Where("parser_version < ?", beforeVersion)
+	}
+	fs := []*ParseFailure{}
+	return fs, db.Order("character_id").Limit(limit).Find(&fs).Error
+}
+
+func (s *parseFailureStore) Delete(ctx context.Context, cID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.DB.Exec(`DELETE FROM parse_failures WHERE character_id = ?`, cID).Error
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFailureStore(t *testing.T) {
+	tx := testDB(t).Begin()
+	defer tx.Rollback()
+
+	testParseFailures(t, NewDataStore(tx))
+}
+
+// testParseFailures tests a ParseFailureStore against any DataStore.
+func testParseFailures(t *testing.T, ds DataStore) {
+	ctx := context.Background()
+	store := ds.ParseFailures()
+
+	_, err := store.Get(ctx, 1)
+	assert.True(t, gorm.IsRecordNotFoundError(err))
+	require.NoError(t, store.Delete(ctx, 1))
+
+	require.NoError(t, store.Save(ctx, &ParseFailure{
+		CharacterID:   1,
+		Region:        "jp",
+		ParserVersion: 1,
+		Errors:        ErrorList{"unknown world: 'Nowhere'", "unknown job: 'Blue Mage'"},
+		HTML:          "<html>1</html>",
+	}))
+	require.NoError(t, store.Save(ctx, &ParseFailure{
+		CharacterID:   2,
+		Region:        "na",
+		ParserVersion: 2,
+		Errors:        ErrorList{"unknown guardian: ''"},
+		HTML:          "<html>2</html>",
+	}))
+
+	f, err := store.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "jp", f.Region)
+	assert.Equal(t, 1, f.ParserVersion)
+	assert.Equal(t, ErrorList{"unknown world: 'Nowhere'", "unknown job: 'Blue Mage'"}, f.Errors)
+	assert.Equal(t, "<html>1</html>", f.HTML)
+	assert.False(t, f.CreatedAt.IsZero())
+	createdAt := f.CreatedAt
+
+	t.Run("List", func(t *testing.T) {
+		fs, err := store.List(ctx, 0, 10, 0)
+		require.NoError(t, err)
+		if assert.Len(t, fs, 2) {
+			assert.Equal(t, int64(1), fs[0].CharacterID)
+			assert.Equal(t, int64(2), fs[1].CharacterID)
+			assert.Equal(t, ErrorList{"unknown guardian: ''"}, fs[1].Errors)
+			assert.Equal(t, "na", fs[1].Region)
+			assert.Empty(t, fs[0].HTML, "List shouldn't load pages")
+		}
+
+		fs, err = store.List(ctx, 1, 10, 0)
+		require.NoError(t, err)
+		if assert.Len(t, fs, 1) {
+			assert.Equal(t, int64(2), fs[0].CharacterID)
+		}
+
+		fs, err = store.List(ctx, 0, 1, 0)
+		require.NoError(t, err)
+		assert.Len(t, fs, 1)
+
+		fs, err = store.List(ctx, 0, 10, 2)
+		require.NoError(t, err)
+		if assert.Len(t, fs, 1) {
+			assert.Equal(t, int64(1), fs[0].CharacterID)
+		}
+	})
+
+	// Saving an existing failure should replace everything but its creation time.
+	t.Run("Replace", func(t *testing.T) {
+		require.NoError(t, store.Save(ctx, &ParseFailure{
+			CharacterID:   1,
+			Region:        "eu",
+			ParserVersion: 2,
+			Errors:        ErrorList{"unknown job: 'Blue Mage'"},
+			HTML:          "<html>1b</html>",
+		}))
+		f, err := store.Get(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "eu", f.Region)
+		assert.Equal(t, 2, f.ParserVersion)
+		assert.Equal(t, ErrorList{"unknown job: 'Blue Mage'"}, f.Errors)
+		assert.Equal(t, "<html>1b</html>", f.HTML)
+		assert.True(t, createdAt.Equal(f.CreatedAt))
+		assert.False(t, f.UpdatedAt.Before(f.CreatedAt))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, 1))
+		_, err := store.Get(ctx, 1)
+		assert.True(t, gorm.IsRecordNotFoundError(err))
+
+		fs, err := store.List(ctx, 0, 10, 0)
+		require.NoError(t, err)
+		assert.Len(t, fs, 1)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		assert.Equal(t, context.Canceled, store.Save(ctx, &ParseFailure{CharacterID: 3}))
+		_, err := store.Get(ctx, 2)
+		assert.Equal(t, context.Canceled, err)
+		_, err = store.List(ctx, 0, 10, 0)
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, context.Canceled, store.Delete(ctx, 2))
+	})
+}
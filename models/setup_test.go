@@ -3,54 +3,119 @@ package models
 import (
 	"os"
 	"path"
+	"strings"
+	"sync"
+	"testing"
 
 	"github.com/jinzhu/gorm"
 	"github.com/mattes/migrate"
+	"github.com/pkg/errors"
 
 	_ "github.com/jinzhu/gorm/dialects/postgres"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
 	_ "github.com/mattes/migrate/database/postgres"
+	_ "github.com/mattes/migrate/database/sqlite3"
 	_ "github.com/mattes/migrate/source/file"
 )
 
-// TestDB is the database used for testing.
-var TestDB *gorm.DB
+var (
+	testDBOnce sync.Once
+	testDBConn *gorm.DB
+	testDBErr  error
+)
 
-func must(err error) {
-	if err != nil {
-		panic(err)
+// testDB returns the database used for testing, setting it up the first time it's called.
+// Tests that need it are skipped with -short or GUBAL_SKIP_DB_TESTS=1, so tests that don't (eg. the
+// in-memory DataStore's) can run without one; otherwise, not being able to set it up is an error.
+func testDB(t *testing.T) *gorm.DB {
+	if testing.Short() || os.Getenv("GUBAL_SKIP_DB_TESTS") != "" {
+		t.Skip("skipping test that needs a database")
 	}
+	testDBOnce.Do(func() { testDBConn, testDBErr = setupTestDB() })
+	if testDBErr != nil {
+		t.Fatalf("couldn't set up test database: %v", testDBErr)
+	}
+	return testDBConn
 }
 
-func init() {
-	// Override the test DB URI with TEST_DB_URI!
+// setupTestDB connects to the test database, wipes it and migrates it.
+func setupTestDB() (*gorm.DB, error) {
+	// Override the test DB URI with TEST_DB_URI! Both postgres:// and sqlite3:// are supported.
 	// -- DO NOT USE A PRODUCTION DATABASE; IT WILL BE WIPED --
 	uri := os.Getenv("TEST_DB_URI")
 	if uri == "" {
 		uri = "postgres:///gubal_test?sslmode=disable"
 	}
 
-	// Connect to the database!
-	db, err := gorm.Open("postgres", uri)
-	must(err)
-	TestDB = db.LogMode(true).Debug()
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	migrationsDir := path.Join(wd, "..", "migrations")
 
-	// Nuke the entire default schema.
-	must(db.Exec(`DROP SCHEMA IF EXISTS public CASCADE`).Error)
-	must(db.Exec(`CREATE SCHEMA public`).Error)
+	// Connect to the database, and nuke its entire contents!
+	var db *gorm.DB
+	if strings.HasPrefix(uri, "sqlite3://") {
+		filename := strings.TrimPrefix(uri, "sqlite3://")
+		if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if db, err = gorm.Open("sqlite3", filename); err != nil {
+			return nil, err
+		}
+		if err := db.Exec(`PRAGMA foreign_keys = ON`).Error; err != nil {
+			return nil, err
+		}
+		db.DB().SetMaxOpenConns(1)
+		migrationsDir = path.Join(migrationsDir, "sqlite3")
+	} else {
+		if db, err = gorm.Open("postgres", uri); err != nil {
+			return nil, err
+		}
+		if err := db.Exec(`DROP SCHEMA IF EXISTS public CASCADE`).Error; err != nil {
+			return nil, err
+		}
+		if err := db.Exec(`CREATE SCHEMA public`).Error; err != nil {
+			return nil, err
+		}
+	}
+	db = db.LogMode(true).Debug()
 
 	// Read migrations...
-	wd, err := os.Getwd()
-	must(err)
-	migr, err := migrate.New("file://"+path.Join(wd, "..", "migrations"), uri)
-	must(err)
+	migr, err := migrate.New("file://"+migrationsDir, uri)
+	if err != nil {
+		return nil, err
+	}
 
 	// Migrate up, down, then back up to verify that both ways are working.
-	must(migr.Up())
-	must(migr.Down())
-	must(migr.Up())
+	for _, step := range []struct {
+		name string
+		fn   func() error
+	}{{"up", migr.Up}, {"down", migr.Down}, {"up again", migr.Up}} {
+		if err := step.fn(); err != nil {
+			return nil, errors.Wrapf(err, "migrating %s", step.name)
+		}
+	}
 
 	// Finish!
 	srcerr, dberr := migr.Close()
-	must(srcerr)
-	must(dberr)
+	if srcerr != nil {
+		return nil, srcerr
+	}
+	return db, dberr
+}
+
+// newTestCharacter returns a character with valid values for every required column.
+func newTestCharacter(id int64, firstName, lastName string) *Character {
+	return &Character{
+		ID:        id,
+		FirstName: firstName,
+		LastName:  lastName,
+		Race:      AuRa,
+		Clan:      AuRaRaen,
+		Gender:    "♀",
+		Guardian:  Oschon,
+		CityState: Gridania,
+		World:     Ultros,
+	}
 }
@@ -0,0 +1,478 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -package=models -source=stats.go -destination=stats.mock.go
+
+// LevelMilestones are the levels characters tend to pile up at: the starting level, and the level
+// caps of the various expansions. Level stats are bucketed into each of these, and the ranges
+// between them; anything above the last is one open-ended bucket.
+var LevelMilestones = []int{1, 15, 30, 50, 60, 70}
+
+// LevelBucket returns the bucket a level falls into, as an inclusive range. A max of 0 means there's
+// no upper bound. Levels below the first milestone aren't in any bucket, and return (0, 0).
+func LevelBucket(lvl int) (min, max int) {
+	for i, m := range LevelMilestones {
+		switch {
+		case lvl < m:
+			if i == 0 {
+				return 0, 0
+			}
+			return LevelMilestones[i-1] + 1, m - 1
+		case lvl == m:
+			return m, m
+		}
+	}
+	return LevelMilestones[len(LevelMilestones)-1] + 1, 0
+}
+
+// levelBucketSQL is an SQL expression for the lower bound of a level's bucket; see LevelBucket().
+var levelBucketSQL = func() string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for i, m := range LevelMilestones {
+		fmt.Fprintf(&b, " WHEN levels.level = %d THEN %d", m, m)
+		if i+1 < len(LevelMilestones) {
+			fmt.Fprintf(&b, " WHEN levels.level > %d AND levels.level < %d THEN %d", m, LevelMilestones[i+1], m+1)
+		} else {
+			fmt.Fprintf(&b, " WHEN levels.level > %d THEN %d", m, m+1)
+		}
+	}
+	b.WriteString(" END")
+	return b.String()
+}()
+
+// dataCenterSQL is an SQL expression for the data center of a character's world.
+var dataCenterSQL = func() string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for _, dc := range DataCenters {
+		worlds := make([]string, len(dc.Worlds()))
+		for i, w := range dc.Worlds() {
+			worlds[i] = "'" + string(w) + "'"
+		}
+		fmt.Fprintf(&b, " WHEN characters.world IN (%s) THEN '%s'", strings.Join(worlds, ", "), dc)
+	}
+	b.WriteString(" END")
+	return b.String()
+}()
+
+// StatsTopLimit is how long precomputed top lists are; asking for more returns at most this many.
+const StatsTopLimit = 100
+
+// clampTopLimit returns the number of entries to return from a top list; anything out of range
+// means StatsTopLimit.
+func clampTopLimit(limit int) int {
+	if limit <= 0 || limit > StatsTopLimit {
+		return StatsTopLimit
+	}
+	return limit
+}
+
+// A StatsFilter narrows down which characters stats are computed over; zero values match everything.
+type StatsFilter struct {
+	World      World
+	DataCenter DataCenter
+}
+
+// Validate returns an error if the filter is invalid, eg. filters on an unknown world.
+func (f StatsFilter) Validate() error {
+	switch {
+	case f.World != "" && !f.World.Valid():
+		return errors.Errorf("unknown world: '%s'", f.World)
+	case f.DataCenter != "" && !f.DataCenter.Valid():
+		return errors.Errorf("unknown data center: '%s'", f.DataCenter)
+	}
+	return nil
+}
+
+// matches returns whether a character matches the filter.
+func (f StatsFilter) matches(ch *Character) bool {
+	return (f.World == "" || ch.World == f.World) &&
+		(f.DataCenter == "" || ch.World.DataCenter() == f.DataCenter)
+}
+
+// A StatsCount is the number of characters with a certain value, eg. a gender or a world.
+type StatsCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// A ClanStatsCount is the number of characters of a race, clan and gender.
+type ClanStatsCount struct {
+	Race   CharacterRace `json:"race"`
+	Clan   CharacterClan `json:"clan"`
+	Gender string        `json:"gender"`
+	Count  int64         `json:"count"`
+}
+
+// A LevelStatsCount is the number of characters with a level in a job within a bucket; see
+// LevelBucket(). A Max of 0 means there's no upper bound.
+type LevelStatsCount struct {
+	Job   Job   `json:"job"`
+	Min   int   `json:"min"`
+	Max   int   `json:"max"`
+	Count int64 `json:"count"`
+}
+
+// A GCRankStatsCount is the number of characters with a rank in a grand company.
+type GCRankStatsCount struct {
+	GC    GrandCompany `json:"gc"`
+	Rank  int          `json:"rank"`
+	Count int64        `json:"count"`
+}
+
+// A StatsStore computes aggregate statistics over characters. Breakdowns are ordered by value, top
+// lists by count, highest first, with ties broken by value.
+//
+// Scanning every character on every request is slow, so stats may be precomputed: they're then
+// only as fresh as the last call to Refresh(). Until the first one, they're counted up live.
+type StatsStore interface {
+	// Genders counts characters by gender.
+	Genders(ctx context.Context, f StatsFilter) ([]StatsCount, error)
+
+	// Races counts characters by race.
+	Races(ctx context.Context, f StatsFilter) ([]StatsCount, error)
+
+	// Clans counts characters by race, clan and gender.
+	Clans(ctx context.Context, f StatsFilter) ([]ClanStatsCount, error)
+
+	// Levels counts levels by job and bucket, ordered by job (as in Jobs), then bucket.
+	Levels(ctx context.Context, f StatsFilter) ([]LevelStatsCount, error)
+
+	// GrandCompanies counts characters by grand company; "" is characters who aren't in one.
+	GrandCompanies(ctx context.Context, f StatsFilter) ([]StatsCount, error)
+
+	// GrandCompanyRanks counts characters in grand companies by grand company and rank.
+	GrandCompanyRanks(ctx context.Context, f StatsFilter) ([]GCRankStatsCount, error)
+
+	// Worlds returns the number of characters on every world, most populous first.
+	Worlds(ctx context.Context, f StatsFilter) ([]StatsCount, error)
+
+	// TopTitles returns the most common titles.
+	TopTitles(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error)
+
+	// TopFirstNames returns the most common first names.
+	TopFirstNames(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error)
+
+	// TopLastNames returns the most common last names.
+	TopLastNames(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error)
+
+	// Refresh recomputes precomputed stats. Run it in a transaction, or readers may see them
+	// half-rebuilt.
+	Refresh(ctx context.Context) error
+
+	// RefreshedAt returns when stats were last refreshed, or a zero time if they're counted up live.
+	RefreshedAt(ctx context.Context) (time.Time, error)
+}
+
+type statsStore struct {
+	DB *gorm.DB
+}
+
+// NewStatsStore creates a new StatsStore.
+func NewStatsStore(db *gorm.DB) StatsStore {
+	return &statsStore{db}
+}
+
+// characterCountsSQL counts characters by every column of stats_character_counts.
+const characterCountsSQL = `SELECT world, race, clan, gender, gc, gc_rank, COUNT(*) AS count FROM characters
+	GROUP BY world, race, clan, gender, gc, gc_rank`
+
+// levelCountsSQL counts levels by world, job and bucket, for stats_level_counts.
+var levelCountsSQL = fmt.Sprintf(`SELECT characters.world, levels.job, %[1]s AS min, COUNT(*) AS count FROM levels
+	JOIN characters ON characters.id = levels.character_id
+	WHERE levels.level >= %[2]d
+	GROUP BY characters.world, levels.job, %[1]s`, levelBucketSQL, LevelMilestones[0])
+
+// table returns the table to read a breakdown from: the precomputed one, or if stats have never
+// been refreshed, a live count that looks just like it.
+func (s *statsStore) table(ctx context.Context, name, live string) (*gorm.DB, error) {
+	at, err := s.RefreshedAt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if at.IsZero() {
+		return s.DB.Table("(" + live + ") AS " + name), nil
+	}
+	return s.DB.Table(name), nil
+}
+
+// filter applies a StatsFilter to a query on one of the per-world stats tables.
+func (s *statsStore) filter(db *gorm.DB, f StatsFilter) *gorm.DB {
+	if f.World != "" {
+		db = db.Where("world = ?", f.World)
+	}
+	if f.DataCenter != "" {
+		db = db.Where("world IN (?)", f.DataCenter.Worlds())
+	}
+	return db
+}
+
+// countBy sums up character counts by an SQL expression. Values are cast to text, so enums sort the
+// same way in every database.
+func (s *statsStore) countBy(ctx context.Context, f StatsFilter, expr, order string) ([]StatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	db, err := s.table(ctx, "stats_character_counts", characterCountsSQL)
+	if err != nil {
+		return nil, err
+	}
+	counts := []StatsCount{}
+	return counts, s.filter(db, f).
+		Select("CAST(" + expr + " AS TEXT) AS value, SUM(count) AS count").
+		Group(expr).Order(order).
+		Scan(&counts).Error
+}
+
+// topCounts returns a precomputed top list.
+func (s *statsStore) topCounts(ctx context.Context, f StatsFilter, stat string, limit int) ([]StatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	counts := []StatsCount{}
+
+	// Top lists are stored for every world and every data center, but not every combination.
+	world, dc := string(f.World), string(f.DataCenter)
+	if f.World != "" {
+		if f.DataCenter != "" && f.World.DataCenter() != f.DataCenter {
+			return counts, nil
+		}
+		dc = ""
+	}
+
+	at, err := s.RefreshedAt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if at.IsZero() {
+		return counts, s.liveTopCounts(f, stat).Limit(clampTopLimit(limit)).Scan(&counts).Error
+	}
+	return counts, s.DB.Table("stats_top_counts").
+		Where("world = ? AND data_center = ? AND stat = ?", world, dc, stat).
+		Select("value, count").
+		Order("count DESC, value").
+		Limit(clampTopLimit(limit)).
+		Scan(&counts).Error
+}
+
+func (s *statsStore) Genders(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	return s.countBy(ctx, f, "gender", "value")
+}
+
+func (s *statsStore) Races(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	return s.countBy(ctx, f, "race", "value")
+}
+
+func (s *statsStore) Clans(ctx context.Context, f StatsFilter) ([]ClanStatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	db, err := s.table(ctx, "stats_character_counts", characterCountsSQL)
+	if err != nil {
+		return nil, err
+	}
+	counts := []ClanStatsCount{}
+	return counts, s.filter(db, f).
+		Select("CAST(race AS TEXT) AS race, CAST(clan AS TEXT) AS clan, gender, SUM(count) AS count").
+		Group("race, clan, gender").
+		Order("race, clan, gender").
+		Scan(&counts).Error
+}
+
+func (s *statsStore) Levels(ctx context.Context, f StatsFilter) ([]LevelStatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	db, err := s.table(ctx, "stats_level_counts", levelCountsSQL)
+	if err != nil {
+		return nil, err
+	}
+	counts := []LevelStatsCount{}
+	if err := s.filter(db, f).
+		Select("CAST(job AS TEXT) AS job, min, SUM(count) AS count").
+		Group("job, min").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	for i := range counts {
+		_, counts[i].Max = LevelBucket(counts[i].Min)
+	}
+	sortLevelStatsCounts(counts)
+	return counts, nil
+}
+
+func (s *statsStore) GrandCompanies(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	return s.countBy(ctx, f, "COALESCE(CAST(gc AS TEXT), '')", "value")
+}
+
+func (s *statsStore) GrandCompanyRanks(ctx context.Context, f StatsFilter) ([]GCRankStatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	db, err := s.table(ctx, "stats_character_counts", characterCountsSQL)
+	if err != nil {
+		return nil, err
+	}
+	counts := []GCRankStatsCount{}
+	return counts, s.filter(db, f).
+		Where("gc IS NOT NULL").
+		Select("CAST(gc AS TEXT) AS gc, gc_rank AS rank, SUM(count) AS count").
+		Group("gc, gc_rank").
+		Order("gc, rank").
+		Scan(&counts).Error
+}
+
+func (s *statsStore) Worlds(ctx context.Context, f StatsFilter) ([]StatsCount, error) {
+	return s.countBy(ctx, f, "world", "count DESC, value")
+}
+
+func (s *statsStore) TopTitles(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error) {
+	return s.topCounts(ctx, f, "title", limit)
+}
+
+func (s *statsStore) TopFirstNames(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error) {
+	return s.topCounts(ctx, f, "first_name", limit)
+}
+
+func (s *statsStore) TopLastNames(ctx context.Context, f StatsFilter, limit int) ([]StatsCount, error) {
+	return s.topCounts(ctx, f, "last_name", limit)
+}
+
+// topStats are the top lists that are precomputed, and the expressions they're counted by.
+var topStats = []struct{ Stat, Expr string }{
+	{"title", "character_titles.title"},
+	{"first_name", "characters.first_name"},
+	{"last_name", "characters.last_name"},
+}
+
+// liveTopCounts returns a query counting up a top list for characters matching a filter, for when
+// stats have never been refreshed.
+func (s *statsStore) liveTopCounts(f StatsFilter, stat string) *gorm.DB {
+	db := s.DB.Table("characters")
+	var expr string
+	for _, top := range topStats {
+		if top.Stat == stat {
+			expr = top.Expr
+		}
+	}
+	if stat == "title" {
+		db = db.Joins("JOIN character_titles ON character_titles.id = characters.title_id")
+	}
+	if f.World != "" {
+		db = db.Where("characters.world = ?", f.World)
+	}
+	if f.DataCenter != "" {
+		db = db.Where("characters.world IN (?)", f.DataCenter.Worlds())
+	}
+	return db.Select(expr + " AS value, COUNT(*) AS count").Group(expr).Order("count DESC, value")
+}
+
+func (s *statsStore) RefreshedAt(ctx context.Context) (time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return time.Time{}, err
+	}
+	var rows []struct{ RefreshedAt time.Time }
+	if err := s.DB.Table("stats_refreshes").Select("refreshed_at").Scan(&rows).Error; err != nil {
+		return time.Time{}, err
+	}
+	if len(rows) == 0 {
+		return time.Time{}, nil
+	}
+	return rows[0].RefreshedAt, nil
+}
+
+func (s *statsStore) Refresh(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, table := range []string{"stats_character_counts", "stats_level_counts", "stats_top_counts", "stats_refreshes"} {
+		if err := s.DB.Exec("DELETE FROM " + table).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := s.DB.Exec(`INSERT INTO stats_character_counts (world, race, clan, gender, gc, gc_rank, count) ` +
+		characterCountsSQL).Error; err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := s.DB.Exec(`INSERT INTO stats_level_counts (world, job, min, count) ` +
+		levelCountsSQL).Error; err != nil {
+		return err
+	}
+
+	// Top lists are ranked within each scope: everything, every data center, and every world.
+	for _, top := range topStats {
+		for _, scope := range []struct{ World, DataCenter string }{
+			{"''", "''"},
+			{"''", dataCenterSQL},
+			{"CAST(characters.world AS TEXT)", "''"},
+		} {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var partition []string
+			for _, expr := range []string{scope.World, scope.DataCenter} {
+				if expr != "''" {
+					partition = append(partition, expr)
+				}
+			}
+			group := strings.Join(append(partition, top.Expr), ", ")
+			over := "ORDER BY COUNT(*) DESC, " + top.Expr
+			if len(partition) > 0 {
+				over = "PARTITION BY " + strings.Join(partition, ", ") + " " + over
+			}
+			db := s.DB.Table("characters")
+			if top.Stat == "title" {
+				db = db.Joins("JOIN character_titles ON character_titles.id = characters.title_id")
+			}
+			ranked := db.Select(scope.World + " AS world, " + scope.DataCenter + " AS data_center, " +
+				top.Expr + " AS value, COUNT(*) AS count, ROW_NUMBER() OVER (" + over + ") AS rank").
+				Group(group).QueryExpr()
+			if err := s.DB.Exec(`INSERT INTO stats_top_counts (world, data_center, stat, value, count)
+				SELECT world, data_center, ?, value, count FROM (?) AS ranked WHERE rank <= ?`,
+				top.Stat, ranked, StatsTopLimit).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return s.DB.Exec(`INSERT INTO stats_refreshes (refreshed_at) VALUES (?)`, time.Now()).Error
+}
+
+// sortLevelStatsCounts sorts level stats by job, in the order of Jobs, then bucket.
+func sortLevelStatsCounts(counts []LevelStatsCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		if a, b := jobOrder[counts[i].Job], jobOrder[counts[j].Job]; a != b {
+			return a < b
+		}
+		return counts[i].Min < counts[j].Min
+	})
+}
+
+// sortTopStatsCounts sorts counts by count, highest first, then value, and truncates them to limit.
+func sortTopStatsCounts(counts []StatsCount, limit int) []StatsCount {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Value < counts[j].Value
+	})
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts
+}
@@ -0,0 +1,227 @@
+package models
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelBucket(t *testing.T) {
+	for lvl, expect := range map[int][2]int{
+		0:  {0, 0},
+		1:  {1, 1},
+		2:  {2, 14},
+		14: {2, 14},
+		15: {15, 15},
+		16: {16, 29},
+		50: {50, 50},
+		69: {61, 69},
+		70: {70, 70},
+		71: {71, 0},
+	} {
+		min, max := LevelBucket(lvl)
+		assert.Equal(t, expect, [2]int{min, max}, "%d", lvl)
+	}
+}
+
+func TestDataCenters(t *testing.T) {
+	seen := make(map[World]bool)
+	for _, dc := range DataCenters {
+		for _, w := range dc.Worlds() {
+			assert.False(t, seen[w], "%s is in multiple data centers", w)
+			seen[w] = true
+			assert.Equal(t, dc, w.DataCenter())
+		}
+	}
+	for _, w := range Worlds {
+		assert.True(t, seen[w], "%s isn't in a data center", w)
+	}
+}
+
+func TestStatsStore(t *testing.T) {
+	tx := testDB(t).Begin()
+	defer tx.Rollback()
+
+	ds := NewDataStore(tx)
+	testStats(t, ds)
+
+	t.Run("Stale", func(t *testing.T) {
+		ctx := context.Background()
+		at, err := ds.Stats().RefreshedAt(ctx)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), at, time.Minute)
+
+		require.NoError(t, ds.Characters().Save(ctx, newTestCharacter(5, "Emi", "Bell")))
+
+		counts, err := ds.Stats().TopFirstNames(ctx, StatsFilter{}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"Emi", 2}}, counts)
+
+		require.NoError(t, ds.Stats().Refresh(ctx))
+		counts, err = ds.Stats().TopFirstNames(ctx, StatsFilter{}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"Emi", 3}}, counts)
+	})
+}
+
+// testStats tests a StatsStore against any DataStore.
+func testStats(t *testing.T, ds DataStore) {
+	ctx := context.Background()
+
+	khloe, err := ds.CharacterTitles().GetOrCreate(ctx, "Khloe's Friend")
+	require.NoError(t, err)
+	witness, err := ds.CharacterTitles().GetOrCreate(ctx, "The Final Witness")
+	require.NoError(t, err)
+
+	maelstrom, adders := Maelstrom, Adders
+	chars := []*Character{
+		newTestCharacter(1, "Emi", "Hawke"),
+		newTestCharacter(2, "Emi", "Adams"),
+		newTestCharacter(3, "Zed", "Adams"),
+		newTestCharacter(4, "Anna", "Adams"),
+	}
+	chars[0].GC, chars[0].GCRank, chars[0].Title = &maelstrom, 9, khloe
+	chars[1].GC, chars[1].GCRank, chars[1].Title = &maelstrom, 2, khloe
+	chars[2].GC, chars[2].GCRank, chars[2].Title = &adders, 2, witness
+	chars[2].World, chars[2].Race, chars[2].Clan, chars[2].Gender = Zalera, Hyur, HyurMidlander, "♂"
+	chars[3].World = Odin
+	for _, ch := range chars {
+		require.NoError(t, ds.Characters().Save(ctx, ch))
+	}
+	for _, lvl := range []*Level{
+		{CharacterID: 1, Job: PLD, Level: 62},
+		{CharacterID: 1, Job: SCH, Level: 70},
+		{CharacterID: 2, Job: PLD, Level: 65},
+		{CharacterID: 3, Job: SCH, Level: 70},
+		{CharacterID: 4, Job: WAR, Level: 1},
+	} {
+		require.NoError(t, ds.Levels().Set(ctx, lvl))
+	}
+
+	// Stats should be counted up live until they're first refreshed, and come out the same after.
+	stats := ds.Stats()
+	at, err := stats.RefreshedAt(ctx)
+	require.NoError(t, err)
+	assert.True(t, at.IsZero(), "%s", at)
+	t.Run("Live", func(t *testing.T) { testStatsCounts(t, stats) })
+	require.NoError(t, stats.Refresh(ctx))
+	t.Run("Refreshed", func(t *testing.T) { testStatsCounts(t, stats) })
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := stats.Genders(ctx, StatsFilter{})
+		assert.Equal(t, context.Canceled, err)
+		_, err = stats.Levels(ctx, StatsFilter{})
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, context.Canceled, stats.Refresh(ctx))
+	})
+}
+
+// testStatsCounts tests every breakdown and top list of a StatsStore over testStats' characters.
+func testStatsCounts(t *testing.T, stats StatsStore) {
+	ctx := context.Background()
+	all := StatsFilter{}
+	primal := StatsFilter{DataCenter: Primal}
+
+	t.Run("Genders", func(t *testing.T) {
+		counts, err := stats.Genders(ctx, all)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"♀", 3}, {"♂", 1}}, counts)
+
+		counts, err = stats.Genders(ctx, StatsFilter{World: Zalera})
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"♂", 1}}, counts)
+	})
+
+	t.Run("Races", func(t *testing.T) {
+		counts, err := stats.Races(ctx, all)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"AuRa", 3}, {"Hyur", 1}}, counts)
+	})
+
+	t.Run("Clans", func(t *testing.T) {
+		counts, err := stats.Clans(ctx, all)
+		require.NoError(t, err)
+		assert.Equal(t, []ClanStatsCount{
+			{AuRa, AuRaRaen, "♀", 3},
+			{Hyur, HyurMidlander, "♂", 1},
+		}, counts)
+	})
+
+	t.Run("Levels", func(t *testing.T) {
+		counts, err := stats.Levels(ctx, all)
+		require.NoError(t, err)
+		assert.Equal(t, []LevelStatsCount{
+			{PLD, 61, 69, 2},
+			{WAR, 1, 1, 1},
+			{SCH, 70, 70, 2},
+		}, counts)
+
+		counts, err = stats.Levels(ctx, primal)
+		require.NoError(t, err)
+		assert.Equal(t, []LevelStatsCount{
+			{PLD, 61, 69, 2},
+			{SCH, 70, 70, 1},
+		}, counts)
+	})
+
+	t.Run("GrandCompanies", func(t *testing.T) {
+		counts, err := stats.GrandCompanies(ctx, all)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"", 1}, {"Adders", 1}, {"Maelstrom", 2}}, counts)
+	})
+
+	t.Run("GrandCompanyRanks", func(t *testing.T) {
+		counts, err := stats.GrandCompanyRanks(ctx, all)
+		require.NoError(t, err)
+		assert.Equal(t, []GCRankStatsCount{
+			{Adders, 2, 1},
+			{Maelstrom, 2, 1},
+			{Maelstrom, 9, 1},
+		}, counts)
+	})
+
+	t.Run("Worlds", func(t *testing.T) {
+		counts, err := stats.Worlds(ctx, all)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"Ultros", 2}, {"Odin", 1}, {"Zalera", 1}}, counts)
+
+		counts, err = stats.Worlds(ctx, StatsFilter{DataCenter: Chaos})
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"Odin", 1}}, counts)
+	})
+
+	t.Run("TopTitles", func(t *testing.T) {
+		counts, err := stats.TopTitles(ctx, all, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"Khloe's Friend", 2}, {"The Final Witness", 1}}, counts)
+
+		counts, err = stats.TopTitles(ctx, all, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"Khloe's Friend", 2}}, counts)
+	})
+
+	t.Run("TopFirstNames", func(t *testing.T) {
+		counts, err := stats.TopFirstNames(ctx, all, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"Emi", 2}, {"Anna", 1}}, counts)
+	})
+
+	t.Run("TopLastNames", func(t *testing.T) {
+		counts, err := stats.TopLastNames(ctx, primal, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"Adams", 1}, {"Hawke", 1}}, counts)
+
+		counts, err = stats.TopLastNames(ctx, StatsFilter{World: Zalera}, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{{"Adams", 1}}, counts)
+
+		counts, err = stats.TopLastNames(ctx, StatsFilter{World: Zalera, DataCenter: Chaos}, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []StatsCount{}, counts)
+	})
+}
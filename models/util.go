@@ -8,6 +8,9 @@ import (
 	"github.com/jinzhu/gorm"
 )
 
+// buildConflictAssignments builds the assignments for an ON CONFLICT DO UPDATE clause, setting every
+// column of mod to the value it would have been inserted with. This is understood by both Postgres
+// and SQLite (3.24+), so the same statements work for both.
 func buildConflictAssignments(mod interface{}, excludeID bool, exclude ...string) string {
 	if excludeID {
 		exclude = append(exclude, "id", "primary_key")
@@ -50,3 +53,34 @@ fieldLoop:
 	}
 	return
 }
+
+// upsertBatchSize is the max number of rows upserted by a single statement, which keeps them under
+// the bind parameter limits of both Postgres (65535) and SQLite (32766).
+const upsertBatchSize = 500
+
+// upsertAll inserts rows into a table with multi-row INSERT ... ON CONFLICT DO UPDATE statements,
+// one per upsertBatchSize rows. Each row must have one value per column, and no two rows may have
+// the same conflict key, as a single statement can't update a row twice.
+func upsertAll(db *gorm.DB, table string, cols []string, conflict, assignments string, rows [][]interface{}) error {
+	placeholder := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ") + ")"
+	for len(rows) > 0 {
+		batch := rows
+		if len(batch) > upsertBatchSize {
+			batch = batch[:upsertBatchSize]
+		}
+		rows = rows[len(batch):]
+
+		placeholders := make([]string, len(batch))
+		vars := make([]interface{}, 0, len(batch)*len(cols))
+		for i, row := range batch {
+			placeholders[i] = placeholder
+			vars = append(vars, row...)
+		}
+		if err := db.Exec(fmt.Sprintf(`INSERT INTO %s (%s) VALUES %s ON CONFLICT (%s) DO UPDATE SET %s`,
+			table, strings.Join(cols, ", "), strings.Join(placeholders, ", "), conflict, assignments,
+		), vars...).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
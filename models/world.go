@@ -72,3 +72,83 @@ const (
 	Shiva        World = "Shiva"
 	Zodiark      World = "Zodiark"
 )
+
+// Worlds lists every known World.
+var Worlds = []World{
+	Aegis,
+	Atomos,
+	Carbuncle,
+	Garuda,
+	Gungnir,
+	Kujata,
+	Ramuh,
+	Tonberry,
+	Typhon,
+	Unicorn,
+	Alexander,
+	Bahamut,
+	Durandal,
+	Fenrir,
+	Ifrit,
+	Ridill,
+	Tiamat,
+	Ultima,
+	Valefor,
+	Yojimbo,
+	Zeromus,
+	Anima,
+	Asura,
+	Belias,
+	Chocobo,
+	Hades,
+	Ixion,
+	Mandragora,
+	Masamune,
+	Pandaemonium,
+	Shinryu,
+	Titan,
+	Adamantoise,
+	Balmung,
+	Cactuar,
+	Coeurl,
+	Faerie,
+	Gilgamesh,
+	Goblin,
+	Jenova,
+	Mateus,
+	Midgardsormr,
+	Sargatanas,
+	Siren,
+	Zalera,
+	Behemoth,
+	Brynhildr,
+	Diabolos,
+	Excalibur,
+	Exodus,
+	Famfrit,
+	Hyperion,
+	Lamia,
+	Leviathan,
+	Malboro,
+	Ultros,
+	Cerberus,
+	Lich,
+	Louisoix,
+	Moogle,
+	Odin,
+	Omega,
+	Phoenix,
+	Ragnarok,
+	Shiva,
+	Zodiark,
+}
+
+// Valid returns whether this is a known World.
+func (v World) Valid() bool {
+	for _, known := range Worlds {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package rpc
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/liclac/gubal/models"
+	"github.com/liclac/gubal/rpc/gubalpb"
+)
+
+// An enumMap maps between a models enum and its protobuf counterpart, by name: the protobuf value
+// for models.Ultros is WORLD_ULTROS. The zero value of both means "unset".
+type enumMap[M ~string, P ~int32] struct {
+	name      string
+	toProto   map[M]P
+	fromProto map[P]M
+}
+
+func newEnumMap[M ~string, P ~int32](name, prefix string, values []M, protoValues map[string]int32) enumMap[M, P] {
+	m := enumMap[M, P]{name: name, toProto: make(map[M]P), fromProto: make(map[P]M)}
+	for _, v := range values {
+		p, ok := protoValues[prefix+"_"+strings.ToUpper(string(v))]
+		if !ok {
+			panic("no protobuf value for " + name + ": " + string(v))
+		}
+		m.toProto[v] = P(p)
+		m.fromProto[P(p)] = v
+	}
+	return m
+}
+
+// ToProto converts a value to protobuf; unknown values become unset.
+func (m enumMap[M, P]) ToProto(v M) P { return m.toProto[v] }
+
+// FromProto converts a value from protobuf, or returns an error if it's unknown.
+func (m enumMap[M, P]) FromProto(p P) (M, error) {
+	if p == 0 {
+		return "", nil
+	}
+	v, ok := m.fromProto[p]
+	if !ok {
+		return "", errors.Errorf("unknown %s: %d", m.name, p)
+	}
+	return v, nil
+}
+
+var (
+	worlds         = newEnumMap[models.World, gubalpb.World]("world", "WORLD", models.Worlds, gubalpb.World_value)
+	races          = newEnumMap[models.CharacterRace, gubalpb.Race]("race", "RACE", models.CharacterRaces, gubalpb.Race_value)
+	clans          = newEnumMap[models.CharacterClan, gubalpb.Clan]("clan", "CLAN", models.CharacterClans, gubalpb.Clan_value)
+	guardians      = newEnumMap[models.CharacterGuardian, gubalpb.Guardian]("guardian", "GUARDIAN", models.CharacterGuardians, gubalpb.Guardian_value)
+	cityStates     = newEnumMap[models.CityState, gubalpb.CityState]("city state", "CITY_STATE", models.CityStates, gubalpb.CityState_value)
+	grandCompanies = newEnumMap[models.GrandCompany, gubalpb.GrandCompany]("grand company", "GRAND_COMPANY", models.GrandCompanies, gubalpb.GrandCompany_value)
+	jobs           = newEnumMap[models.Job, gubalpb.Job]("job", "JOB", models.Jobs, gubalpb.Job_value)
+)
+
+// sorts maps protobuf CharacterSorts to models ones.
+var sorts = map[gubalpb.CharacterSort]models.CharacterSort{
+	gubalpb.CharacterSort_CHARACTER_SORT_UNSPECIFIED: models.SortByID,
+	gubalpb.CharacterSort_CHARACTER_SORT_ID:          models.SortByID,
+	gubalpb.CharacterSort_CHARACTER_SORT_FIRST_NAME:  models.SortByFirstName,
+	gubalpb.CharacterSort_CHARACTER_SORT_LAST_NAME:   models.SortByLastName,
+	gubalpb.CharacterSort_CHARACTER_SORT_UPDATED_AT:  models.SortByUpdatedAt,
+	gubalpb.CharacterSort_CHARACTER_SORT_SEEN_AT:     models.SortBySeenAt,
+}
+
+func characterToProto(ch *models.Character) *gubalpb.Character {
+	pb := &gubalpb.Character{
+		Id:        ch.ID,
+		CreatedAt: timestamppb.New(ch.CreatedAt),
+		UpdatedAt: timestamppb.New(ch.UpdatedAt),
+		SeenAt:    timestamppb.New(ch.SeenAt),
+		FirstName: ch.FirstName,
+		LastName:  ch.LastName,
+		Race:      races.ToProto(ch.Race),
+		Clan:      clans.ToProto(ch.Clan),
+		Gender:    ch.Gender,
+		Guardian:  guardians.ToProto(ch.Guardian),
+		CityState: cityStates.ToProto(ch.CityState),
+		World:     worlds.ToProto(ch.World),
+		GcRank:    int32(ch.GCRank),
+	}
+	if ch.Title != nil {
+		pb.Title = titleToProto(ch.Title)
+	}
+	if ch.GC != nil {
+		pb.Gc = grandCompanies.ToProto(*ch.GC)
+	}
+	return pb
+}
+
+func levelToProto(lvl *models.Level) *gubalpb.Level {
+	return &gubalpb.Level{
+		CharacterId: lvl.CharacterID,
+		Job:         jobs.ToProto(lvl.Job),
+		CreatedAt:   timestamppb.New(lvl.CreatedAt),
+		UpdatedAt:   timestamppb.New(lvl.UpdatedAt),
+		Level:       int32(lvl.Level),
+	}
+}
+
+func titleToProto(title *models.CharacterTitle) *gubalpb.CharacterTitle {
+	return &gubalpb.CharacterTitle{
+		Id:        int32(title.ID),
+		CreatedAt: timestamppb.New(title.CreatedAt),
+		Title:     title.Title,
+	}
+}
+
+func tombstoneToProto(ts *models.CharacterTombstone) *gubalpb.Tombstone {
+	return &gubalpb.Tombstone{
+		Id:        ts.ID,
+		CreatedAt: timestamppb.New(ts.CreatedAt),
+	}
+}
+
+// queryFromProto converts a search request to a CharacterQuery. The page size isn't capped.
+func queryFromProto(req *gubalpb.SearchCharactersRequest) (q models.CharacterQuery, err error) {
+	q = models.CharacterQuery{
+		Name:   req.Name,
+		Gender: req.Gender,
+		GCRank: int(req.GcRank),
+		Title:  req.Title,
+		Desc:   req.Desc,
+		After:  req.PageToken,
+		Limit:  int(req.PageSize),
+	}
+	if q.World, err = worlds.FromProto(req.World); err != nil {
+		return q, err
+	}
+	if q.Race, err = races.FromProto(req.Race); err != nil {
+		return q, err
+	}
+	if q.Clan, err = clans.FromProto(req.Clan); err != nil {
+		return q, err
+	}
+	if q.Guardian, err = guardians.FromProto(req.Guardian); err != nil {
+		return q, err
+	}
+	if q.CityState, err = cityStates.FromProto(req.CityState); err != nil {
+		return q, err
+	}
+	if q.GC, err = grandCompanies.FromProto(req.Gc); err != nil {
+		return q, err
+	}
+	for _, r := range req.Levels {
+		job, err := jobs.FromProto(r.Job)
+		if err != nil {
+			return q, err
+		}
+		if job == "" {
+			return q, errors.New("level range without a job")
+		}
+		// Proto3 can't tell an unset max from 0, so a max of 0 means there's no upper bound.
+		lr := models.LevelRange{Job: job, Min: int(r.Min)}
+		if r.Max != 0 {
+			max := int(r.Max)
+			lr.Max = &max
+		}
+		q.Levels = append(q.Levels, lr)
+	}
+	sort, ok := sorts[req.Sort]
+	if !ok {
+		return q, errors.Errorf("unknown sort: %d", req.Sort)
+	}
+	q.Sort = sort
+	return q, q.Validate()
+}
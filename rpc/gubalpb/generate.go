@@ -0,0 +1,9 @@
+// Package gubalpb contains the protobuf messages and gRPC service generated from gubal.proto.
+package gubalpb
+
+// Generating is pinned to these versions, so that it's reproducible: the plugins are installed into
+// bin/ at theirs, and protoc, which can't be installed that way, is checked.
+//go:generate sh -c "protoc --version | grep -qx 'libprotoc 23.4' || { echo 'gubalpb: generating needs protoc 23.4' >&2; exit 1; }"
+//go:generate sh -c "GOBIN=$DOLLAR(pwd)/bin go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.30.0"
+//go:generate sh -c "GOBIN=$DOLLAR(pwd)/bin go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.3.0"
+//go:generate protoc --plugin=bin/protoc-gen-go --plugin=bin/protoc-gen-go-grpc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative gubal.proto
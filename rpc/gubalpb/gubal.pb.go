@@ -0,0 +1,2374 @@
+// Protobuf schema for gubal's gRPC API. Regenerate the Go code with `go generate`.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v4.23.4
+// source: gubal.proto
+
+package gubalpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A CharacterSort is a field that searches can be sorted by. Ties are broken by ID.
+type CharacterSort int32
+
+const (
+	CharacterSort_CHARACTER_SORT_UNSPECIFIED CharacterSort = 0 // Sorts by ID.
+	CharacterSort_CHARACTER_SORT_ID          CharacterSort = 1
+	CharacterSort_CHARACTER_SORT_FIRST_NAME  CharacterSort = 2
+	CharacterSort_CHARACTER_SORT_LAST_NAME   CharacterSort = 3
+	CharacterSort_CHARACTER_SORT_UPDATED_AT  CharacterSort = 4
+	CharacterSort_CHARACTER_SORT_SEEN_AT     CharacterSort = 5
+)
+
+// Enum value maps for CharacterSort.
+var (
+	CharacterSort_name = map[int32]string{
+		0: "CHARACTER_SORT_UNSPECIFIED",
+		1: "CHARACTER_SORT_ID",
+		2: "CHARACTER_SORT_FIRST_NAME",
+		3: "CHARACTER_SORT_LAST_NAME",
+		4: "CHARACTER_SORT_UPDATED_AT",
+		5: "CHARACTER_SORT_SEEN_AT",
+	}
+	CharacterSort_value = map[string]int32{
+		"CHARACTER_SORT_UNSPECIFIED": 0,
+		"CHARACTER_SORT_ID":          1,
+		"CHARACTER_SORT_FIRST_NAME":  2,
+		"CHARACTER_SORT_LAST_NAME":   3,
+		"CHARACTER_SORT_UPDATED_AT":  4,
+		"CHARACTER_SORT_SEEN_AT":     5,
+	}
+)
+
+func (x CharacterSort) Enum() *CharacterSort {
+	p := new(CharacterSort)
+	*p = x
+	return p
+}
+
+func (x CharacterSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CharacterSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_gubal_proto_enumTypes[0].Descriptor()
+}
+
+func (CharacterSort) Type() protoreflect.EnumType {
+	return &file_gubal_proto_enumTypes[0]
+}
+
+func (x CharacterSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CharacterSort.Descriptor instead.
+func (CharacterSort) EnumDescriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{0}
+}
+
+// A World is a game world; see models.World.
+type World int32
+
+const (
+	World_WORLD_UNSPECIFIED  World = 0
+	World_WORLD_AEGIS        World = 1
+	World_WORLD_ATOMOS       World = 2
+	World_WORLD_CARBUNCLE    World = 3
+	World_WORLD_GARUDA       World = 4
+	World_WORLD_GUNGNIR      World = 5
+	World_WORLD_KUJATA       World = 6
+	World_WORLD_RAMUH        World = 7
+	World_WORLD_TONBERRY     World = 8
+	World_WORLD_TYPHON       World = 9
+	World_WORLD_UNICORN      World = 10
+	World_WORLD_ALEXANDER    World = 11
+	World_WORLD_BAHAMUT      World = 12
+	World_WORLD_DURANDAL     World = 13
+	World_WORLD_FENRIR       World = 14
+	World_WORLD_IFRIT        World = 15
+	World_WORLD_RIDILL       World = 16
+	World_WORLD_TIAMAT       World = 17
+	World_WORLD_ULTIMA       World = 18
+	World_WORLD_VALEFOR      World = 19
+	World_WORLD_YOJIMBO      World = 20
+	World_WORLD_ZEROMUS      World = 21
+	World_WORLD_ANIMA        World = 22
+	World_WORLD_ASURA        World = 23
+	World_WORLD_BELIAS       World = 24
+	World_WORLD_CHOCOBO      World = 25
+	World_WORLD_HADES        World = 26
+	World_WORLD_IXION        World = 27
+	World_WORLD_MANDRAGORA   World = 28
+	World_WORLD_MASAMUNE     World = 29
+	World_WORLD_PANDAEMONIUM World = 30
+	World_WORLD_SHINRYU      World = 31
+	World_WORLD_TITAN        World = 32
+	World_WORLD_ADAMANTOISE  World = 33
+	World_WORLD_BALMUNG      World = 34
+	World_WORLD_CACTUAR      World = 35
+	World_WORLD_COEURL       World = 36
+	World_WORLD_FAERIE       World = 37
+	World_WORLD_GILGAMESH    World = 38
+	World_WORLD_GOBLIN       World = 39
+	World_WORLD_JENOVA       World = 40
+	World_WORLD_MATEUS       World = 41
+	World_WORLD_MIDGARDSORMR World = 42
+	World_WORLD_SARGATANAS   World = 43
+	World_WORLD_SIREN        World = 44
+	World_WORLD_ZALERA       World = 45
+	World_WORLD_BEHEMOTH     World = 46
+	World_WORLD_BRYNHILDR    World = 47
+	World_WORLD_DIABOLOS     World = 48
+	World_WORLD_EXCALIBUR    World = 49
+	World_WORLD_EXODUS       World = 50
+	World_WORLD_FAMFRIT      World = 51
+	World_WORLD_HYPERION     World = 52
+	World_WORLD_LAMIA        World = 53
+	World_WORLD_LEVIATHAN    World = 54
+	World_WORLD_MALBORO      World = 55
+	World_WORLD_ULTROS       World = 56
+	World_WORLD_CERBERUS     World = 57
+	World_WORLD_LICH         World = 58
+	World_WORLD_LOUISOIX     World = 59
+	World_WORLD_MOOGLE       World = 60
+	World_WORLD_ODIN         World = 61
+	World_WORLD_OMEGA        World = 62
+	World_WORLD_PHOENIX      World = 63
+	World_WORLD_RAGNAROK     World = 64
+	World_WORLD_SHIVA        World = 65
+	World_WORLD_ZODIARK      World = 66
+)
+
+// Enum value maps for World.
+var (
+	World_name = map[int32]string{
+		0:  "WORLD_UNSPECIFIED",
+		1:  "WORLD_AEGIS",
+		2:  "WORLD_ATOMOS",
+		3:  "WORLD_CARBUNCLE",
+		4:  "WORLD_GARUDA",
+		5:  "WORLD_GUNGNIR",
+		6:  "WORLD_KUJATA",
+		7:  "WORLD_RAMUH",
+		8:  "WORLD_TONBERRY",
+		9:  "WORLD_TYPHON",
+		10: "WORLD_UNICORN",
+		11: "WORLD_ALEXANDER",
+		12: "WORLD_BAHAMUT",
+		13: "WORLD_DURANDAL",
+		14: "WORLD_FENRIR",
+		15: "WORLD_IFRIT",
+		16: "WORLD_RIDILL",
+		17: "WORLD_TIAMAT",
+		18: "WORLD_ULTIMA",
+		19: "WORLD_VALEFOR",
+		20: "WORLD_YOJIMBO",
+		21: "WORLD_ZEROMUS",
+		22: "WORLD_ANIMA",
+		23: "WORLD_ASURA",
+		24: "WORLD_BELIAS",
+		25: "WORLD_CHOCOBO",
+		26: "WORLD_HADES",
+		27: "WORLD_IXION",
+		28: "WORLD_MANDRAGORA",
+		29: "WORLD_MASAMUNE",
+		30: "WORLD_PANDAEMONIUM",
+		31: "WORLD_SHINRYU",
+		32: "WORLD_TITAN",
+		33: "WORLD_ADAMANTOISE",
+		34: "WORLD_BALMUNG",
+		35: "WORLD_CACTUAR",
+		36: "WORLD_COEURL",
+		37: "WORLD_FAERIE",
+		38: "WORLD_GILGAMESH",
+		39: "WORLD_GOBLIN",
+		40: "WORLD_JENOVA",
+		41: "WORLD_MATEUS",
+		42: "WORLD_MIDGARDSORMR",
+		43: "WORLD_SARGATANAS",
+		44: "WORLD_SIREN",
+		45: "WORLD_ZALERA",
+		46: "WORLD_BEHEMOTH",
+		47: "WORLD_BRYNHILDR",
+		48: "WORLD_DIABOLOS",
+		49: "WORLD_EXCALIBUR",
+		50: "WORLD_EXODUS",
+		51: "WORLD_FAMFRIT",
+		52: "WORLD_HYPERION",
+		53: "WORLD_LAMIA",
+		54: "WORLD_LEVIATHAN",
+		55: "WORLD_MALBORO",
+		56: "WORLD_ULTROS",
+		57: "WORLD_CERBERUS",
+		58: "WORLD_LICH",
+		59: "WORLD_LOUISOIX",
+		60: "WORLD_MOOGLE",
+		61: "WORLD_ODIN",
+		62: "WORLD_OMEGA",
+		63: "WORLD_PHOENIX",
+		64: "WORLD_RAGNAROK",
+		65: "WORLD_SHIVA",
+		66: "WORLD_ZODIARK",
+	}
+	World_value = map[string]int32{
+		"WORLD_UNSPECIFIED":  0,
+		"WORLD_AEGIS":        1,
+		"WORLD_ATOMOS":       2,
+		"WORLD_CARBUNCLE":    3,
+		"WORLD_GARUDA":       4,
+		"WORLD_GUNGNIR":      5,
+		"WORLD_KUJATA":       6,
+		"WORLD_RAMUH":        7,
+		"WORLD_TONBERRY":     8,
+		"WORLD_TYPHON":       9,
+		"WORLD_UNICORN":      10,
+		"WORLD_ALEXANDER":    11,
+		"WORLD_BAHAMUT":      12,
+		"WORLD_DURANDAL":     13,
+		"WORLD_FENRIR":       14,
+		"WORLD_IFRIT":        15,
+		"WORLD_RIDILL":       16,
+		"WORLD_TIAMAT":       17,
+		"WORLD_ULTIMA":       18,
+		"WORLD_VALEFOR":      19,
+		"WORLD_YOJIMBO":      20,
+		"WORLD_ZEROMUS":      21,
+		"WORLD_ANIMA":        22,
+		"WORLD_ASURA":        23,
+		"WORLD_BELIAS":       24,
+		"WORLD_CHOCOBO":      25,
+		"WORLD_HADES":        26,
+		"WORLD_IXION":        27,
+		"WORLD_MANDRAGORA":   28,
+		"WORLD_MASAMUNE":     29,
+		"WORLD_PANDAEMONIUM": 30,
+		"WORLD_SHINRYU":      31,
+		"WORLD_TITAN":        32,
+		"WORLD_ADAMANTOISE":  33,
+		"WORLD_BALMUNG":      34,
+		"WORLD_CACTUAR":      35,
+		"WORLD_COEURL":       36,
+		"WORLD_FAERIE":       37,
+		"WORLD_GILGAMESH":    38,
+		"WORLD_GOBLIN":       39,
+		"WORLD_JENOVA":       40,
+		"WORLD_MATEUS":       41,
+		"WORLD_MIDGARDSORMR": 42,
+		"WORLD_SARGATANAS":   43,
+		"WORLD_SIREN":        44,
+		"WORLD_ZALERA":       45,
+		"WORLD_BEHEMOTH":     46,
+		"WORLD_BRYNHILDR":    47,
+		"WORLD_DIABOLOS":     48,
+		"WORLD_EXCALIBUR":    49,
+		"WORLD_EXODUS":       50,
+		"WORLD_FAMFRIT":      51,
+		"WORLD_HYPERION":     52,
+		"WORLD_LAMIA":        53,
+		"WORLD_LEVIATHAN":    54,
+		"WORLD_MALBORO":      55,
+		"WORLD_ULTROS":       56,
+		"WORLD_CERBERUS":     57,
+		"WORLD_LICH":         58,
+		"WORLD_LOUISOIX":     59,
+		"WORLD_MOOGLE":       60,
+		"WORLD_ODIN":         61,
+		"WORLD_OMEGA":        62,
+		"WORLD_PHOENIX":      63,
+		"WORLD_RAGNAROK":     64,
+		"WORLD_SHIVA":        65,
+		"WORLD_ZODIARK":      66,
+	}
+)
+
+func (x World) Enum() *World {
+	p := new(World)
+	*p = x
+	return p
+}
+
+func (x World) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (World) Descriptor() protoreflect.EnumDescriptor {
+	return file_gubal_proto_enumTypes[1].Descriptor()
+}
+
+func (World) Type() protoreflect.EnumType {
+	return &file_gubal_proto_enumTypes[1]
+}
+
+func (x World) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use World.Descriptor instead.
+func (World) EnumDescriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{1}
+}
+
+// A Race is a character's race; see models.CharacterRace.
+type Race int32
+
+const (
+	Race_RACE_UNSPECIFIED Race = 0
+	Race_RACE_HYUR        Race = 1
+	Race_RACE_ELEZEN      Race = 2
+	Race_RACE_LALAFELL    Race = 3
+	Race_RACE_MIQOTE      Race = 4
+	Race_RACE_ROEGADYN    Race = 5
+	Race_RACE_AURA        Race = 6
+)
+
+// Enum value maps for Race.
+var (
+	Race_name = map[int32]string{
+		0: "RACE_UNSPECIFIED",
+		1: "RACE_HYUR",
+		2: "RACE_ELEZEN",
+		3: "RACE_LALAFELL",
+		4: "RACE_MIQOTE",
+		5: "RACE_ROEGADYN",
+		6: "RACE_AURA",
+	}
+	Race_value = map[string]int32{
+		"RACE_UNSPECIFIED": 0,
+		"RACE_HYUR":        1,
+		"RACE_ELEZEN":      2,
+		"RACE_LALAFELL":    3,
+		"RACE_MIQOTE":      4,
+		"RACE_ROEGADYN":    5,
+		"RACE_AURA":        6,
+	}
+)
+
+func (x Race) Enum() *Race {
+	p := new(Race)
+	*p = x
+	return p
+}
+
+func (x Race) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Race) Descriptor() protoreflect.EnumDescriptor {
+	return file_gubal_proto_enumTypes[2].Descriptor()
+}
+
+func (Race) Type() protoreflect.EnumType {
+	return &file_gubal_proto_enumTypes[2]
+}
+
+func (x Race) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Race.Descriptor instead.
+func (Race) EnumDescriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{2}
+}
+
+// A Clan is a character's clan; see models.CharacterClan.
+type Clan int32
+
+const (
+	Clan_CLAN_UNSPECIFIED Clan = 0
+	Clan_CLAN_MIDLANDER   Clan = 1
+	Clan_CLAN_HIGHLANDER  Clan = 2
+	Clan_CLAN_WILDWOOD    Clan = 3
+	Clan_CLAN_DUSKWIGHT   Clan = 4
+	Clan_CLAN_PLAINSFOLK  Clan = 5
+	Clan_CLAN_DUNESFOLK   Clan = 6
+	Clan_CLAN_SUNSEEKER   Clan = 7
+	Clan_CLAN_MOONKEEPER  Clan = 8
+	Clan_CLAN_SEAWOLF     Clan = 9
+	Clan_CLAN_HELLSGUARD  Clan = 10
+	Clan_CLAN_RAEN        Clan = 11
+	Clan_CLAN_XAELA       Clan = 12
+)
+
+// Enum value maps for Clan.
+var (
+	Clan_name = map[int32]string{
+		0:  "CLAN_UNSPECIFIED",
+		1:  "CLAN_MIDLANDER",
+		2:  "CLAN_HIGHLANDER",
+		3:  "CLAN_WILDWOOD",
+		4:  "CLAN_DUSKWIGHT",
+		5:  "CLAN_PLAINSFOLK",
+		6:  "CLAN_DUNESFOLK",
+		7:  "CLAN_SUNSEEKER",
+		8:  "CLAN_MOONKEEPER",
+		9:  "CLAN_SEAWOLF",
+		10: "CLAN_HELLSGUARD",
+		11: "CLAN_RAEN",
+		12: "CLAN_XAELA",
+	}
+	Clan_value = map[string]int32{
+		"CLAN_UNSPECIFIED": 0,
+		"CLAN_MIDLANDER":   1,
+		"CLAN_HIGHLANDER":  2,
+		"CLAN_WILDWOOD":    3,
+		"CLAN_DUSKWIGHT":   4,
+		"CLAN_PLAINSFOLK":  5,
+		"CLAN_DUNESFOLK":   6,
+		"CLAN_SUNSEEKER":   7,
+		"CLAN_MOONKEEPER":  8,
+		"CLAN_SEAWOLF":     9,
+		"CLAN_HELLSGUARD":  10,
+		"CLAN_RAEN":        11,
+		"CLAN_XAELA":       12,
+	}
+)
+
+func (x Clan) Enum() *Clan {
+	p := new(Clan)
+	*p = x
+	return p
+}
+
+func (x Clan) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Clan) Descriptor() protoreflect.EnumDescriptor {
+	return file_gubal_proto_enumTypes[3].Descriptor()
+}
+
+func (Clan) Type() protoreflect.EnumType {
+	return &file_gubal_proto_enumTypes[3]
+}
+
+func (x Clan) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Clan.Descriptor instead.
+func (Clan) EnumDescriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{3}
+}
+
+// A Guardian is a character's guardian deity; see models.CharacterGuardian.
+type Guardian int32
+
+const (
+	Guardian_GUARDIAN_UNSPECIFIED Guardian = 0
+	Guardian_GUARDIAN_HALONE      Guardian = 1
+	Guardian_GUARDIAN_MENPHINA    Guardian = 2
+	Guardian_GUARDIAN_THALIAK     Guardian = 3
+	Guardian_GUARDIAN_NYMEIA      Guardian = 4
+	Guardian_GUARDIAN_LLYMLAEN    Guardian = 5
+	Guardian_GUARDIAN_OSCHON      Guardian = 6
+	Guardian_GUARDIAN_BYREGOT     Guardian = 7
+	Guardian_GUARDIAN_RHALGR      Guardian = 8
+	Guardian_GUARDIAN_AZEYMA      Guardian = 9
+	Guardian_GUARDIAN_NALDTHAL    Guardian = 10
+	Guardian_GUARDIAN_NOPHICA     Guardian = 11
+	Guardian_GUARDIAN_ALTHYK      Guardian = 12
+)
+
+// Enum value maps for Guardian.
+var (
+	Guardian_name = map[int32]string{
+		0:  "GUARDIAN_UNSPECIFIED",
+		1:  "GUARDIAN_HALONE",
+		2:  "GUARDIAN_MENPHINA",
+		3:  "GUARDIAN_THALIAK",
+		4:  "GUARDIAN_NYMEIA",
+		5:  "GUARDIAN_LLYMLAEN",
+		6:  "GUARDIAN_OSCHON",
+		7:  "GUARDIAN_BYREGOT",
+		8:  "GUARDIAN_RHALGR",
+		9:  "GUARDIAN_AZEYMA",
+		10: "GUARDIAN_NALDTHAL",
+		11: "GUARDIAN_NOPHICA",
+		12: "GUARDIAN_ALTHYK",
+	}
+	Guardian_value = map[string]int32{
+		"GUARDIAN_UNSPECIFIED": 0,
+		"GUARDIAN_HALONE":      1,
+		"GUARDIAN_MENPHINA":    2,
+		"GUARDIAN_THALIAK":     3,
+		"GUARDIAN_NYMEIA":      4,
+		"GUARDIAN_LLYMLAEN":    5,
+		"GUARDIAN_OSCHON":      6,
+		"GUARDIAN_BYREGOT":     7,
+		"GUARDIAN_RHALGR":      8,
+		"GUARDIAN_AZEYMA":      9,
+		"GUARDIAN_NALDTHAL":    10,
+		"GUARDIAN_NOPHICA":     11,
+		"GUARDIAN_ALTHYK":      12,
+	}
+)
+
+func (x Guardian) Enum() *Guardian {
+	p := new(Guardian)
+	*p = x
+	return p
+}
+
+func (x Guardian) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Guardian) Descriptor() protoreflect.EnumDescriptor {
+	return file_gubal_proto_enumTypes[4].Descriptor()
+}
+
+func (Guardian) Type() protoreflect.EnumType {
+	return &file_gubal_proto_enumTypes[4]
+}
+
+func (x Guardian) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Guardian.Descriptor instead.
+func (Guardian) EnumDescriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{4}
+}
+
+// A CityState is a character's starting city-state; see models.CityState.
+type CityState int32
+
+const (
+	CityState_CITY_STATE_UNSPECIFIED CityState = 0
+	CityState_CITY_STATE_GRIDANIA    CityState = 1
+	CityState_CITY_STATE_ULDAH       CityState = 2
+	CityState_CITY_STATE_LIMSA       CityState = 3
+)
+
+// Enum value maps for CityState.
+var (
+	CityState_name = map[int32]string{
+		0: "CITY_STATE_UNSPECIFIED",
+		1: "CITY_STATE_GRIDANIA",
+		2: "CITY_STATE_ULDAH",
+		3: "CITY_STATE_LIMSA",
+	}
+	CityState_value = map[string]int32{
+		"CITY_STATE_UNSPECIFIED": 0,
+		"CITY_STATE_GRIDANIA":    1,
+		"CITY_STATE_ULDAH":       2,
+		"CITY_STATE_LIMSA":       3,
+	}
+)
+
+func (x CityState) Enum() *CityState {
+	p := new(CityState)
+	*p = x
+	return p
+}
+
+func (x CityState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CityState) Descriptor() protoreflect.EnumDescriptor {
+	return file_gubal_proto_enumTypes[5].Descriptor()
+}
+
+func (CityState) Type() protoreflect.EnumType {
+	return &file_gubal_proto_enumTypes[5]
+}
+
+func (x CityState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CityState.Descriptor instead.
+func (CityState) EnumDescriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{5}
+}
+
+// A GrandCompany is a grand company; see models.GrandCompany.
+type GrandCompany int32
+
+const (
+	GrandCompany_GRAND_COMPANY_UNSPECIFIED GrandCompany = 0
+	GrandCompany_GRAND_COMPANY_MAELSTROM   GrandCompany = 1
+	GrandCompany_GRAND_COMPANY_ADDERS      GrandCompany = 2
+	GrandCompany_GRAND_COMPANY_FLAMES      GrandCompany = 3
+)
+
+// Enum value maps for GrandCompany.
+var (
+	GrandCompany_name = map[int32]string{
+		0: "GRAND_COMPANY_UNSPECIFIED",
+		1: "GRAND_COMPANY_MAELSTROM",
+		2: "GRAND_COMPANY_ADDERS",
+		3: "GRAND_COMPANY_FLAMES",
+	}
+	GrandCompany_value = map[string]int32{
+		"GRAND_COMPANY_UNSPECIFIED": 0,
+		"GRAND_COMPANY_MAELSTROM":   1,
+		"GRAND_COMPANY_ADDERS":      2,
+		"GRAND_COMPANY_FLAMES":      3,
+	}
+)
+
+func (x GrandCompany) Enum() *GrandCompany {
+	p := new(GrandCompany)
+	*p = x
+	return p
+}
+
+func (x GrandCompany) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GrandCompany) Descriptor() protoreflect.EnumDescriptor {
+	return file_gubal_proto_enumTypes[6].Descriptor()
+}
+
+func (GrandCompany) Type() protoreflect.EnumType {
+	return &file_gubal_proto_enumTypes[6]
+}
+
+func (x GrandCompany) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GrandCompany.Descriptor instead.
+func (GrandCompany) EnumDescriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{6}
+}
+
+// A Job is a job or class; see models.Job.
+type Job int32
+
+const (
+	Job_JOB_UNSPECIFIED Job = 0
+	Job_JOB_PLD         Job = 1
+	Job_JOB_WAR         Job = 2
+	Job_JOB_DRK         Job = 3
+	Job_JOB_WHM         Job = 4
+	Job_JOB_SCH         Job = 5
+	Job_JOB_AST         Job = 6
+	Job_JOB_MNK         Job = 7
+	Job_JOB_DRG         Job = 8
+	Job_JOB_NIN         Job = 9
+	Job_JOB_SAM         Job = 10
+	Job_JOB_BRD         Job = 11
+	Job_JOB_MCH         Job = 12
+	Job_JOB_BLM         Job = 13
+	Job_JOB_SMN         Job = 14
+	Job_JOB_RDM         Job = 15
+	Job_JOB_CRP         Job = 16
+	Job_JOB_BSM         Job = 17
+	Job_JOB_ARM         Job = 18
+	Job_JOB_GSM         Job = 19
+	Job_JOB_LTW         Job = 20
+	Job_JOB_WVR         Job = 21
+	Job_JOB_ALC         Job = 22
+	Job_JOB_CUL         Job = 23
+	Job_JOB_MIN         Job = 24
+	Job_JOB_BOT         Job = 25
+	Job_JOB_FSH         Job = 26
+)
+
+// Enum value maps for Job.
+var (
+	Job_name = map[int32]string{
+		0:  "JOB_UNSPECIFIED",
+		1:  "JOB_PLD",
+		2:  "JOB_WAR",
+		3:  "JOB_DRK",
+		4:  "JOB_WHM",
+		5:  "JOB_SCH",
+		6:  "JOB_AST",
+		7:  "JOB_MNK",
+		8:  "JOB_DRG",
+		9:  "JOB_NIN",
+		10: "JOB_SAM",
+		11: "JOB_BRD",
+		12: "JOB_MCH",
+		13: "JOB_BLM",
+		14: "JOB_SMN",
+		15: "JOB_RDM",
+		16: "JOB_CRP",
+		17: "JOB_BSM",
+		18: "JOB_ARM",
+		19: "JOB_GSM",
+		20: "JOB_LTW",
+		21: "JOB_WVR",
+		22: "JOB_ALC",
+		23: "JOB_CUL",
+		24: "JOB_MIN",
+		25: "JOB_BOT",
+		26: "JOB_FSH",
+	}
+	Job_value = map[string]int32{
+		"JOB_UNSPECIFIED": 0,
+		"JOB_PLD":         1,
+		"JOB_WAR":         2,
+		"JOB_DRK":         3,
+		"JOB_WHM":         4,
+		"JOB_SCH":         5,
+		"JOB_AST":         6,
+		"JOB_MNK":         7,
+		"JOB_DRG":         8,
+		"JOB_NIN":         9,
+		"JOB_SAM":         10,
+		"JOB_BRD":         11,
+		"JOB_MCH":         12,
+		"JOB_BLM":         13,
+		"JOB_SMN":         14,
+		"JOB_RDM":         15,
+		"JOB_CRP":         16,
+		"JOB_BSM":         17,
+		"JOB_ARM":         18,
+		"JOB_GSM":         19,
+		"JOB_LTW":         20,
+		"JOB_WVR":         21,
+		"JOB_ALC":         22,
+		"JOB_CUL":         23,
+		"JOB_MIN":         24,
+		"JOB_BOT":         25,
+		"JOB_FSH":         26,
+	}
+)
+
+func (x Job) Enum() *Job {
+	p := new(Job)
+	*p = x
+	return p
+}
+
+func (x Job) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Job) Descriptor() protoreflect.EnumDescriptor {
+	return file_gubal_proto_enumTypes[7].Descriptor()
+}
+
+func (Job) Type() protoreflect.EnumType {
+	return &file_gubal_proto_enumTypes[7]
+}
+
+func (x Job) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Job.Descriptor instead.
+func (Job) EnumDescriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{7}
+}
+
+// A Character is a player character; see models.Character.
+type Character struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	SeenAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=seen_at,json=seenAt,proto3" json:"seen_at,omitempty"`
+	FirstName string                 `protobuf:"bytes,5,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string                 `protobuf:"bytes,6,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Race      Race                   `protobuf:"varint,7,opt,name=race,proto3,enum=gubal.Race" json:"race,omitempty"`
+	Clan      Clan                   `protobuf:"varint,8,opt,name=clan,proto3,enum=gubal.Clan" json:"clan,omitempty"`
+	Gender    string                 `protobuf:"bytes,9,opt,name=gender,proto3" json:"gender,omitempty"`
+	Guardian  Guardian               `protobuf:"varint,10,opt,name=guardian,proto3,enum=gubal.Guardian" json:"guardian,omitempty"`
+	CityState CityState              `protobuf:"varint,11,opt,name=city_state,json=cityState,proto3,enum=gubal.CityState" json:"city_state,omitempty"`
+	World     World                  `protobuf:"varint,12,opt,name=world,proto3,enum=gubal.World" json:"world,omitempty"`
+	Title     *CharacterTitle        `protobuf:"bytes,13,opt,name=title,proto3" json:"title,omitempty"`                    // Unset if the character has no title.
+	Gc        GrandCompany           `protobuf:"varint,14,opt,name=gc,proto3,enum=gubal.GrandCompany" json:"gc,omitempty"` // GRAND_COMPANY_UNSPECIFIED if the character isn't in one.
+	GcRank    int32                  `protobuf:"varint,15,opt,name=gc_rank,json=gcRank,proto3" json:"gc_rank,omitempty"`
+}
+
+func (x *Character) Reset() {
+	*x = Character{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Character) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Character) ProtoMessage() {}
+
+func (x *Character) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Character.ProtoReflect.Descriptor instead.
+func (*Character) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Character) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Character) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Character) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Character) GetSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SeenAt
+	}
+	return nil
+}
+
+func (x *Character) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Character) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Character) GetRace() Race {
+	if x != nil {
+		return x.Race
+	}
+	return Race_RACE_UNSPECIFIED
+}
+
+func (x *Character) GetClan() Clan {
+	if x != nil {
+		return x.Clan
+	}
+	return Clan_CLAN_UNSPECIFIED
+}
+
+func (x *Character) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *Character) GetGuardian() Guardian {
+	if x != nil {
+		return x.Guardian
+	}
+	return Guardian_GUARDIAN_UNSPECIFIED
+}
+
+func (x *Character) GetCityState() CityState {
+	if x != nil {
+		return x.CityState
+	}
+	return CityState_CITY_STATE_UNSPECIFIED
+}
+
+func (x *Character) GetWorld() World {
+	if x != nil {
+		return x.World
+	}
+	return World_WORLD_UNSPECIFIED
+}
+
+func (x *Character) GetTitle() *CharacterTitle {
+	if x != nil {
+		return x.Title
+	}
+	return nil
+}
+
+func (x *Character) GetGc() GrandCompany {
+	if x != nil {
+		return x.Gc
+	}
+	return GrandCompany_GRAND_COMPANY_UNSPECIFIED
+}
+
+func (x *Character) GetGcRank() int32 {
+	if x != nil {
+		return x.GcRank
+	}
+	return 0
+}
+
+// A Level is a character's level in a job; see models.Level.
+type Level struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CharacterId int64                  `protobuf:"varint,1,opt,name=character_id,json=characterId,proto3" json:"character_id,omitempty"`
+	Job         Job                    `protobuf:"varint,2,opt,name=job,proto3,enum=gubal.Job" json:"job,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Level       int32                  `protobuf:"varint,5,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *Level) Reset() {
+	*x = Level{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Level) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Level) ProtoMessage() {}
+
+func (x *Level) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Level.ProtoReflect.Descriptor instead.
+func (*Level) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Level) GetCharacterId() int64 {
+	if x != nil {
+		return x.CharacterId
+	}
+	return 0
+}
+
+func (x *Level) GetJob() Job {
+	if x != nil {
+		return x.Job
+	}
+	return Job_JOB_UNSPECIFIED
+}
+
+func (x *Level) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Level) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Level) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+// A CharacterTitle is a title, eg. "Khloe's Friend"; see models.CharacterTitle.
+type CharacterTitle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Title     string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+}
+
+func (x *CharacterTitle) Reset() {
+	*x = CharacterTitle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CharacterTitle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CharacterTitle) ProtoMessage() {}
+
+func (x *CharacterTitle) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CharacterTitle.ProtoReflect.Descriptor instead.
+func (*CharacterTitle) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CharacterTitle) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CharacterTitle) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CharacterTitle) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+// A Tombstone marks a character that doesn't exist; see models.CharacterTombstone.
+type Tombstone struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *Tombstone) Reset() {
+	*x = Tombstone{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tombstone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tombstone) ProtoMessage() {}
+
+func (x *Tombstone) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tombstone.ProtoReflect.Descriptor instead.
+func (*Tombstone) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Tombstone) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tombstone) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// A LevelRange matches characters with a level in a job between min and max, inclusive.
+// A max of 0 means there's no upper bound.
+type LevelRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Job Job   `protobuf:"varint,1,opt,name=job,proto3,enum=gubal.Job" json:"job,omitempty"`
+	Min int32 `protobuf:"varint,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max int32 `protobuf:"varint,3,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *LevelRange) Reset() {
+	*x = LevelRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LevelRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LevelRange) ProtoMessage() {}
+
+func (x *LevelRange) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LevelRange.ProtoReflect.Descriptor instead.
+func (*LevelRange) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LevelRange) GetJob() Job {
+	if x != nil {
+		return x.Job
+	}
+	return Job_JOB_UNSPECIFIED
+}
+
+func (x *LevelRange) GetMin() int32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *LevelRange) GetMax() int32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type GetCharacterRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetCharacterRequest) Reset() {
+	*x = GetCharacterRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCharacterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCharacterRequest) ProtoMessage() {}
+
+func (x *GetCharacterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCharacterRequest.ProtoReflect.Descriptor instead.
+func (*GetCharacterRequest) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCharacterRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListLevelsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CharacterId int64 `protobuf:"varint,1,opt,name=character_id,json=characterId,proto3" json:"character_id,omitempty"`
+}
+
+func (x *ListLevelsRequest) Reset() {
+	*x = ListLevelsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListLevelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLevelsRequest) ProtoMessage() {}
+
+func (x *ListLevelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLevelsRequest.ProtoReflect.Descriptor instead.
+func (*ListLevelsRequest) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListLevelsRequest) GetCharacterId() int64 {
+	if x != nil {
+		return x.CharacterId
+	}
+	return 0
+}
+
+type ListLevelsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Levels []*Level `protobuf:"bytes,1,rep,name=levels,proto3" json:"levels,omitempty"`
+}
+
+func (x *ListLevelsResponse) Reset() {
+	*x = ListLevelsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListLevelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLevelsResponse) ProtoMessage() {}
+
+func (x *ListLevelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLevelsResponse.ProtoReflect.Descriptor instead.
+func (*ListLevelsResponse) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListLevelsResponse) GetLevels() []*Level {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+// A SearchCharactersRequest is a search for characters; unset fields match everything.
+// See models.CharacterQuery.
+type SearchCharactersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Prefix of "First Last", case insensitive.
+	World     World         `protobuf:"varint,2,opt,name=world,proto3,enum=gubal.World" json:"world,omitempty"`
+	Race      Race          `protobuf:"varint,3,opt,name=race,proto3,enum=gubal.Race" json:"race,omitempty"`
+	Clan      Clan          `protobuf:"varint,4,opt,name=clan,proto3,enum=gubal.Clan" json:"clan,omitempty"`
+	Gender    string        `protobuf:"bytes,5,opt,name=gender,proto3" json:"gender,omitempty"`
+	Guardian  Guardian      `protobuf:"varint,6,opt,name=guardian,proto3,enum=gubal.Guardian" json:"guardian,omitempty"`
+	CityState CityState     `protobuf:"varint,7,opt,name=city_state,json=cityState,proto3,enum=gubal.CityState" json:"city_state,omitempty"`
+	Gc        GrandCompany  `protobuf:"varint,8,opt,name=gc,proto3,enum=gubal.GrandCompany" json:"gc,omitempty"`
+	GcRank    int32         `protobuf:"varint,9,opt,name=gc_rank,json=gcRank,proto3" json:"gc_rank,omitempty"`
+	Title     string        `protobuf:"bytes,10,opt,name=title,proto3" json:"title,omitempty"`
+	Levels    []*LevelRange `protobuf:"bytes,11,rep,name=levels,proto3" json:"levels,omitempty"`
+	Sort      CharacterSort `protobuf:"varint,12,opt,name=sort,proto3,enum=gubal.CharacterSort" json:"sort,omitempty"`
+	Desc      bool          `protobuf:"varint,13,opt,name=desc,proto3" json:"desc,omitempty"`
+	PageToken string        `protobuf:"bytes,14,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token from the previous page.
+	PageSize  int32         `protobuf:"varint,15,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Defaults to 100, and is capped at 1000.
+}
+
+func (x *SearchCharactersRequest) Reset() {
+	*x = SearchCharactersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchCharactersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCharactersRequest) ProtoMessage() {}
+
+func (x *SearchCharactersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCharactersRequest.ProtoReflect.Descriptor instead.
+func (*SearchCharactersRequest) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchCharactersRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchCharactersRequest) GetWorld() World {
+	if x != nil {
+		return x.World
+	}
+	return World_WORLD_UNSPECIFIED
+}
+
+func (x *SearchCharactersRequest) GetRace() Race {
+	if x != nil {
+		return x.Race
+	}
+	return Race_RACE_UNSPECIFIED
+}
+
+func (x *SearchCharactersRequest) GetClan() Clan {
+	if x != nil {
+		return x.Clan
+	}
+	return Clan_CLAN_UNSPECIFIED
+}
+
+func (x *SearchCharactersRequest) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *SearchCharactersRequest) GetGuardian() Guardian {
+	if x != nil {
+		return x.Guardian
+	}
+	return Guardian_GUARDIAN_UNSPECIFIED
+}
+
+func (x *SearchCharactersRequest) GetCityState() CityState {
+	if x != nil {
+		return x.CityState
+	}
+	return CityState_CITY_STATE_UNSPECIFIED
+}
+
+func (x *SearchCharactersRequest) GetGc() GrandCompany {
+	if x != nil {
+		return x.Gc
+	}
+	return GrandCompany_GRAND_COMPANY_UNSPECIFIED
+}
+
+func (x *SearchCharactersRequest) GetGcRank() int32 {
+	if x != nil {
+		return x.GcRank
+	}
+	return 0
+}
+
+func (x *SearchCharactersRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SearchCharactersRequest) GetLevels() []*LevelRange {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+func (x *SearchCharactersRequest) GetSort() CharacterSort {
+	if x != nil {
+		return x.Sort
+	}
+	return CharacterSort_CHARACTER_SORT_UNSPECIFIED
+}
+
+func (x *SearchCharactersRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *SearchCharactersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchCharactersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type SearchCharactersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Characters    []*Character `protobuf:"bytes,1,rep,name=characters,proto3" json:"characters,omitempty"`
+	NextPageToken string       `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page.
+}
+
+func (x *SearchCharactersResponse) Reset() {
+	*x = SearchCharactersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchCharactersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCharactersResponse) ProtoMessage() {}
+
+func (x *SearchCharactersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCharactersResponse.ProtoReflect.Descriptor instead.
+func (*SearchCharactersResponse) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchCharactersResponse) GetCharacters() []*Character {
+	if x != nil {
+		return x.Characters
+	}
+	return nil
+}
+
+func (x *SearchCharactersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetTombstoneRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetTombstoneRequest) Reset() {
+	*x = GetTombstoneRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTombstoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTombstoneRequest) ProtoMessage() {}
+
+func (x *GetTombstoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTombstoneRequest.ProtoReflect.Descriptor instead.
+func (*GetTombstoneRequest) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTombstoneRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetTitleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetTitleRequest) Reset() {
+	*x = GetTitleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTitleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTitleRequest) ProtoMessage() {}
+
+func (x *GetTitleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTitleRequest.ProtoReflect.Descriptor instead.
+func (*GetTitleRequest) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTitleRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type EnqueueFetchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CharacterId int64 `protobuf:"varint,1,opt,name=character_id,json=characterId,proto3" json:"character_id,omitempty"`
+	Force       bool  `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`       // Reparse the character even if the page hasn't changed.
+	Priority    bool  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"` // Put the job on the priority topic, rather than behind the crawl.
+}
+
+func (x *EnqueueFetchRequest) Reset() {
+	*x = EnqueueFetchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnqueueFetchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueFetchRequest) ProtoMessage() {}
+
+func (x *EnqueueFetchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueFetchRequest.ProtoReflect.Descriptor instead.
+func (*EnqueueFetchRequest) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EnqueueFetchRequest) GetCharacterId() int64 {
+	if x != nil {
+		return x.CharacterId
+	}
+	return 0
+}
+
+func (x *EnqueueFetchRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *EnqueueFetchRequest) GetPriority() bool {
+	if x != nil {
+		return x.Priority
+	}
+	return false
+}
+
+type EnqueueFetchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EnqueueFetchResponse) Reset() {
+	*x = EnqueueFetchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gubal_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnqueueFetchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueFetchResponse) ProtoMessage() {}
+
+func (x *EnqueueFetchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gubal_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueFetchResponse.ProtoReflect.Descriptor instead.
+func (*EnqueueFetchResponse) Descriptor() ([]byte, []int) {
+	return file_gubal_proto_rawDescGZIP(), []int{13}
+}
+
+var File_gubal_proto protoreflect.FileDescriptor
+
+var file_gubal_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x67,
+	0x75, 0x62, 0x61, 0x6c, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc9, 0x04, 0x0a, 0x09, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39,
+	0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x73, 0x65, 0x65,
+	0x6e, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x73, 0x65, 0x65, 0x6e, 0x41, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x72, 0x61,
+	0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c,
+	0x2e, 0x52, 0x61, 0x63, 0x65, 0x52, 0x04, 0x72, 0x61, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x63,
+	0x6c, 0x61, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x67, 0x75, 0x62, 0x61,
+	0x6c, 0x2e, 0x43, 0x6c, 0x61, 0x6e, 0x52, 0x04, 0x63, 0x6c, 0x61, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x08, 0x67, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x47,
+	0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x52, 0x08, 0x67, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61,
+	0x6e, 0x12, 0x2f, 0x0a, 0x0a, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x43, 0x69,
+	0x74, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x09, 0x63, 0x69, 0x74, 0x79, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0c, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x52,
+	0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x43, 0x68,
+	0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x52, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x12, 0x23, 0x0a, 0x02, 0x67, 0x63, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x13, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x47, 0x72, 0x61, 0x6e, 0x64, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x79, 0x52, 0x02, 0x67, 0x63, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x63, 0x5f, 0x72,
+	0x61, 0x6e, 0x6b, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x67, 0x63, 0x52, 0x61, 0x6e,
+	0x6b, 0x22, 0xd4, 0x01, 0x0a, 0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x63,
+	0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1c,
+	0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x67, 0x75,
+	0x62, 0x61, 0x6c, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x39, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x71, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x72,
+	0x61, 0x63, 0x74, 0x65, 0x72, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x22, 0x56, 0x0a, 0x09, 0x54,
+	0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x22, 0x4e, 0x0a, 0x0a, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x1c, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a,
+	0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12,
+	0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x69,
+	0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x6d, 0x61, 0x78, 0x22, 0x25, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x36, 0x0a, 0x11, 0x4c, 0x69,
+	0x73, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72,
+	0x49, 0x64, 0x22, 0x3a, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c,
+	0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x22, 0x82,
+	0x04, 0x0a, 0x17, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22,
+	0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e,
+	0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x52, 0x05, 0x77, 0x6f, 0x72,
+	0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x04, 0x72, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0b, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x52, 0x61, 0x63, 0x65, 0x52, 0x04, 0x72,
+	0x61, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x63, 0x6c, 0x61, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0b, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x43, 0x6c, 0x61, 0x6e, 0x52, 0x04,
+	0x63, 0x6c, 0x61, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x08,
+	0x67, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f,
+	0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x52,
+	0x08, 0x67, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x12, 0x2f, 0x0a, 0x0a, 0x63, 0x69, 0x74,
+	0x79, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e,
+	0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x09, 0x63, 0x69, 0x74, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x02, 0x67, 0x63,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x47,
+	0x72, 0x61, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x52, 0x02, 0x67, 0x63, 0x12,
+	0x17, 0x0a, 0x07, 0x67, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x67, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x29,
+	0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x6f, 0x72,
+	0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e,
+	0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x53, 0x6f, 0x72, 0x74, 0x52, 0x04, 0x73,
+	0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x22, 0x74, 0x0a, 0x18, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x68, 0x61,
+	0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x30, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x43, 0x68, 0x61, 0x72,
+	0x61, 0x63, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72,
+	0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74,
+	0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x25, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x54, 0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x21, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x6a, 0x0a, 0x13, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68,
+	0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f,
+	0x72, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22,
+	0x16, 0x0a, 0x14, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0xbe, 0x01, 0x0a, 0x0d, 0x43, 0x68, 0x61, 0x72,
+	0x61, 0x63, 0x74, 0x65, 0x72, 0x53, 0x6f, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x48, 0x41,
+	0x52, 0x41, 0x43, 0x54, 0x45, 0x52, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x48, 0x41,
+	0x52, 0x41, 0x43, 0x54, 0x45, 0x52, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x49, 0x44, 0x10, 0x01,
+	0x12, 0x1d, 0x0a, 0x19, 0x43, 0x48, 0x41, 0x52, 0x41, 0x43, 0x54, 0x45, 0x52, 0x5f, 0x53, 0x4f,
+	0x52, 0x54, 0x5f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x02, 0x12,
+	0x1c, 0x0a, 0x18, 0x43, 0x48, 0x41, 0x52, 0x41, 0x43, 0x54, 0x45, 0x52, 0x5f, 0x53, 0x4f, 0x52,
+	0x54, 0x5f, 0x4c, 0x41, 0x53, 0x54, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x03, 0x12, 0x1d, 0x0a,
+	0x19, 0x43, 0x48, 0x41, 0x52, 0x41, 0x43, 0x54, 0x45, 0x52, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f,
+	0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x41, 0x54, 0x10, 0x04, 0x12, 0x1a, 0x0a, 0x16,
+	0x43, 0x48, 0x41, 0x52, 0x41, 0x43, 0x54, 0x45, 0x52, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x53,
+	0x45, 0x45, 0x4e, 0x5f, 0x41, 0x54, 0x10, 0x05, 0x2a, 0xfd, 0x09, 0x0a, 0x05, 0x57, 0x6f, 0x72,
+	0x6c, 0x64, 0x12, 0x15, 0x0a, 0x11, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x4f, 0x52,
+	0x4c, 0x44, 0x5f, 0x41, 0x45, 0x47, 0x49, 0x53, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f,
+	0x52, 0x4c, 0x44, 0x5f, 0x41, 0x54, 0x4f, 0x4d, 0x4f, 0x53, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f,
+	0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x43, 0x41, 0x52, 0x42, 0x55, 0x4e, 0x43, 0x4c, 0x45, 0x10,
+	0x03, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x47, 0x41, 0x52, 0x55, 0x44,
+	0x41, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x47, 0x55, 0x4e,
+	0x47, 0x4e, 0x49, 0x52, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f,
+	0x4b, 0x55, 0x4a, 0x41, 0x54, 0x41, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x4f, 0x52, 0x4c,
+	0x44, 0x5f, 0x52, 0x41, 0x4d, 0x55, 0x48, 0x10, 0x07, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x4f, 0x52,
+	0x4c, 0x44, 0x5f, 0x54, 0x4f, 0x4e, 0x42, 0x45, 0x52, 0x52, 0x59, 0x10, 0x08, 0x12, 0x10, 0x0a,
+	0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x48, 0x4f, 0x4e, 0x10, 0x09, 0x12,
+	0x11, 0x0a, 0x0d, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x55, 0x4e, 0x49, 0x43, 0x4f, 0x52, 0x4e,
+	0x10, 0x0a, 0x12, 0x13, 0x0a, 0x0f, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x41, 0x4c, 0x45, 0x58,
+	0x41, 0x4e, 0x44, 0x45, 0x52, 0x10, 0x0b, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x4f, 0x52, 0x4c, 0x44,
+	0x5f, 0x42, 0x41, 0x48, 0x41, 0x4d, 0x55, 0x54, 0x10, 0x0c, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x4f,
+	0x52, 0x4c, 0x44, 0x5f, 0x44, 0x55, 0x52, 0x41, 0x4e, 0x44, 0x41, 0x4c, 0x10, 0x0d, 0x12, 0x10,
+	0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x46, 0x45, 0x4e, 0x52, 0x49, 0x52, 0x10, 0x0e,
+	0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x49, 0x46, 0x52, 0x49, 0x54, 0x10,
+	0x0f, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x52, 0x49, 0x44, 0x49, 0x4c,
+	0x4c, 0x10, 0x10, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x54, 0x49, 0x41,
+	0x4d, 0x41, 0x54, 0x10, 0x11, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x55,
+	0x4c, 0x54, 0x49, 0x4d, 0x41, 0x10, 0x12, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x4f, 0x52, 0x4c, 0x44,
+	0x5f, 0x56, 0x41, 0x4c, 0x45, 0x46, 0x4f, 0x52, 0x10, 0x13, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x4f,
+	0x52, 0x4c, 0x44, 0x5f, 0x59, 0x4f, 0x4a, 0x49, 0x4d, 0x42, 0x4f, 0x10, 0x14, 0x12, 0x11, 0x0a,
+	0x0d, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x5a, 0x45, 0x52, 0x4f, 0x4d, 0x55, 0x53, 0x10, 0x15,
+	0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x41, 0x4e, 0x49, 0x4d, 0x41, 0x10,
+	0x16, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x41, 0x53, 0x55, 0x52, 0x41,
+	0x10, 0x17, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x42, 0x45, 0x4c, 0x49,
+	0x41, 0x53, 0x10, 0x18, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x43, 0x48,
+	0x4f, 0x43, 0x4f, 0x42, 0x4f, 0x10, 0x19, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x4f, 0x52, 0x4c, 0x44,
+	0x5f, 0x48, 0x41, 0x44, 0x45, 0x53, 0x10, 0x1a, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x4f, 0x52, 0x4c,
+	0x44, 0x5f, 0x49, 0x58, 0x49, 0x4f, 0x4e, 0x10, 0x1b, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x4f, 0x52,
+	0x4c, 0x44, 0x5f, 0x4d, 0x41, 0x4e, 0x44, 0x52, 0x41, 0x47, 0x4f, 0x52, 0x41, 0x10, 0x1c, 0x12,
+	0x12, 0x0a, 0x0e, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x4d, 0x41, 0x53, 0x41, 0x4d, 0x55, 0x4e,
+	0x45, 0x10, 0x1d, 0x12, 0x16, 0x0a, 0x12, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x50, 0x41, 0x4e,
+	0x44, 0x41, 0x45, 0x4d, 0x4f, 0x4e, 0x49, 0x55, 0x4d, 0x10, 0x1e, 0x12, 0x11, 0x0a, 0x0d, 0x57,
+	0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x53, 0x48, 0x49, 0x4e, 0x52, 0x59, 0x55, 0x10, 0x1f, 0x12, 0x0f,
+	0x0a, 0x0b, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x54, 0x49, 0x54, 0x41, 0x4e, 0x10, 0x20, 0x12,
+	0x15, 0x0a, 0x11, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x41, 0x44, 0x41, 0x4d, 0x41, 0x4e, 0x54,
+	0x4f, 0x49, 0x53, 0x45, 0x10, 0x21, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f,
+	0x42, 0x41, 0x4c, 0x4d, 0x55, 0x4e, 0x47, 0x10, 0x22, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x4f, 0x52,
+	0x4c, 0x44, 0x5f, 0x43, 0x41, 0x43, 0x54, 0x55, 0x41, 0x52, 0x10, 0x23, 0x12, 0x10, 0x0a, 0x0c,
+	0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x43, 0x4f, 0x45, 0x55, 0x52, 0x4c, 0x10, 0x24, 0x12, 0x10,
+	0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x46, 0x41, 0x45, 0x52, 0x49, 0x45, 0x10, 0x25,
+	0x12, 0x13, 0x0a, 0x0f, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x47, 0x49, 0x4c, 0x47, 0x41, 0x4d,
+	0x45, 0x53, 0x48, 0x10, 0x26, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x47,
+	0x4f, 0x42, 0x4c, 0x49, 0x4e, 0x10, 0x27, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44,
+	0x5f, 0x4a, 0x45, 0x4e, 0x4f, 0x56, 0x41, 0x10, 0x28, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f, 0x52,
+	0x4c, 0x44, 0x5f, 0x4d, 0x41, 0x54, 0x45, 0x55, 0x53, 0x10, 0x29, 0x12, 0x16, 0x0a, 0x12, 0x57,
+	0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x4d, 0x49, 0x44, 0x47, 0x41, 0x52, 0x44, 0x53, 0x4f, 0x52, 0x4d,
+	0x52, 0x10, 0x2a, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x53, 0x41, 0x52,
+	0x47, 0x41, 0x54, 0x41, 0x4e, 0x41, 0x53, 0x10, 0x2b, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x4f, 0x52,
+	0x4c, 0x44, 0x5f, 0x53, 0x49, 0x52, 0x45, 0x4e, 0x10, 0x2c, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f,
+	0x52, 0x4c, 0x44, 0x5f, 0x5a, 0x41, 0x4c, 0x45, 0x52, 0x41, 0x10, 0x2d, 0x12, 0x12, 0x0a, 0x0e,
+	0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x42, 0x45, 0x48, 0x45, 0x4d, 0x4f, 0x54, 0x48, 0x10, 0x2e,
+	0x12, 0x13, 0x0a, 0x0f, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x42, 0x52, 0x59, 0x4e, 0x48, 0x49,
+	0x4c, 0x44, 0x52, 0x10, 0x2f, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x44,
+	0x49, 0x41, 0x42, 0x4f, 0x4c, 0x4f, 0x53, 0x10, 0x30, 0x12, 0x13, 0x0a, 0x0f, 0x57, 0x4f, 0x52,
+	0x4c, 0x44, 0x5f, 0x45, 0x58, 0x43, 0x41, 0x4c, 0x49, 0x42, 0x55, 0x52, 0x10, 0x31, 0x12, 0x10,
+	0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x45, 0x58, 0x4f, 0x44, 0x55, 0x53, 0x10, 0x32,
+	0x12, 0x11, 0x0a, 0x0d, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x46, 0x41, 0x4d, 0x46, 0x52, 0x49,
+	0x54, 0x10, 0x33, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x48, 0x59, 0x50,
+	0x45, 0x52, 0x49, 0x4f, 0x4e, 0x10, 0x34, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x4f, 0x52, 0x4c, 0x44,
+	0x5f, 0x4c, 0x41, 0x4d, 0x49, 0x41, 0x10, 0x35, 0x12, 0x13, 0x0a, 0x0f, 0x57, 0x4f, 0x52, 0x4c,
+	0x44, 0x5f, 0x4c, 0x45, 0x56, 0x49, 0x41, 0x54, 0x48, 0x41, 0x4e, 0x10, 0x36, 0x12, 0x11, 0x0a,
+	0x0d, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x4d, 0x41, 0x4c, 0x42, 0x4f, 0x52, 0x4f, 0x10, 0x37,
+	0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x55, 0x4c, 0x54, 0x52, 0x4f, 0x53,
+	0x10, 0x38, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x43, 0x45, 0x52, 0x42,
+	0x45, 0x52, 0x55, 0x53, 0x10, 0x39, 0x12, 0x0e, 0x0a, 0x0a, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f,
+	0x4c, 0x49, 0x43, 0x48, 0x10, 0x3a, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f,
+	0x4c, 0x4f, 0x55, 0x49, 0x53, 0x4f, 0x49, 0x58, 0x10, 0x3b, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x4f,
+	0x52, 0x4c, 0x44, 0x5f, 0x4d, 0x4f, 0x4f, 0x47, 0x4c, 0x45, 0x10, 0x3c, 0x12, 0x0e, 0x0a, 0x0a,
+	0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x4f, 0x44, 0x49, 0x4e, 0x10, 0x3d, 0x12, 0x0f, 0x0a, 0x0b,
+	0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x4f, 0x4d, 0x45, 0x47, 0x41, 0x10, 0x3e, 0x12, 0x11, 0x0a,
+	0x0d, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x50, 0x48, 0x4f, 0x45, 0x4e, 0x49, 0x58, 0x10, 0x3f,
+	0x12, 0x12, 0x0a, 0x0e, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x52, 0x41, 0x47, 0x4e, 0x41, 0x52,
+	0x4f, 0x4b, 0x10, 0x40, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x53, 0x48,
+	0x49, 0x56, 0x41, 0x10, 0x41, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x5f, 0x5a,
+	0x4f, 0x44, 0x49, 0x41, 0x52, 0x4b, 0x10, 0x42, 0x2a, 0x82, 0x01, 0x0a, 0x04, 0x52, 0x61, 0x63,
+	0x65, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x41, 0x43, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x41, 0x43, 0x45, 0x5f,
+	0x48, 0x59, 0x55, 0x52, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x41, 0x43, 0x45, 0x5f, 0x45,
+	0x4c, 0x45, 0x5a, 0x45, 0x4e, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x41, 0x43, 0x45, 0x5f,
+	0x4c, 0x41, 0x4c, 0x41, 0x46, 0x45, 0x4c, 0x4c, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x41,
+	0x43, 0x45, 0x5f, 0x4d, 0x49, 0x51, 0x4f, 0x54, 0x45, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x52,
+	0x41, 0x43, 0x45, 0x5f, 0x52, 0x4f, 0x45, 0x47, 0x41, 0x44, 0x59, 0x4e, 0x10, 0x05, 0x12, 0x0d,
+	0x0a, 0x09, 0x52, 0x41, 0x43, 0x45, 0x5f, 0x41, 0x55, 0x52, 0x41, 0x10, 0x06, 0x2a, 0x84, 0x02,
+	0x0a, 0x04, 0x43, 0x6c, 0x61, 0x6e, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4c, 0x41, 0x4e, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e,
+	0x43, 0x4c, 0x41, 0x4e, 0x5f, 0x4d, 0x49, 0x44, 0x4c, 0x41, 0x4e, 0x44, 0x45, 0x52, 0x10, 0x01,
+	0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4c, 0x41, 0x4e, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x4c, 0x41, 0x4e,
+	0x44, 0x45, 0x52, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4c, 0x41, 0x4e, 0x5f, 0x57, 0x49,
+	0x4c, 0x44, 0x57, 0x4f, 0x4f, 0x44, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4c, 0x41, 0x4e,
+	0x5f, 0x44, 0x55, 0x53, 0x4b, 0x57, 0x49, 0x47, 0x48, 0x54, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f,
+	0x43, 0x4c, 0x41, 0x4e, 0x5f, 0x50, 0x4c, 0x41, 0x49, 0x4e, 0x53, 0x46, 0x4f, 0x4c, 0x4b, 0x10,
+	0x05, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4c, 0x41, 0x4e, 0x5f, 0x44, 0x55, 0x4e, 0x45, 0x53, 0x46,
+	0x4f, 0x4c, 0x4b, 0x10, 0x06, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4c, 0x41, 0x4e, 0x5f, 0x53, 0x55,
+	0x4e, 0x53, 0x45, 0x45, 0x4b, 0x45, 0x52, 0x10, 0x07, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4c, 0x41,
+	0x4e, 0x5f, 0x4d, 0x4f, 0x4f, 0x4e, 0x4b, 0x45, 0x45, 0x50, 0x45, 0x52, 0x10, 0x08, 0x12, 0x10,
+	0x0a, 0x0c, 0x43, 0x4c, 0x41, 0x4e, 0x5f, 0x53, 0x45, 0x41, 0x57, 0x4f, 0x4c, 0x46, 0x10, 0x09,
+	0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4c, 0x41, 0x4e, 0x5f, 0x48, 0x45, 0x4c, 0x4c, 0x53, 0x47, 0x55,
+	0x41, 0x52, 0x44, 0x10, 0x0a, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4c, 0x41, 0x4e, 0x5f, 0x52, 0x41,
+	0x45, 0x4e, 0x10, 0x0b, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4c, 0x41, 0x4e, 0x5f, 0x58, 0x41, 0x45,
+	0x4c, 0x41, 0x10, 0x0c, 0x2a, 0xa9, 0x02, 0x0a, 0x08, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61,
+	0x6e, 0x12, 0x18, 0x0a, 0x14, 0x47, 0x55, 0x41, 0x52, 0x44, 0x49, 0x41, 0x4e, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x47,
+	0x55, 0x41, 0x52, 0x44, 0x49, 0x41, 0x4e, 0x5f, 0x48, 0x41, 0x4c, 0x4f, 0x4e, 0x45, 0x10, 0x01,
+	0x12, 0x15, 0x0a, 0x11, 0x47, 0x55, 0x41, 0x52, 0x44, 0x49, 0x41, 0x4e, 0x5f, 0x4d, 0x45, 0x4e,
+	0x50, 0x48, 0x49, 0x4e, 0x41, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x47, 0x55, 0x41, 0x52, 0x44,
+	0x49, 0x41, 0x4e, 0x5f, 0x54, 0x48, 0x41, 0x4c, 0x49, 0x41, 0x4b, 0x10, 0x03, 0x12, 0x13, 0x0a,
+	0x0f, 0x47, 0x55, 0x41, 0x52, 0x44, 0x49, 0x41, 0x4e, 0x5f, 0x4e, 0x59, 0x4d, 0x45, 0x49, 0x41,
+	0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x47, 0x55, 0x41, 0x52, 0x44, 0x49, 0x41, 0x4e, 0x5f, 0x4c,
+	0x4c, 0x59, 0x4d, 0x4c, 0x41, 0x45, 0x4e, 0x10, 0x05, 0x12, 0x13, 0x0a, 0x0f, 0x47, 0x55, 0x41,
+	0x52, 0x44, 0x49, 0x41, 0x4e, 0x5f, 0x4f, 0x53, 0x43, 0x48, 0x4f, 0x4e, 0x10, 0x06, 0x12, 0x14,
+	0x0a, 0x10, 0x47, 0x55, 0x41, 0x52, 0x44, 0x49, 0x41, 0x4e, 0x5f, 0x42, 0x59, 0x52, 0x45, 0x47,
+	0x4f, 0x54, 0x10, 0x07, 0x12, 0x13, 0x0a, 0x0f, 0x47, 0x55, 0x41, 0x52, 0x44, 0x49, 0x41, 0x4e,
+	0x5f, 0x52, 0x48, 0x41, 0x4c, 0x47, 0x52, 0x10, 0x08, 0x12, 0x13, 0x0a, 0x0f, 0x47, 0x55, 0x41,
+	0x52, 0x44, 0x49, 0x41, 0x4e, 0x5f, 0x41, 0x5a, 0x45, 0x59, 0x4d, 0x41, 0x10, 0x09, 0x12, 0x15,
+	0x0a, 0x11, 0x47, 0x55, 0x41, 0x52, 0x44, 0x49, 0x41, 0x4e, 0x5f, 0x4e, 0x41, 0x4c, 0x44, 0x54,
+	0x48, 0x41, 0x4c, 0x10, 0x0a, 0x12, 0x14, 0x0a, 0x10, 0x47, 0x55, 0x41, 0x52, 0x44, 0x49, 0x41,
+	0x4e, 0x5f, 0x4e, 0x4f, 0x50, 0x48, 0x49, 0x43, 0x41, 0x10, 0x0b, 0x12, 0x13, 0x0a, 0x0f, 0x47,
+	0x55, 0x41, 0x52, 0x44, 0x49, 0x41, 0x4e, 0x5f, 0x41, 0x4c, 0x54, 0x48, 0x59, 0x4b, 0x10, 0x0c,
+	0x2a, 0x6c, 0x0a, 0x09, 0x43, 0x69, 0x74, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a,
+	0x16, 0x43, 0x49, 0x54, 0x59, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x49, 0x54,
+	0x59, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x47, 0x52, 0x49, 0x44, 0x41, 0x4e, 0x49, 0x41,
+	0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x49, 0x54, 0x59, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x5f, 0x55, 0x4c, 0x44, 0x41, 0x48, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x49, 0x54, 0x59,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x53, 0x41, 0x10, 0x03, 0x2a, 0x7e,
+	0x0a, 0x0c, 0x47, 0x72, 0x61, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x12, 0x1d,
+	0x0a, 0x19, 0x47, 0x52, 0x41, 0x4e, 0x44, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a,
+	0x17, 0x47, 0x52, 0x41, 0x4e, 0x44, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x4d,
+	0x41, 0x45, 0x4c, 0x53, 0x54, 0x52, 0x4f, 0x4d, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x47, 0x52,
+	0x41, 0x4e, 0x44, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x41, 0x44, 0x44, 0x45,
+	0x52, 0x53, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x47, 0x52, 0x41, 0x4e, 0x44, 0x5f, 0x43, 0x4f,
+	0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x46, 0x4c, 0x41, 0x4d, 0x45, 0x53, 0x10, 0x03, 0x2a, 0xec,
+	0x02, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x13, 0x0a, 0x0f, 0x4a, 0x4f, 0x42, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x4a,
+	0x4f, 0x42, 0x5f, 0x50, 0x4c, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f,
+	0x57, 0x41, 0x52, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x44, 0x52, 0x4b,
+	0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x57, 0x48, 0x4d, 0x10, 0x04, 0x12,
+	0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x43, 0x48, 0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07,
+	0x4a, 0x4f, 0x42, 0x5f, 0x41, 0x53, 0x54, 0x10, 0x06, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42,
+	0x5f, 0x4d, 0x4e, 0x4b, 0x10, 0x07, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x44, 0x52,
+	0x47, 0x10, 0x08, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x4e, 0x49, 0x4e, 0x10, 0x09,
+	0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x41, 0x4d, 0x10, 0x0a, 0x12, 0x0b, 0x0a,
+	0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x42, 0x52, 0x44, 0x10, 0x0b, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f,
+	0x42, 0x5f, 0x4d, 0x43, 0x48, 0x10, 0x0c, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x42,
+	0x4c, 0x4d, 0x10, 0x0d, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x4d, 0x4e, 0x10,
+	0x0e, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x52, 0x44, 0x4d, 0x10, 0x0f, 0x12, 0x0b,
+	0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x43, 0x52, 0x50, 0x10, 0x10, 0x12, 0x0b, 0x0a, 0x07, 0x4a,
+	0x4f, 0x42, 0x5f, 0x42, 0x53, 0x4d, 0x10, 0x11, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f,
+	0x41, 0x52, 0x4d, 0x10, 0x12, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x47, 0x53, 0x4d,
+	0x10, 0x13, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x4c, 0x54, 0x57, 0x10, 0x14, 0x12,
+	0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x57, 0x56, 0x52, 0x10, 0x15, 0x12, 0x0b, 0x0a, 0x07,
+	0x4a, 0x4f, 0x42, 0x5f, 0x41, 0x4c, 0x43, 0x10, 0x16, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42,
+	0x5f, 0x43, 0x55, 0x4c, 0x10, 0x17, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x4d, 0x49,
+	0x4e, 0x10, 0x18, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x42, 0x4f, 0x54, 0x10, 0x19,
+	0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x42, 0x5f, 0x46, 0x53, 0x48, 0x10, 0x1a, 0x32, 0x9f, 0x03,
+	0x0a, 0x05, 0x47, 0x75, 0x62, 0x61, 0x6c, 0x12, 0x3c, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x43, 0x68,
+	0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x43, 0x68, 0x61, 0x72,
+	0x61, 0x63, 0x74, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x73, 0x12, 0x18, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x2e, 0x67,
+	0x75, 0x62, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x68, 0x61, 0x72, 0x61,
+	0x63, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67,
+	0x75, 0x62, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x68, 0x61, 0x72, 0x61,
+	0x63, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a,
+	0x0c, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x12, 0x1a, 0x2e,
+	0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f,
+	0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x67, 0x75, 0x62, 0x61,
+	0x6c, 0x2e, 0x54, 0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x47,
+	0x65, 0x74, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e,
+	0x47, 0x65, 0x74, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x15, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65,
+	0x72, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12, 0x1a, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x45,
+	0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2e, 0x45, 0x6e, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69,
+	0x63, 0x6c, 0x61, 0x63, 0x2f, 0x67, 0x75, 0x62, 0x61, 0x6c, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x67,
+	0x75, 0x62, 0x61, 0x6c, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gubal_proto_rawDescOnce sync.Once
+	file_gubal_proto_rawDescData = file_gubal_proto_rawDesc
+)
+
+func file_gubal_proto_rawDescGZIP() []byte {
+	file_gubal_proto_rawDescOnce.Do(func() {
+		file_gubal_proto_rawDescData = protoimpl.X.CompressGZIP(file_gubal_proto_rawDescData)
+	})
+	return file_gubal_proto_rawDescData
+}
+
+var file_gubal_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_gubal_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_gubal_proto_goTypes = []interface{}{
+	(CharacterSort)(0),               // 0: gubal.CharacterSort
+	(World)(0),                       // 1: gubal.World
+	(Race)(0),                        // 2: gubal.Race
+	(Clan)(0),                        // 3: gubal.Clan
+	(Guardian)(0),                    // 4: gubal.Guardian
+	(CityState)(0),                   // 5: gubal.CityState
+	(GrandCompany)(0),                // 6: gubal.GrandCompany
+	(Job)(0),                         // 7: gubal.Job
+	(*Character)(nil),                // 8: gubal.Character
+	(*Level)(nil),                    // 9: gubal.Level
+	(*CharacterTitle)(nil),           // 10: gubal.CharacterTitle
+	(*Tombstone)(nil),                // 11: gubal.Tombstone
+	(*LevelRange)(nil),               // 12: gubal.LevelRange
+	(*GetCharacterRequest)(nil),      // 13: gubal.GetCharacterRequest
+	(*ListLevelsRequest)(nil),        // 14: gubal.ListLevelsRequest
+	(*ListLevelsResponse)(nil),       // 15: gubal.ListLevelsResponse
+	(*SearchCharactersRequest)(nil),  // 16: gubal.SearchCharactersRequest
+	(*SearchCharactersResponse)(nil), // 17: gubal.SearchCharactersResponse
+	(*GetTombstoneRequest)(nil),      // 18: gubal.GetTombstoneRequest
+	(*GetTitleRequest)(nil),          // 19: gubal.GetTitleRequest
+	(*EnqueueFetchRequest)(nil),      // 20: gubal.EnqueueFetchRequest
+	(*EnqueueFetchResponse)(nil),     // 21: gubal.EnqueueFetchResponse
+	(*timestamppb.Timestamp)(nil),    // 22: google.protobuf.Timestamp
+}
+var file_gubal_proto_depIdxs = []int32{
+	22, // 0: gubal.Character.created_at:type_name -> google.protobuf.Timestamp
+	22, // 1: gubal.Character.updated_at:type_name -> google.protobuf.Timestamp
+	22, // 2: gubal.Character.seen_at:type_name -> google.protobuf.Timestamp
+	2,  // 3: gubal.Character.race:type_name -> gubal.Race
+	3,  // 4: gubal.Character.clan:type_name -> gubal.Clan
+	4,  // 5: gubal.Character.guardian:type_name -> gubal.Guardian
+	5,  // 6: gubal.Character.city_state:type_name -> gubal.CityState
+	1,  // 7: gubal.Character.world:type_name -> gubal.World
+	10, // 8: gubal.Character.title:type_name -> gubal.CharacterTitle
+	6,  // 9: gubal.Character.gc:type_name -> gubal.GrandCompany
+	7,  // 10: gubal.Level.job:type_name -> gubal.Job
+	22, // 11: gubal.Level.created_at:type_name -> google.protobuf.Timestamp
+	22, // 12: gubal.Level.updated_at:type_name -> google.protobuf.Timestamp
+	22, // 13: gubal.CharacterTitle.created_at:type_name -> google.protobuf.Timestamp
+	22, // 14: gubal.Tombstone.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 15: gubal.LevelRange.job:type_name -> gubal.Job
+	9,  // 16: gubal.ListLevelsResponse.levels:type_name -> gubal.Level
+	1,  // 17: gubal.SearchCharactersRequest.world:type_name -> gubal.World
+	2,  // 18: gubal.SearchCharactersRequest.race:type_name -> gubal.Race
+	3,  // 19: gubal.SearchCharactersRequest.clan:type_name -> gubal.Clan
+	4,  // 20: gubal.SearchCharactersRequest.guardian:type_name -> gubal.Guardian
+	5,  // 21: gubal.SearchCharactersRequest.city_state:type_name -> gubal.CityState
+	6,  // 22: gubal.SearchCharactersRequest.gc:type_name -> gubal.GrandCompany
+	12, // 23: gubal.SearchCharactersRequest.levels:type_name -> gubal.LevelRange
+	0,  // 24: gubal.SearchCharactersRequest.sort:type_name -> gubal.CharacterSort
+	8,  // 25: gubal.SearchCharactersResponse.characters:type_name -> gubal.Character
+	13, // 26: gubal.Gubal.GetCharacter:input_type -> gubal.GetCharacterRequest
+	14, // 27: gubal.Gubal.ListLevels:input_type -> gubal.ListLevelsRequest
+	16, // 28: gubal.Gubal.SearchCharacters:input_type -> gubal.SearchCharactersRequest
+	18, // 29: gubal.Gubal.GetTombstone:input_type -> gubal.GetTombstoneRequest
+	19, // 30: gubal.Gubal.GetTitle:input_type -> gubal.GetTitleRequest
+	20, // 31: gubal.Gubal.EnqueueFetch:input_type -> gubal.EnqueueFetchRequest
+	8,  // 32: gubal.Gubal.GetCharacter:output_type -> gubal.Character
+	15, // 33: gubal.Gubal.ListLevels:output_type -> gubal.ListLevelsResponse
+	17, // 34: gubal.Gubal.SearchCharacters:output_type -> gubal.SearchCharactersResponse
+	11, // 35: gubal.Gubal.GetTombstone:output_type -> gubal.Tombstone
+	10, // 36: gubal.Gubal.GetTitle:output_type -> gubal.CharacterTitle
+	21, // 37: gubal.Gubal.EnqueueFetch:output_type -> gubal.EnqueueFetchResponse
+	32, // [32:38] is the sub-list for method output_type
+	26, // [26:32] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_gubal_proto_init() }
+func file_gubal_proto_init() {
+	if File_gubal_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gubal_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Character); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Level); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CharacterTitle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tombstone); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LevelRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCharacterRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListLevelsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListLevelsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchCharactersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchCharactersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTombstoneRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTitleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnqueueFetchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gubal_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnqueueFetchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gubal_proto_rawDesc,
+			NumEnums:      8,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gubal_proto_goTypes,
+		DependencyIndexes: file_gubal_proto_depIdxs,
+		EnumInfos:         file_gubal_proto_enumTypes,
+		MessageInfos:      file_gubal_proto_msgTypes,
+	}.Build()
+	File_gubal_proto = out.File
+	file_gubal_proto_rawDesc = nil
+	file_gubal_proto_goTypes = nil
+	file_gubal_proto_depIdxs = nil
+}
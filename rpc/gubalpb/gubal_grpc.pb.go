@@ -0,0 +1,310 @@
+// Protobuf schema for gubal's gRPC API. Regenerate the Go code with `go generate`.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.23.4
+// source: gubal.proto
+
+package gubalpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Gubal_GetCharacter_FullMethodName     = "/gubal.Gubal/GetCharacter"
+	Gubal_ListLevels_FullMethodName       = "/gubal.Gubal/ListLevels"
+	Gubal_SearchCharacters_FullMethodName = "/gubal.Gubal/SearchCharacters"
+	Gubal_GetTombstone_FullMethodName     = "/gubal.Gubal/GetTombstone"
+	Gubal_GetTitle_FullMethodName         = "/gubal.Gubal/GetTitle"
+	Gubal_EnqueueFetch_FullMethodName     = "/gubal.Gubal/EnqueueFetch"
+)
+
+// GubalClient is the client API for Gubal service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GubalClient interface {
+	// GetCharacter returns a character, with its title. Fails with NOT_FOUND if it doesn't exist,
+	// or if it has a tombstone.
+	GetCharacter(ctx context.Context, in *GetCharacterRequest, opts ...grpc.CallOption) (*Character, error)
+	// ListLevels returns all of a character's levels, ordered by job.
+	ListLevels(ctx context.Context, in *ListLevelsRequest, opts ...grpc.CallOption) (*ListLevelsResponse, error)
+	// SearchCharacters returns a page of characters matching a query.
+	SearchCharacters(ctx context.Context, in *SearchCharactersRequest, opts ...grpc.CallOption) (*SearchCharactersResponse, error)
+	// GetTombstone returns a character's tombstone; NOT_FOUND if it doesn't have one.
+	GetTombstone(ctx context.Context, in *GetTombstoneRequest, opts ...grpc.CallOption) (*Tombstone, error)
+	// GetTitle returns a title by ID.
+	GetTitle(ctx context.Context, in *GetTitleRequest, opts ...grpc.CallOption) (*CharacterTitle, error)
+	// EnqueueFetch queues up a character to be fetched.
+	EnqueueFetch(ctx context.Context, in *EnqueueFetchRequest, opts ...grpc.CallOption) (*EnqueueFetchResponse, error)
+}
+
+type gubalClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGubalClient(cc grpc.ClientConnInterface) GubalClient {
+	return &gubalClient{cc}
+}
+
+func (c *gubalClient) GetCharacter(ctx context.Context, in *GetCharacterRequest, opts ...grpc.CallOption) (*Character, error) {
+	out := new(Character)
+	err := c.cc.Invoke(ctx, Gubal_GetCharacter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gubalClient) ListLevels(ctx context.Context, in *ListLevelsRequest, opts ...grpc.CallOption) (*ListLevelsResponse, error) {
+	out := new(ListLevelsResponse)
+	err := c.cc.Invoke(ctx, Gubal_ListLevels_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gubalClient) SearchCharacters(ctx context.Context, in *SearchCharactersRequest, opts ...grpc.CallOption) (*SearchCharactersResponse, error) {
+	out := new(SearchCharactersResponse)
+	err := c.cc.Invoke(ctx, Gubal_SearchCharacters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gubalClient) GetTombstone(ctx context.Context, in *GetTombstoneRequest, opts ...grpc.CallOption) (*Tombstone, error) {
+	out := new(Tombstone)
+	err := c.cc.Invoke(ctx, Gubal_GetTombstone_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gubalClient) GetTitle(ctx context.Context, in *GetTitleRequest, opts ...grpc.CallOption) (*CharacterTitle, error) {
+	out := new(CharacterTitle)
+	err := c.cc.Invoke(ctx, Gubal_GetTitle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gubalClient) EnqueueFetch(ctx context.Context, in *EnqueueFetchRequest, opts ...grpc.CallOption) (*EnqueueFetchResponse, error) {
+	out := new(EnqueueFetchResponse)
+	err := c.cc.Invoke(ctx, Gubal_EnqueueFetch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GubalServer is the server API for Gubal service.
+// All implementations must embed UnimplementedGubalServer
+// for forward compatibility
+type GubalServer interface {
+	// GetCharacter returns a character, with its title. Fails with NOT_FOUND if it doesn't exist,
+	// or if it has a tombstone.
+	GetCharacter(context.Context, *GetCharacterRequest) (*Character, error)
+	// ListLevels returns all of a character's levels, ordered by job.
+	ListLevels(context.Context, *ListLevelsRequest) (*ListLevelsResponse, error)
+	// SearchCharacters returns a page of characters matching a query.
+	SearchCharacters(context.Context, *SearchCharactersRequest) (*SearchCharactersResponse, error)
+	// GetTombstone returns a character's tombstone; NOT_FOUND if it doesn't have one.
+	GetTombstone(context.Context, *GetTombstoneRequest) (*Tombstone, error)
+	// GetTitle returns a title by ID.
+	GetTitle(context.Context, *GetTitleRequest) (*CharacterTitle, error)
+	// EnqueueFetch queues up a character to be fetched.
+	EnqueueFetch(context.Context, *EnqueueFetchRequest) (*EnqueueFetchResponse, error)
+	mustEmbedUnimplementedGubalServer()
+}
+
+// UnimplementedGubalServer must be embedded to have forward compatible implementations.
+type UnimplementedGubalServer struct {
+}
+
+func (UnimplementedGubalServer) GetCharacter(context.Context, *GetCharacterRequest) (*Character, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCharacter not implemented")
+}
+func (UnimplementedGubalServer) ListLevels(context.Context, *ListLevelsRequest) (*ListLevelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLevels not implemented")
+}
+func (UnimplementedGubalServer) SearchCharacters(context.Context, *SearchCharactersRequest) (*SearchCharactersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchCharacters not implemented")
+}
+func (UnimplementedGubalServer) GetTombstone(context.Context, *GetTombstoneRequest) (*Tombstone, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTombstone not implemented")
+}
+func (UnimplementedGubalServer) GetTitle(context.Context, *GetTitleRequest) (*CharacterTitle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTitle not implemented")
+}
+func (UnimplementedGubalServer) EnqueueFetch(context.Context, *EnqueueFetchRequest) (*EnqueueFetchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnqueueFetch not implemented")
+}
+func (UnimplementedGubalServer) mustEmbedUnimplementedGubalServer() {}
+
+// UnsafeGubalServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GubalServer will
+// result in compilation errors.
+type UnsafeGubalServer interface {
+	mustEmbedUnimplementedGubalServer()
+}
+
+func RegisterGubalServer(s grpc.ServiceRegistrar, srv GubalServer) {
+	s.RegisterService(&Gubal_ServiceDesc, srv)
+}
+
+func _Gubal_GetCharacter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCharacterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GubalServer).GetCharacter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gubal_GetCharacter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GubalServer).GetCharacter(ctx, req.(*GetCharacterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gubal_ListLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GubalServer).ListLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gubal_ListLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GubalServer).ListLevels(ctx, req.(*ListLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gubal_SearchCharacters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCharactersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GubalServer).SearchCharacters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gubal_SearchCharacters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GubalServer).SearchCharacters(ctx, req.(*SearchCharactersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gubal_GetTombstone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTombstoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GubalServer).GetTombstone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gubal_GetTombstone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GubalServer).GetTombstone(ctx, req.(*GetTombstoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gubal_GetTitle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTitleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GubalServer).GetTitle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gubal_GetTitle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GubalServer).GetTitle(ctx, req.(*GetTitleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gubal_EnqueueFetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueFetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GubalServer).EnqueueFetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gubal_EnqueueFetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GubalServer).EnqueueFetch(ctx, req.(*EnqueueFetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Gubal_ServiceDesc is the grpc.ServiceDesc for Gubal service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Gubal_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gubal.Gubal",
+	HandlerType: (*GubalServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCharacter",
+			Handler:    _Gubal_GetCharacter_Handler,
+		},
+		{
+			MethodName: "ListLevels",
+			Handler:    _Gubal_ListLevels_Handler,
+		},
+		{
+			MethodName: "SearchCharacters",
+			Handler:    _Gubal_SearchCharacters_Handler,
+		},
+		{
+			MethodName: "GetTombstone",
+			Handler:    _Gubal_GetTombstone_Handler,
+		},
+		{
+			MethodName: "GetTitle",
+			Handler:    _Gubal_GetTitle_Handler,
+		},
+		{
+			MethodName: "EnqueueFetch",
+			Handler:    _Gubal_EnqueueFetch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gubal.proto",
+}
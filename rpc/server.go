@@ -0,0 +1,220 @@
+// Package rpc implements gubal's gRPC service; see gubalpb/gubal.proto.
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/liclac/gubal/fetcher"
+	"github.com/liclac/gubal/lib"
+	"github.com/liclac/gubal/models"
+	"github.com/liclac/gubal/rpc/gubalpb"
+)
+
+var tracer = otel.Tracer("github.com/liclac/gubal/rpc")
+
+// Page size limits for SearchCharacters.
+const (
+	DefaultPageSize = models.DefaultSearchLimit
+	MaxPageSize     = 1000
+)
+
+// Server implements gubalpb.GubalServer.
+type Server struct {
+	gubalpb.UnimplementedGubalServer
+
+	ds  models.DataStore
+	pub lib.Publisher
+}
+
+var _ gubalpb.GubalServer = &Server{}
+
+// NewServer creates a Server backed by the given data store. Fetches are published to pub; if it's
+// nil, EnqueueFetch fails with UNAVAILABLE.
+func NewServer(ds models.DataStore, pub lib.Publisher) *Server {
+	return &Server{ds: ds, pub: pub}
+}
+
+// NewGRPCServer returns a gRPC server with a Server registered on it.
+func NewGRPCServer(ds models.DataStore, pub lib.Publisher) *grpc.Server {
+	srv := grpc.NewServer(grpc.UnaryInterceptor(errorInterceptor))
+	gubalpb.RegisterGubalServer(srv, NewServer(ds, pub))
+	return srv
+}
+
+// errorInterceptor turns errors that aren't gRPC statuses into them: missing records are
+// NOT_FOUND, and anything else is logged and turned into INTERNAL.
+func errorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return nil, err
+	}
+	switch {
+	case gorm.IsRecordNotFoundError(err):
+		return nil, status.Error(codes.NotFound, "not found")
+	case err == context.Canceled:
+		return nil, status.Error(codes.Canceled, err.Error())
+	case err == context.DeadlineExceeded:
+		return nil, status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	lib.GetLogger(ctx).Error("Internal server error", zap.String("method", info.FullMethod), zap.Error(err))
+	return nil, status.Error(codes.Internal, "internal server error")
+}
+
+// GetCharacter returns a character, with its title.
+func (s *Server) GetCharacter(ctx context.Context, req *gubalpb.GetCharacterRequest) (*gubalpb.Character, error) {
+	ch, err := s.ds.Characters().Get(ctx, req.Id)
+	if gorm.IsRecordNotFoundError(err) {
+		dead, err := s.ds.CharacterTombstones().Check(ctx, req.Id)
+		if err != nil {
+			return nil, err
+		}
+		if dead {
+			return nil, status.Error(codes.NotFound, "character does not exist")
+		}
+		return nil, status.Error(codes.NotFound, "character not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ch.TitleID.Valid {
+		if ch.Title, err = s.ds.CharacterTitles().Get(ctx, int(ch.TitleID.Int64)); err != nil {
+			return nil, err
+		}
+	}
+	return characterToProto(ch), nil
+}
+
+// ListLevels returns all of a character's levels.
+func (s *Server) ListLevels(ctx context.Context, req *gubalpb.ListLevelsRequest) (*gubalpb.ListLevelsResponse, error) {
+	if _, err := s.ds.Characters().Get(ctx, req.CharacterId); err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, status.Error(codes.NotFound, "character not found")
+		}
+		return nil, err
+	}
+	lvls, err := s.ds.Levels().List(ctx, req.CharacterId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gubalpb.ListLevelsResponse{}
+	for _, lvl := range lvls {
+		resp.Levels = append(resp.Levels, levelToProto(lvl))
+	}
+	return resp, nil
+}
+
+// SearchCharacters returns a page of characters matching a query.
+func (s *Server) SearchCharacters(ctx context.Context, req *gubalpb.SearchCharactersRequest) (*gubalpb.SearchCharactersResponse, error) {
+	if req.PageSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page size: %d", req.PageSize)
+	}
+	q, err := queryFromProto(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit > MaxPageSize {
+		q.Limit = MaxPageSize
+	}
+
+	chars, err := s.ds.Characters().Search(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gubalpb.SearchCharactersResponse{}
+	for _, ch := range chars {
+		resp.Characters = append(resp.Characters, characterToProto(ch))
+	}
+	if len(chars) == q.Limit {
+		resp.NextPageToken = q.CursorFor(chars[len(chars)-1])
+	}
+	return resp, nil
+}
+
+// GetTombstone returns a character's tombstone.
+func (s *Server) GetTombstone(ctx context.Context, req *gubalpb.GetTombstoneRequest) (*gubalpb.Tombstone, error) {
+	ts, err := s.ds.CharacterTombstones().Get(ctx, req.Id)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, status.Error(codes.NotFound, "tombstone not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tombstoneToProto(ts), nil
+}
+
+// GetTitle returns a title by ID.
+func (s *Server) GetTitle(ctx context.Context, req *gubalpb.GetTitleRequest) (*gubalpb.CharacterTitle, error) {
+	title, err := s.ds.CharacterTitles().Get(ctx, int(req.Id))
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, status.Error(codes.NotFound, "title not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return titleToProto(title), nil
+}
+
+// EnqueueFetch queues up a character to be fetched.
+func (s *Server) EnqueueFetch(ctx context.Context, req *gubalpb.EnqueueFetchRequest) (_ *gubalpb.EnqueueFetchResponse, rerr error) {
+	// Continue the caller's trace, if any, so it follows the job through to the fetcher.
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	}
+	ctx, span := tracer.Start(ctx, "gubal.Gubal/EnqueueFetch", trace.WithSpanKind(trace.SpanKindServer))
+	defer func() { lib.EndSpan(span, rerr) }()
+
+	if s.pub == nil {
+		return nil, status.Error(codes.Unavailable, "fetching is not available")
+	}
+	if req.CharacterId <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid character id: %d", req.CharacterId)
+	}
+	body, err := json.Marshal(fetcher.NewFetchMessage(ctx, fetcher.FetchCharacterJob{ID: req.CharacterId, Force: req.Force}))
+	if err != nil {
+		return nil, err
+	}
+	topic := fetcher.FetchTopic
+	if req.Priority {
+		topic = fetcher.FetchPriorityTopic
+	}
+	if err := s.pub.Publish(topic, body); err != nil {
+		return nil, err
+	}
+	return &gubalpb.EnqueueFetchResponse{}, nil
+}
+
+// metadataCarrier lets a propagator read trace context from gRPC metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) { metadata.MD(c).Set(key, value) }
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,251 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/liclac/gubal/fetcher"
+	"github.com/liclac/gubal/lib"
+	"github.com/liclac/gubal/models"
+	"github.com/liclac/gubal/rpc/gubalpb"
+)
+
+// newTestClient serves a Server over an in-memory connection, and returns a client for it.
+func newTestClient(t *testing.T, ds models.DataStore, pub lib.Publisher) gubalpb.GubalClient {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := NewGRPCServer(ds, pub)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return gubalpb.NewGubalClient(conn)
+}
+
+var (
+	testSpansOnce     sync.Once
+	testSpansRecorder *tracetest.SpanRecorder
+)
+
+// recordSpans installs a global tracer provider recording every span, and W3C trace propagation.
+func recordSpans() *tracetest.SpanRecorder {
+	testSpansOnce.Do(func() {
+		testSpansRecorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(testSpansRecorder)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+	return testSpansRecorder
+}
+
+func TestEnumMaps(t *testing.T) {
+	for _, w := range models.Worlds {
+		v, err := worlds.FromProto(worlds.ToProto(w))
+		require.NoError(t, err)
+		assert.Equal(t, w, v)
+	}
+	assert.Equal(t, gubalpb.World_WORLD_ULTROS, worlds.ToProto(models.Ultros))
+	assert.Equal(t, gubalpb.Clan_CLAN_SUNSEEKER, clans.ToProto(models.MiqoteSunSeeker))
+	assert.Equal(t, gubalpb.Job_JOB_PLD, jobs.ToProto(models.PLD))
+
+	v, err := worlds.FromProto(0)
+	require.NoError(t, err)
+	assert.Equal(t, models.World(""), v)
+
+	_, err = worlds.FromProto(9999)
+	assert.EqualError(t, err, "unknown world: 9999")
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	ds := models.NewMemoryDataStore()
+
+	var published []string
+	var topics []string
+	client := newTestClient(t, ds, lib.PublisherFunc(func(topic string, body []byte) error {
+		topics = append(topics, topic)
+		published = append(published, string(body))
+		return nil
+	}))
+
+	title, err := ds.CharacterTitles().GetOrCreate(ctx, "Khloe's Friend")
+	require.NoError(t, err)
+	gc := models.Maelstrom
+	require.NoError(t, ds.Characters().Save(ctx, &models.Character{
+		ID:        7248246,
+		FirstName: "Emi",
+		LastName:  "Hawke",
+		Race:      models.AuRa,
+		Clan:      models.AuRaRaen,
+		World:     models.Ultros,
+		Title:     title,
+		GC:        &gc,
+		GCRank:    9,
+	}))
+	require.NoError(t, ds.Characters().Save(ctx, &models.Character{ID: 1000, FirstName: "Emi", LastName: "Aoki", World: models.Ultros}))
+	require.NoError(t, ds.Levels().Set(ctx, &models.Level{CharacterID: 7248246, Job: models.SCH, Level: 70}))
+	require.NoError(t, ds.Levels().Set(ctx, &models.Level{CharacterID: 7248246, Job: models.PLD, Level: 62}))
+	require.NoError(t, ds.CharacterTombstones().Create(ctx, 1234))
+
+	t.Run("GetCharacter", func(t *testing.T) {
+		ch, err := client.GetCharacter(ctx, &gubalpb.GetCharacterRequest{Id: 7248246})
+		require.NoError(t, err)
+		assert.Equal(t, "Emi", ch.FirstName)
+		assert.Equal(t, gubalpb.Race_RACE_AURA, ch.Race)
+		assert.Equal(t, gubalpb.Clan_CLAN_RAEN, ch.Clan)
+		assert.Equal(t, gubalpb.World_WORLD_ULTROS, ch.World)
+		assert.Equal(t, gubalpb.GrandCompany_GRAND_COMPANY_MAELSTROM, ch.Gc)
+		assert.Equal(t, int32(9), ch.GcRank)
+		assert.Equal(t, "Khloe's Friend", ch.Title.GetTitle())
+
+		_, err = client.GetCharacter(ctx, &gubalpb.GetCharacterRequest{Id: 1})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = client.GetCharacter(ctx, &gubalpb.GetCharacterRequest{Id: 1234})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "character does not exist", status.Convert(err).Message())
+	})
+
+	t.Run("ListLevels", func(t *testing.T) {
+		resp, err := client.ListLevels(ctx, &gubalpb.ListLevelsRequest{CharacterId: 7248246})
+		require.NoError(t, err)
+		require.Len(t, resp.Levels, 2)
+		assert.Equal(t, gubalpb.Job_JOB_PLD, resp.Levels[0].Job)
+		assert.Equal(t, int32(62), resp.Levels[0].Level)
+		assert.Equal(t, gubalpb.Job_JOB_SCH, resp.Levels[1].Job)
+
+		_, err = client.ListLevels(ctx, &gubalpb.ListLevelsRequest{CharacterId: 1})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("SearchCharacters", func(t *testing.T) {
+		resp, err := client.SearchCharacters(ctx, &gubalpb.SearchCharactersRequest{
+			Name:     "emi",
+			Sort:     gubalpb.CharacterSort_CHARACTER_SORT_LAST_NAME,
+			PageSize: 1,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Characters, 1)
+		assert.Equal(t, "Aoki", resp.Characters[0].LastName)
+		require.NotEmpty(t, resp.NextPageToken)
+
+		resp, err = client.SearchCharacters(ctx, &gubalpb.SearchCharactersRequest{
+			Name:      "emi",
+			Sort:      gubalpb.CharacterSort_CHARACTER_SORT_LAST_NAME,
+			PageSize:  1,
+			PageToken: resp.NextPageToken,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Characters, 1)
+		assert.Equal(t, "Hawke", resp.Characters[0].LastName)
+
+		resp, err = client.SearchCharacters(ctx, &gubalpb.SearchCharactersRequest{
+			World:  gubalpb.World_WORLD_ULTROS,
+			Levels: []*gubalpb.LevelRange{{Job: gubalpb.Job_JOB_SCH, Min: 60}},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Characters, 1)
+		assert.Equal(t, int64(7248246), resp.Characters[0].Id)
+		assert.Equal(t, "", resp.NextPageToken)
+
+		_, err = client.SearchCharacters(ctx, &gubalpb.SearchCharactersRequest{World: 9999})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "unknown world: 9999", status.Convert(err).Message())
+
+		_, err = client.SearchCharacters(ctx, &gubalpb.SearchCharactersRequest{
+			Levels: []*gubalpb.LevelRange{{Job: gubalpb.Job_JOB_SCH, Min: 60, Max: 50}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "level range ends before it starts: SCH:60-50", status.Convert(err).Message())
+
+		_, err = client.SearchCharacters(ctx, &gubalpb.SearchCharactersRequest{PageToken: "garbage"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("GetTombstone", func(t *testing.T) {
+		ts, err := client.GetTombstone(ctx, &gubalpb.GetTombstoneRequest{Id: 1234})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1234), ts.Id)
+
+		_, err = client.GetTombstone(ctx, &gubalpb.GetTombstoneRequest{Id: 1})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("GetTitle", func(t *testing.T) {
+		tl, err := client.GetTitle(ctx, &gubalpb.GetTitleRequest{Id: int32(title.ID)})
+		require.NoError(t, err)
+		assert.Equal(t, "Khloe's Friend", tl.Title)
+
+		_, err = client.GetTitle(ctx, &gubalpb.GetTitleRequest{Id: 100})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("EnqueueFetch", func(t *testing.T) {
+		published, topics = nil, nil
+		_, err := client.EnqueueFetch(ctx, &gubalpb.EnqueueFetchRequest{CharacterId: 7248246})
+		require.NoError(t, err)
+		_, err = client.EnqueueFetch(ctx, &gubalpb.EnqueueFetchRequest{CharacterId: 1000, Force: true, Priority: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{fetcher.FetchTopic, fetcher.FetchPriorityTopic}, topics)
+
+		require.Len(t, published, 2)
+		var msg fetcher.FetchMessage
+		require.NoError(t, json.Unmarshal([]byte(published[1]), &msg))
+		assert.Equal(t, &fetcher.FetchCharacterJob{ID: 1000, Force: true}, msg.Job)
+
+		_, err = client.EnqueueFetch(ctx, &gubalpb.EnqueueFetchRequest{CharacterId: 0})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("EnqueueFetchTrace", func(t *testing.T) {
+		spans := recordSpans()
+		published, topics = nil, nil
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		parentID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+		ctx := metadata.AppendToOutgoingContext(ctx, "traceparent", "00-"+traceID.String()+"-"+parentID.String()+"-01")
+		_, err := client.EnqueueFetch(ctx, &gubalpb.EnqueueFetchRequest{CharacterId: 7248246})
+		require.NoError(t, err)
+
+		// The job should carry the trace on, as a child of the handler's span, not the caller's.
+		var server sdktrace.ReadOnlySpan
+		for _, s := range spans.Ended() {
+			if s.SpanContext().TraceID() == traceID && s.Parent().SpanID() == parentID {
+				server = s
+			}
+		}
+		require.NotNil(t, server)
+		assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+		require.Len(t, published, 1)
+		var msg fetcher.FetchMessage
+		require.NoError(t, json.Unmarshal([]byte(published[0]), &msg))
+		sc := msg.SpanContext()
+		assert.Equal(t, traceID, sc.TraceID())
+		assert.Equal(t, server.SpanContext().SpanID(), sc.SpanID())
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		client := newTestClient(t, ds, nil)
+		_, err := client.EnqueueFetch(ctx, &gubalpb.EnqueueFetchRequest{CharacterId: 7248246})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}